@@ -10,13 +10,17 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"stet/cli/internal/baseline"
 	"stet/cli/internal/benchmark"
+	"stet/cli/internal/calibration"
 	"stet/cli/internal/commitmsg"
 	"stet/cli/internal/config"
 	"stet/cli/internal/erruser"
@@ -25,16 +29,22 @@ import (
 	"stet/cli/internal/history"
 	"stet/cli/internal/llm"
 	"stet/cli/internal/ollama"
+	"stet/cli/internal/rank"
 	"stet/cli/internal/run"
 	"stet/cli/internal/session"
 	"stet/cli/internal/skill"
 	"stet/cli/internal/stats"
 	"stet/cli/internal/version"
 
+	_ "stet/cli/internal/rag/cpp"    // register C/C++ resolver
+	_ "stet/cli/internal/rag/csharp" // register C# resolver
 	_ "stet/cli/internal/rag/go"     // register Go resolver for RAG symbol lookup
 	_ "stet/cli/internal/rag/java"   // register Java resolver
 	_ "stet/cli/internal/rag/js"     // register JavaScript/TypeScript resolver
+	_ "stet/cli/internal/rag/kotlin" // register Kotlin resolver
+	_ "stet/cli/internal/rag/php"    // register PHP resolver
 	_ "stet/cli/internal/rag/python" // register Python resolver
+	_ "stet/cli/internal/rag/ruby"   // register Ruby resolver
 	_ "stet/cli/internal/rag/rust"   // register Rust resolver
 	_ "stet/cli/internal/rag/swift"  // register Swift resolver
 )
@@ -102,34 +112,73 @@ func printLLMUnreachable(provider, baseURL string, err error) {
 	}
 }
 
-// activeFindings loads session from stateDir and returns findings not in DismissedIDs.
-func activeFindings(stateDir string) ([]findings.Finding, error) {
-	s, err := session.Load(stateDir)
-	if err != nil {
-		return nil, erruser.New("Could not load session.", err)
-	}
-	dismissed := make(map[string]struct{}, len(s.DismissedIDs))
-	for _, id := range s.DismissedIDs {
-		dismissed[id] = struct{}{}
+// addSessionFlag registers --session on a command that works on a review session.
+func addSessionFlag(cmd *cobra.Command) {
+	cmd.Flags().String("session", "", "Named review session to use (default: the unnamed session); see stet sessions")
+}
+
+// sessionFromFlag returns the validated --session name ("" is the default session).
+func sessionFromFlag(cmd *cobra.Command) (string, error) {
+	name, _ := cmd.Flags().GetString("session")
+	name = strings.TrimSpace(name)
+	if err := session.ValidateName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// sessionArg returns the --session argument for name in hints ("" for the default session).
+func sessionArg(name string) string {
+	if name == "" {
+		return ""
 	}
-	active := make([]findings.Finding, 0, len(s.Findings))
-	for _, f := range s.Findings {
-		if _, ok := dismissed[f.ID]; !ok {
+	return " --session " + name
+}
+
+// activeFindings loads the session in sessionDir and returns the findings that need attention
+// (open or accepted) with their status set. Dismissed, fixed and deferred findings are excluded
+// until a deferral's date has passed.
+func activeFindings(sessionDir string) ([]findings.Finding, error) {
+	all, err := sessionFindings(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]findings.Finding, 0, len(all))
+	for _, f := range all {
+		if findings.NeedsAttention(f, now) {
 			active = append(active, f)
 		}
 	}
 	return active, nil
 }
 
+// sessionFindings loads the session in sessionDir and returns all its findings with their current status.
+func sessionFindings(sessionDir string) ([]findings.Finding, error) {
+	s, err := session.Load(sessionDir)
+	if err != nil {
+		return nil, erruser.New("Could not load session.", err)
+	}
+	return session.FindingsWithStatus(&s, time.Now()), nil
+}
+
 // writeFindingsJSON writes {"findings": [...]} to w. Uses activeFindings so dismissed are excluded.
-func writeFindingsJSON(w io.Writer, stateDir string) error {
-	active, err := activeFindings(stateDir)
+func writeFindingsJSON(w io.Writer, sessionDir string) error {
+	active, err := activeFindings(sessionDir)
 	if err != nil {
 		return err
 	}
+	return writeFindingListJSON(w, active)
+}
+
+// writeFindingListJSON writes {"findings": [...]} for list to w.
+func writeFindingListJSON(w io.Writer, list []findings.Finding) error {
+	if list == nil {
+		list = []findings.Finding{}
+	}
 	payload := struct {
 		Findings []findings.Finding `json:"findings"`
-	}{Findings: active}
+	}{Findings: list}
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return erruser.New("Could not write findings.", err)
@@ -145,11 +194,16 @@ func writeFindingsJSON(w io.Writer, stateDir string) error {
 
 // writeFindingsHuman writes a human-readable summary to w: one line per finding (id  file:line  severity  message), then a summary line.
 // When stats is non-nil and EvalDurationNs > 0, the summary line includes " at Y tokens/sec.".
-func writeFindingsHuman(w io.Writer, stateDir string, stats *run.RunStats) error {
-	active, err := activeFindings(stateDir)
+func writeFindingsHuman(w io.Writer, sessionDir string, stats *run.RunStats) error {
+	active, err := activeFindings(sessionDir)
 	if err != nil {
 		return err
 	}
+	return writeFindingListHuman(w, active, stats)
+}
+
+// writeFindingListHuman writes list to w in the writeFindingsHuman format.
+func writeFindingListHuman(w io.Writer, active []findings.Finding, stats *run.RunStats) error {
 	for _, f := range active {
 		line := f.Line
 		if f.Range != nil {
@@ -184,28 +238,129 @@ func writeFindingsHuman(w io.Writer, stateDir string, stats *run.RunStats) error
 			}
 		}
 	}
+	if stats != nil && stats.Suppressed > 0 {
+		if _, err := fmt.Fprintf(w, "%d suppressed by stet:ignore comments.\n", stats.Suppressed); err != nil {
+			return erruser.New("Could not write findings.", err)
+		}
+	}
 	return nil
 }
 
-// writeFindingsWithIDs writes one line per active finding: id  file:line  severity  message.
-// Used by status --ids and list commands.
-func writeFindingsWithIDs(w io.Writer, stateDir string) error {
-	active, err := activeFindings(stateDir)
+// warnStaleSuppressions prints a warning to stderr for each stet:ignore directive
+// that covered reviewed lines but suppressed no finding.
+func warnStaleSuppressions(stats run.RunStats) {
+	for _, loc := range stats.StaleSuppressions {
+		fmt.Fprintf(os.Stderr, "Warning: stet:ignore at %s suppressed nothing in this review; it may be stale.\n", loc)
+	}
+}
+
+// listedFindings returns the active findings of the session in sessionDir, or every finding with its
+// status when all is true. When commit is non-empty, only findings from that commit (per-commit review) are returned.
+func listedFindings(sessionDir, commit string, all bool) ([]findings.Finding, error) {
+	load := activeFindings
+	if all {
+		load = sessionFindings
+	}
+	list, err := load(sessionDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, f := range active {
+	if commit == "" {
+		return list, nil
+	}
+	var out []findings.Finding
+	for _, f := range list {
+		if f.Commit == commit {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// addOrderFlags adds --sort and --top to cmd (list and status --ids).
+func addOrderFlags(cmd *cobra.Command) {
+	cmd.Flags().String("sort", "", "Order findings: rank (most important first), file or severity (default: review order)")
+	cmd.Flags().Int("top", 0, "Show only the first N findings after sorting (0 = all)")
+}
+
+// orderFindings applies --sort and --top from cmd to list. Findings are ranked (Rank set) when sorting by
+// rank or severity, or when withRank is true (JSON output). Ranking reads the session's diff and file history;
+// when git fails it warns and ranks with neutral signals.
+func orderFindings(cmd *cobra.Command, repoRoot string, s *session.Session, list []findings.Finding, withRank bool) ([]findings.Finding, error) {
+	by, _ := cmd.Flags().GetString("sort")
+	by = strings.ToLower(strings.TrimSpace(by))
+	if err := rank.ValidSort(by); err != nil {
+		return nil, err
+	}
+	top, _ := cmd.Flags().GetInt("top")
+	if top < 0 {
+		return nil, erruser.New("--top must be 0 or a positive number of findings.", nil)
+	}
+	if withRank || by == rank.SortRank || by == rank.SortSeverity {
+		head := s.LastReviewedAt
+		if head == "" {
+			head = "HEAD"
+		}
+		sig, err := rank.Collect(context.Background(), repoRoot, s.BaselineRef, head, list)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ranking without diff or file history: %v\n", err)
+		}
+		rank.Apply(list, sig)
+	}
+	rank.Order(list, by)
+	if top > 0 && len(list) > top {
+		list = list[:top]
+	}
+	return list, nil
+}
+
+// writeFindingsWithIDs writes one line per finding in list: id  file:line  severity  message,
+// followed by one indented line per comment (stet comment).
+// When all is true, each finding's status is appended (e.g. "[deferred until 2025-07-01]").
+// Used by status --ids and list commands.
+func writeFindingsWithIDs(w io.Writer, list []findings.Finding, all bool) error {
+	for _, f := range list {
 		line := f.Line
 		if f.Range != nil {
 			line = f.Range.Start
 		}
-		if _, err := fmt.Fprintf(w, "%s  %s:%d  %s  %s\n", findings.ShortID(f.ID), f.File, line, strings.ToUpper(string(f.Severity)), f.Message); err != nil {
+		status := ""
+		if all {
+			status = "  [" + string(f.Status)
+			if f.DeferredUntil != "" {
+				status += " until " + f.DeferredUntil
+			}
+			status += "]"
+		}
+		if _, err := fmt.Fprintf(w, "%s  %s:%d  %s  %s%s\n", findings.ShortID(f.ID), f.File, line, strings.ToUpper(string(f.Severity)), f.Message, status); err != nil {
 			return erruser.New("Could not write findings.", err)
 		}
+		for _, c := range f.Comments {
+			if _, err := fmt.Fprintf(w, "    %s\n", formatComment(c)); err != nil {
+				return erruser.New("Could not write findings.", err)
+			}
+		}
 	}
 	return nil
 }
 
+// formatComment returns "date time author: text" for a finding comment, with the
+// time in local time and the author falling back to the email.
+func formatComment(c findings.Comment) string {
+	at := c.At
+	if t, err := time.Parse(time.RFC3339, c.At); err == nil {
+		at = t.Local().Format("2006-01-02 15:04")
+	}
+	author := c.Author
+	if author == "" {
+		author = c.Email
+	}
+	if author == "" {
+		author = "unknown"
+	}
+	return fmt.Sprintf("%s %s: %s", at, author, strings.Join(strings.Fields(c.Text), " "))
+}
+
 func main() {
 	os.Exit(Run())
 }
@@ -225,17 +380,26 @@ func runCLI(args []string) int {
 	rootCmd.AddCommand(newStartCmd())
 	rootCmd.AddCommand(newRunCmd())
 	rootCmd.AddCommand(newRerunCmd())
+	rootCmd.AddCommand(newReviewPatchCmd())
 	rootCmd.AddCommand(newFinishCmd())
 	rootCmd.AddCommand(newCleanupCmd())
 	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newSessionsCmd())
+	rootCmd.AddCommand(newSessionCmd())
 	rootCmd.AddCommand(newListCmd())
+	rootCmd.AddCommand(newDiffFindingsCmd())
 	rootCmd.AddCommand(newDismissCmd())
+	rootCmd.AddCommand(newAcceptCmd())
+	rootCmd.AddCommand(newDeferCmd())
+	rootCmd.AddCommand(newCommentCmd())
+	rootCmd.AddCommand(newBaselineCmd())
 	rootCmd.AddCommand(newOptimizeCmd())
 	rootCmd.AddCommand(newCommitMsgCmd())
 	rootCmd.AddCommand(newDoctorCmd())
 	rootCmd.AddCommand(newSkillCmd())
 	rootCmd.AddCommand(newBenchmarkCmd())
 	rootCmd.AddCommand(newStatsCmd())
+	rootCmd.AddCommand(newHistoryCmd())
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
 	rootCmd.SetArgs(args)
@@ -262,6 +426,8 @@ func newStartCmd() *cobra.Command {
 		Short: "Start a review session at the given ref (default HEAD)",
 		RunE:  runStart,
 	}
+	cmd.Flags().Bool("per-commit", false, "Review each commit since the baseline on its own, with its message as intent; findings record the commit (see stet list --commit)")
+	cmd.Flags().String("against", "", "Review HEAD against a target branch (e.g. origin/main): baseline is their merge base; cannot be combined with [ref]")
 	cmd.Flags().Bool("dry-run", false, "Skip LLM; inject canned findings for CI")
 	cmd.Flags().BoolP("quiet", "q", false, "Suppress progress (use for scripts and IDE integration)")
 	cmd.Flags().String("output", "human", "Output format: human (default) or json")
@@ -271,6 +437,11 @@ func newStartCmd() *cobra.Command {
 	cmd.Flags().Int("rag-symbol-max-definitions", 0, "Max symbol definitions to inject (0 = use config); overrides config and env")
 	cmd.Flags().Int("rag-symbol-max-tokens", 0, "Max tokens for symbol-definitions block (0 = use config); overrides config and env")
 	cmd.Flags().Bool("rag-call-graph", false, "Enable RAG call-graph (callers/callees) for Go hunks; overrides config and env")
+	cmd.Flags().Bool("rag-go-types", false, "Resolve Go symbols from go/packages type information (falls back to grep); overrides config and env")
+	cmd.Flags().Bool("related-tests", false, "Include tests that exercise each changed function (Go, Python, JS/TS); overrides config and env")
+	cmd.Flags().String("coverage", "", "Go coverprofile or LCOV file; uncovered added lines are reported to the model (overrides config and env)")
+	cmd.Flags().Bool("summary", false, "Summarize the whole change first and include the summary in every hunk prompt; overrides config and env")
+	cmd.Flags().Bool("batch-files", false, "Review all hunks of a small file in one prompt; overrides config and env")
 	cmd.Flags().String("strictness", "", "Review strictness preset: strict, default, lenient, strict+, default+, lenient+ (overrides config and env)")
 	cmd.Flags().Bool("nitpicky", false, "Enable nitpicky mode: report typos, grammar, style, and convention violations; do not filter those findings")
 	cmd.Flags().Bool("verify", false, "Run critic (second-pass verification) on each finding; drops findings the critic rejects (increases latency and token usage)")
@@ -281,6 +452,7 @@ func newStartCmd() *cobra.Command {
 	cmd.Flags().String("openai-base-url", "", "OpenAI-compat server URL when provider=openai (e.g. http://localhost:1234/v1); overrides config and STET_OPENAI_BASE_URL")
 	cmd.Flags().Bool("trace", false, "Print internal steps to stderr (partition, rules, RAG, prompts, LLM I/O)")
 	cmd.Flags().Bool("search-replace", false, "Use search-replace style diff in the prompt (experimental; compare token usage and finding quality)")
+	addSessionFlag(cmd)
 	return cmd
 }
 
@@ -289,6 +461,17 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		ref = args[0]
 	}
+	against, _ := cmd.Flags().GetString("against")
+	against = strings.TrimSpace(against)
+	if against != "" && len(args) > 0 {
+		return errors.New("Use either a baseline ref or --against, not both.")
+	}
+	// retryArgs is what the user passes to 'stet start' again in hints.
+	retryArgs := ref
+	if against != "" {
+		retryArgs = "--against " + against
+	}
+	perCommit, _ := cmd.Flags().GetBool("per-commit")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	output, _ := cmd.Flags().GetString("output")
@@ -345,6 +528,12 @@ func runStart(cmd *cobra.Command, args []string) error {
 		applyFP = false
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	retryArgs += sessionArg(sessionName)
 	var persistStrictness *string
 	if overrides != nil && overrides.Strictness != nil && *overrides.Strictness != "" {
 		persistStrictness = &cfg.Strictness
@@ -370,8 +559,11 @@ func runStart(cmd *cobra.Command, args []string) error {
 	opts := run.StartOptions{
 		RepoRoot:                       repoRoot,
 		StateDir:                       stateDir,
+		Session:                        sessionName,
 		WorktreeRoot:                   cfg.WorktreeRoot,
 		Ref:                            ref,
+		Against:                        against,
+		PerCommit:                      perCommit,
 		DryRun:                         dryRun,
 		AllowDirty:                     allowDirty,
 		Model:                          cfg.Model,
@@ -391,10 +583,22 @@ func runStart(cmd *cobra.Command, args []string) error {
 		RAGCallersMax:                  cfg.RAGCallersMax,
 		RAGCalleesMax:                  cfg.RAGCalleesMax,
 		RAGCallGraphMaxTokens:          cfg.RAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:              cfg.RAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:          cfg.RAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:         cfg.RAGRelatedTestsEnabled,
+		CoverageProfile:                cfg.CoverageProfile,
+		SummaryEnabled:                 cfg.SummaryEnabled,
+		DanglingRefsEnabled:            cfg.DanglingRefsEnabled,
+		HunkMaxLines:                   cfg.HunkMaxLines,
+		HunkMergeGap:                   cfg.HunkMergeGap,
+		FileBatchingEnabled:            cfg.FileBatchingEnabled,
+		HunkIDNormalization:            cfg.HunkIDNormalization,
 		MinConfidenceKeep:              minKeep,
 		MinConfidenceMaintainability:   minMaint,
 		ApplyFPKillList:                &applyFP,
 		Nitpicky:                       cfg.Nitpicky,
+		Filters:                        cfg.Filters,
+		CalibrationEnabled:             cfg.CalibrationEnabled,
 		CriticEnabled:                  cfg.CriticEnabled,
 		CriticModel:                    cfg.CriticModel,
 		PersistStrictness:              persistStrictness,
@@ -422,33 +626,34 @@ func runStart(cmd *cobra.Command, args []string) error {
 			return errExit(2)
 		}
 		if errors.Is(err, run.ErrDirtyWorktree) {
-			fmt.Fprintf(errHintOut, "Hint: Commit or stash your changes, then run 'stet start %s' again.\n", ref)
+			fmt.Fprintf(errHintOut, "Hint: Commit or stash your changes, then run 'stet start %s' again.\n", retryArgs)
 			return err
 		}
 		if errors.Is(err, git.ErrWorktreeExists) {
-			fmt.Fprintf(errHintOut, "Hint: Run 'stet finish' to end the current review and remove the worktree, then run 'stet start %s' again.\n", ref)
+			fmt.Fprintf(errHintOut, "Hint: Run 'stet finish%s' to end the current review and remove the worktree, then run 'stet start %s' again.\n", sessionArg(sessionName), retryArgs)
 			return errors.New("worktree already exists")
 		}
 		if errors.Is(err, git.ErrBaselineNotAncestor) {
 			return errors.New("baseline ref is not an ancestor of HEAD")
 		}
 		if errors.Is(err, session.ErrLocked) {
-			fmt.Fprintf(errHintOut, "Hint: Run 'stet finish' to end the current review, then run 'stet start %s' again.\n", ref)
+			fmt.Fprintf(errHintOut, "Hint: Run 'stet finish%s' to end the current review, then run 'stet start %s' again.\n", sessionArg(sessionName), retryArgs)
 			return errors.New("finish or cleanup current review first")
 		}
 		return err
 	}
+	warnStaleSuppressions(stats)
 	if stream {
 		// Findings already emitted as NDJSON by run.Start
 		return nil
 	}
 	w := findingsWriter()
 	if output == "json" {
-		if err := writeFindingsJSON(w, stateDir); err != nil {
+		if err := writeFindingsJSON(w, sessionDir); err != nil {
 			return err
 		}
 	} else {
-		if err := writeFindingsHuman(w, stateDir, &stats); err != nil {
+		if err := writeFindingsHuman(w, sessionDir, &stats); err != nil {
 			return err
 		}
 	}
@@ -465,6 +670,11 @@ func addRunLikeFlags(cmd *cobra.Command) {
 	cmd.Flags().Int("rag-symbol-max-definitions", 0, "Max symbol definitions to inject (0 = use config); overrides config and env")
 	cmd.Flags().Int("rag-symbol-max-tokens", 0, "Max tokens for symbol-definitions block (0 = use config); overrides config and env")
 	cmd.Flags().Bool("rag-call-graph", false, "Enable RAG call-graph (callers/callees) for Go hunks; overrides config and env")
+	cmd.Flags().Bool("rag-go-types", false, "Resolve Go symbols from go/packages type information (falls back to grep); overrides config and env")
+	cmd.Flags().Bool("related-tests", false, "Include tests that exercise each changed function (Go, Python, JS/TS); overrides config and env")
+	cmd.Flags().String("coverage", "", "Go coverprofile or LCOV file; uncovered added lines are reported to the model (overrides config and env)")
+	cmd.Flags().Bool("summary", false, "Summarize the whole change first and include the summary in every hunk prompt; overrides config and env")
+	cmd.Flags().Bool("batch-files", false, "Review all hunks of a small file in one prompt; overrides config and env")
 	cmd.Flags().String("strictness", "", "Review strictness preset: strict, default, lenient, strict+, default+, lenient+ (overrides config and env)")
 	cmd.Flags().Bool("nitpicky", false, "Enable nitpicky mode: report typos, grammar, style, and convention violations; do not filter those findings")
 	cmd.Flags().Bool("verify", false, "Run critic (second-pass verification) on each finding; drops findings the critic rejects (increases latency and token usage)")
@@ -484,6 +694,7 @@ func newRunCmd() *cobra.Command {
 		RunE:  runRun,
 	}
 	addRunLikeFlags(cmd)
+	addSessionFlag(cmd)
 	return cmd
 }
 
@@ -514,6 +725,11 @@ func overridesFromFlags(cmd *cobra.Command) (*config.Overrides, error) {
 	defChanged := cmd.Flags().Lookup("rag-symbol-max-definitions") != nil && cmd.Flags().Lookup("rag-symbol-max-definitions").Changed
 	tokChanged := cmd.Flags().Lookup("rag-symbol-max-tokens") != nil && cmd.Flags().Lookup("rag-symbol-max-tokens").Changed
 	ragCallGraphChanged := cmd.Flags().Lookup("rag-call-graph") != nil && cmd.Flags().Lookup("rag-call-graph").Changed
+	ragGoTypesChanged := cmd.Flags().Lookup("rag-go-types") != nil && cmd.Flags().Lookup("rag-go-types").Changed
+	relatedTestsChanged := cmd.Flags().Lookup("related-tests") != nil && cmd.Flags().Lookup("related-tests").Changed
+	coverageChanged := cmd.Flags().Lookup("coverage") != nil && cmd.Flags().Lookup("coverage").Changed
+	summaryChanged := cmd.Flags().Lookup("summary") != nil && cmd.Flags().Lookup("summary").Changed
+	batchFilesChanged := cmd.Flags().Lookup("batch-files") != nil && cmd.Flags().Lookup("batch-files").Changed
 	strictnessChanged := cmd.Flags().Lookup("strictness") != nil && cmd.Flags().Lookup("strictness").Changed
 	nitpickyChanged := cmd.Flags().Lookup("nitpicky") != nil && cmd.Flags().Lookup("nitpicky").Changed
 	verifyChanged := cmd.Flags().Lookup("verify") != nil && cmd.Flags().Lookup("verify").Changed
@@ -522,7 +738,7 @@ func overridesFromFlags(cmd *cobra.Command) (*config.Overrides, error) {
 	timeoutChanged := cmd.Flags().Lookup("timeout") != nil && cmd.Flags().Lookup("timeout").Changed
 	providerChanged := cmd.Flags().Lookup("provider") != nil && cmd.Flags().Lookup("provider").Changed
 	openaiBaseURLChanged := cmd.Flags().Lookup("openai-base-url") != nil && cmd.Flags().Lookup("openai-base-url").Changed
-	if !defChanged && !tokChanged && !ragCallGraphChanged && !strictnessChanged && !nitpickyChanged && !verifyChanged && !contextChanged && !numCtxChanged && !timeoutChanged && !providerChanged && !openaiBaseURLChanged {
+	if !defChanged && !tokChanged && !ragCallGraphChanged && !ragGoTypesChanged && !relatedTestsChanged && !coverageChanged && !summaryChanged && !batchFilesChanged && !strictnessChanged && !nitpickyChanged && !verifyChanged && !contextChanged && !numCtxChanged && !timeoutChanged && !providerChanged && !openaiBaseURLChanged {
 		return nil, nil
 	}
 	o := &config.Overrides{}
@@ -538,6 +754,26 @@ func overridesFromFlags(cmd *cobra.Command) (*config.Overrides, error) {
 		v, _ := cmd.Flags().GetBool("rag-call-graph")
 		o.RAGCallGraphEnabled = &v
 	}
+	if ragGoTypesChanged {
+		v, _ := cmd.Flags().GetBool("rag-go-types")
+		o.RAGGoTypesEnabled = &v
+	}
+	if relatedTestsChanged {
+		v, _ := cmd.Flags().GetBool("related-tests")
+		o.RAGRelatedTestsEnabled = &v
+	}
+	if coverageChanged {
+		v, _ := cmd.Flags().GetString("coverage")
+		o.CoverageProfile = &v
+	}
+	if summaryChanged {
+		v, _ := cmd.Flags().GetBool("summary")
+		o.SummaryEnabled = &v
+	}
+	if batchFilesChanged {
+		v, _ := cmd.Flags().GetBool("batch-files")
+		o.FileBatchingEnabled = &v
+	}
 	if strictnessChanged {
 		v, _ := cmd.Flags().GetString("strictness")
 		o.Strictness = &v
@@ -621,7 +857,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
-	s, err := session.Load(stateDir)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
 	if err != nil {
 		return err
 	}
@@ -696,6 +937,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 	opts := run.RunOptions{
 		RepoRoot:                     repoRoot,
 		StateDir:                     stateDir,
+		Session:                      sessionName,
 		DryRun:                       dryRun,
 		Model:                        cfg.Model,
 		Provider:                     cfg.EffectiveLLMProvider(),
@@ -714,10 +956,22 @@ func runRun(cmd *cobra.Command, args []string) error {
 		RAGCallersMax:                cfg.RAGCallersMax,
 		RAGCalleesMax:                cfg.RAGCalleesMax,
 		RAGCallGraphMaxTokens:        cfg.RAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:            cfg.RAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:        cfg.RAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:       cfg.RAGRelatedTestsEnabled,
+		CoverageProfile:              cfg.CoverageProfile,
+		SummaryEnabled:               cfg.SummaryEnabled,
+		DanglingRefsEnabled:          cfg.DanglingRefsEnabled,
+		HunkMaxLines:                 cfg.HunkMaxLines,
+		HunkMergeGap:                 cfg.HunkMergeGap,
+		FileBatchingEnabled:          cfg.FileBatchingEnabled,
+		HunkIDNormalization:          cfg.HunkIDNormalization,
 		MinConfidenceKeep:            minKeep,
 		MinConfidenceMaintainability: minMaint,
 		ApplyFPKillList:              &applyFP,
 		Nitpicky:                     effectiveNitpicky,
+		Filters:                      cfg.Filters,
+		CalibrationEnabled:           cfg.CalibrationEnabled,
 		CriticEnabled:                cfg.CriticEnabled,
 		CriticModel:                  cfg.CriticModel,
 		TraceOut:                     traceOut,
@@ -744,17 +998,18 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	warnStaleSuppressions(stats)
 	if stream {
 		// Findings already emitted as NDJSON by run.Run
 		return nil
 	}
 	w := findingsWriter()
 	if output == "json" {
-		if err := writeFindingsJSON(w, stateDir); err != nil {
+		if err := writeFindingsJSON(w, sessionDir); err != nil {
 			return err
 		}
 	} else {
-		if err := writeFindingsHuman(w, stateDir, &stats); err != nil {
+		if err := writeFindingsHuman(w, sessionDir, &stats); err != nil {
 			return err
 		}
 	}
@@ -773,6 +1028,7 @@ Other flags (--dry-run, --json, --stream, --rag-symbol-*, --strictness, --nitpic
 	}
 	addRunLikeFlags(cmd)
 	cmd.Flags().Bool("replace", false, "Replace session findings with only this run's results; default is to merge new findings with existing")
+	addSessionFlag(cmd)
 	return cmd
 }
 
@@ -801,7 +1057,12 @@ func runRerun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
-	s, err := session.Load(stateDir)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
 	if err != nil {
 		return err
 	}
@@ -876,6 +1137,7 @@ func runRerun(cmd *cobra.Command, args []string) error {
 	opts := run.RunOptions{
 		RepoRoot:                     repoRoot,
 		StateDir:                     stateDir,
+		Session:                      sessionName,
 		DryRun:                       dryRun,
 		Model:                        cfg.Model,
 		Provider:                     cfg.EffectiveLLMProvider(),
@@ -894,10 +1156,22 @@ func runRerun(cmd *cobra.Command, args []string) error {
 		RAGCallersMax:                cfg.RAGCallersMax,
 		RAGCalleesMax:                cfg.RAGCalleesMax,
 		RAGCallGraphMaxTokens:        cfg.RAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:            cfg.RAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:        cfg.RAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:       cfg.RAGRelatedTestsEnabled,
+		CoverageProfile:              cfg.CoverageProfile,
+		SummaryEnabled:               cfg.SummaryEnabled,
+		DanglingRefsEnabled:          cfg.DanglingRefsEnabled,
+		HunkMaxLines:                 cfg.HunkMaxLines,
+		HunkMergeGap:                 cfg.HunkMergeGap,
+		FileBatchingEnabled:          cfg.FileBatchingEnabled,
+		HunkIDNormalization:          cfg.HunkIDNormalization,
 		MinConfidenceKeep:            minKeep,
 		MinConfidenceMaintainability: minMaint,
 		ApplyFPKillList:              &applyFP,
 		Nitpicky:                     effectiveNitpicky,
+		Filters:                      cfg.Filters,
+		CalibrationEnabled:           cfg.CalibrationEnabled,
 		CriticEnabled:                cfg.CriticEnabled,
 		CriticModel:                  cfg.CriticModel,
 		TraceOut:                     traceOut,
@@ -926,22 +1200,169 @@ func runRerun(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	warnStaleSuppressions(stats)
 	if stream {
 		return nil
 	}
 	w := findingsWriter()
 	if output == "json" {
-		if err := writeFindingsJSON(w, stateDir); err != nil {
+		if err := writeFindingsJSON(w, sessionDir); err != nil {
 			return err
 		}
 	} else {
-		if err := writeFindingsHuman(w, stateDir, &stats); err != nil {
+		if err := writeFindingsHuman(w, sessionDir, &stats); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func newReviewPatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review-patch <file|->",
+		Short: "Review a patch file or a diff on stdin without a session",
+		Long: "Review an arbitrary unified diff (git diff, git format-patch mail, or diff -u output) read from a file, or from stdin when the argument is -. " +
+			"The patch is not applied and the session is not touched; RAG and rules read the current checkout.",
+		Args: cobra.ExactArgs(1),
+		RunE: runReviewPatch,
+	}
+	addRunLikeFlags(cmd)
+	return cmd
+}
+
+func runReviewPatch(cmd *cobra.Command, args []string) error {
+	var patch []byte
+	var err error
+	if args[0] == "-" {
+		patch, err = io.ReadAll(os.Stdin)
+	} else {
+		patch, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return erruser.New(fmt.Sprintf("Could not read patch %s.", args[0]), err)
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	output, _ := cmd.Flags().GetString("output")
+	outputJSON, _ := cmd.Flags().GetBool("json")
+	if outputJSON {
+		output = "json"
+	}
+	if output != "human" && output != "json" {
+		return errors.New("Invalid output format; use human or json.")
+	}
+	stream, _ := cmd.Flags().GetBool("stream")
+	if stream && output != "json" {
+		return errors.New("--stream requires --output=json or --json.")
+	}
+	verbose := !quiet
+	if stream || output == "json" {
+		verbose = false
+	}
+	trace, _ := cmd.Flags().GetBool("trace")
+	var traceOut io.Writer
+	if trace {
+		traceOut = os.Stderr
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	overrides, err := overridesFromFlags(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return errExit(1)
+	}
+	if overrides != nil && overrides.Strictness != nil && *overrides.Strictness != "" {
+		if _, _, _, err := findings.ResolveStrictness(*overrides.Strictness); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return errExit(1)
+		}
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot, Overrides: overrides})
+	if err != nil {
+		return err
+	}
+	minKeep, minMaint, applyFP, err := findings.ResolveStrictness(cfg.Strictness)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return errExit(1)
+	}
+	if cfg.Nitpicky {
+		applyFP = false
+	}
+	opts := run.StartOptions{
+		RepoRoot:                     repoRoot,
+		StateDir:                     cfg.EffectiveStateDir(repoRoot),
+		DryRun:                       dryRun,
+		Model:                        cfg.Model,
+		Provider:                     cfg.EffectiveLLMProvider(),
+		LLMBaseURL:                   cfg.EffectiveLLMBaseURL(),
+		ContextLimit:                 cfg.ContextLimit,
+		WarnThreshold:                cfg.WarnThreshold,
+		Timeout:                      cfg.Timeout,
+		Temperature:                  cfg.Temperature,
+		NumCtx:                       cfg.NumCtx,
+		MaxCompletionTokens:          cfg.MaxCompletionTokens,
+		Verbose:                      verbose,
+		StreamOut:                    nil,
+		RAGSymbolMaxDefinitions:      cfg.RAGSymbolMaxDefinitions,
+		RAGSymbolMaxTokens:           cfg.RAGSymbolMaxTokens,
+		RAGCallGraphEnabled:          cfg.RAGCallGraphEnabled,
+		RAGCallersMax:                cfg.RAGCallersMax,
+		RAGCalleesMax:                cfg.RAGCalleesMax,
+		RAGCallGraphMaxTokens:        cfg.RAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:            cfg.RAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:        cfg.RAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:       cfg.RAGRelatedTestsEnabled,
+		CoverageProfile:              cfg.CoverageProfile,
+		HunkMaxLines:                 cfg.HunkMaxLines,
+		FileBatchingEnabled:          cfg.FileBatchingEnabled,
+		HunkIDNormalization:          cfg.HunkIDNormalization,
+		MinConfidenceKeep:            minKeep,
+		MinConfidenceMaintainability: minMaint,
+		ApplyFPKillList:              &applyFP,
+		Nitpicky:                     cfg.Nitpicky,
+		Filters:                      cfg.Filters,
+		CalibrationEnabled:           cfg.CalibrationEnabled,
+		CriticEnabled:                cfg.CriticEnabled,
+		CriticModel:                  cfg.CriticModel,
+		TraceOut:                     traceOut,
+		UseSearchReplaceFormat:       getSearchReplaceFlag(cmd),
+		SuppressionEnabled:           cfg.SuppressionEnabled,
+		SuppressionHistoryCount:      cfg.SuppressionHistoryCount,
+	}
+	if stream {
+		opts.StreamOut = findingsWriter()
+	}
+	list, stats, err := run.ReviewPatch(cmd.Context(), opts, string(patch))
+	if err != nil {
+		if errors.Is(err, llm.ErrUnreachable) {
+			printLLMUnreachable(cfg.EffectiveLLMProvider(), cfg.EffectiveLLMBaseURL(), err)
+			return errExit(2)
+		}
+		if errors.Is(err, llm.ErrBadRequest) {
+			fmt.Fprintf(os.Stderr, "LLM bad request at %s. %v\n", cfg.EffectiveLLMBaseURL(), errForDetails(err))
+			return errExit(2)
+		}
+		return err
+	}
+	warnStaleSuppressions(stats)
+	if stream {
+		// Findings already emitted as NDJSON by run.ReviewPatch
+		return nil
+	}
+	w := findingsWriter()
+	if output == "json" {
+		return writeFindingListJSON(w, list)
+	}
+	return writeFindingListHuman(w, list, &stats)
+}
+
 func getSearchReplaceFlag(cmd *cobra.Command) bool {
 	v, _ := cmd.Flags().GetBool("search-replace")
 	return v
@@ -953,6 +1374,7 @@ func newFinishCmd() *cobra.Command {
 		Short: "Finish the review session and remove the worktree",
 		RunE:  runFinish,
 	}
+	addSessionFlag(cmd)
 	return cmd
 }
 
@@ -970,9 +1392,14 @@ func runFinish(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
 	opts := run.FinishOptions{
 		RepoRoot:     repoRoot,
 		StateDir:     stateDir,
+		Session:      sessionName,
 		WorktreeRoot: cfg.WorktreeRoot,
 	}
 	if err := run.Finish(cmd.Context(), opts); err != nil {
@@ -988,7 +1415,7 @@ func runFinish(cmd *cobra.Command, args []string) error {
 func newCleanupCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cleanup",
-		Short: "Remove orphan stet worktrees (not the current session's worktree)",
+		Short: "Remove orphan stet worktrees (not the worktrees of active sessions)",
 		RunE:  runCleanup,
 	}
 	return cmd
@@ -1031,8 +1458,8 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		return errExit(1)
 	}
 
-	var currentPath string
-	s, err := session.Load(stateDir)
+	// Worktrees of every active session (default and named) are kept.
+	names, err := session.Names(stateDir)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		if u := errors.Unwrap(err); u != nil {
@@ -1040,8 +1467,20 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		}
 		return errExit(1)
 	}
-	if s.BaselineRef != "" {
-		currentPath, err = git.PathForRef(repoRoot, cfg.WorktreeRoot, s.BaselineRef)
+	keep := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		s, err := session.Load(session.Dir(stateDir, name))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			if u := errors.Unwrap(err); u != nil {
+				fmt.Fprintf(os.Stderr, "Details: %v\n", u)
+			}
+			return errExit(1)
+		}
+		if s.BaselineRef == "" {
+			continue
+		}
+		currentPath, err := git.PathForSession(repoRoot, cfg.WorktreeRoot, name, s.BaselineRef)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			if u := errors.Unwrap(err); u != nil {
@@ -1054,6 +1493,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 			fmt.Fprintln(os.Stderr, erruser.New("Could not resolve worktree path.", err))
 			return errExit(1)
 		}
+		keep[currentPath] = struct{}{}
 	}
 
 	list, err := git.List(repoRoot)
@@ -1073,7 +1513,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		if dir != worktreeRoot || !strings.HasPrefix(base, "stet-") {
 			continue
 		}
-		if currentPath != "" && wtPath == currentPath {
+		if _, ok := keep[wtPath]; ok {
 			continue
 		}
 		if err := git.Remove(repoRoot, wtPath); err != nil {
@@ -1099,6 +1539,8 @@ func newStatusCmd() *cobra.Command {
 		RunE:  runStatus,
 	}
 	cmd.Flags().BoolP("ids", "i", false, "List active finding IDs (for stet dismiss)")
+	addOrderFlags(cmd)
+	addSessionFlag(cmd)
 	return cmd
 }
 
@@ -1116,23 +1558,40 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
-	s, err := session.Load(stateDir)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
 	if err != nil {
 		return err
 	}
 	if s.BaselineRef == "" {
-		fmt.Fprintln(os.Stderr, "No active session. Run 'stet start' to begin a review.")
+		fmt.Fprintf(os.Stderr, "No active session. Run 'stet start%s' to begin a review.\n", sessionArg(sessionName))
 		return errExit(1)
 	}
-	worktreePath, err := git.PathForRef(repoRoot, cfg.WorktreeRoot, s.BaselineRef)
+	worktreePath, err := git.PathForSession(repoRoot, cfg.WorktreeRoot, sessionName, s.BaselineRef)
 	if err != nil {
 		return err
 	}
 	fmt.Fprintf(os.Stdout, "baseline: %s\n", s.BaselineRef)
+	if s.TargetBranch != "" {
+		fmt.Fprintf(os.Stdout, "target: %s\n", s.TargetBranch)
+	}
 	fmt.Fprintf(os.Stdout, "last_reviewed_at: %s\n", s.LastReviewedAt)
 	fmt.Fprintf(os.Stdout, "worktree: %s\n", worktreePath)
 	fmt.Fprintf(os.Stdout, "findings: %d\n", len(s.Findings))
 	fmt.Fprintf(os.Stdout, "dismissed: %d\n", len(s.DismissedIDs))
+	byStatus := make(map[findings.Status]int)
+	for _, f := range session.FindingsWithStatus(&s, time.Now()) {
+		byStatus[f.Status]++
+	}
+	for _, st := range []findings.Status{findings.StatusAccepted, findings.StatusFixed, findings.StatusDeferred} {
+		if byStatus[st] > 0 {
+			fmt.Fprintf(os.Stdout, "%s: %d\n", st, byStatus[st])
+		}
+	}
 	if s.Strictness != "" {
 		fmt.Fprintf(os.Stdout, "strictness: %s\n", s.Strictness)
 	}
@@ -1144,13 +1603,17 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 	showIDs, _ := cmd.Flags().GetBool("ids")
 	if showIDs {
-		active, err := activeFindings(stateDir)
+		active, err := activeFindings(sessionDir)
+		if err != nil {
+			return err
+		}
+		active, err = orderFindings(cmd, repoRoot, &s, active, false)
 		if err != nil {
 			return err
 		}
 		if len(active) > 0 {
 			fmt.Fprintln(os.Stdout, "---")
-			if err := writeFindingsWithIDs(os.Stdout, stateDir); err != nil {
+			if err := writeFindingsWithIDs(os.Stdout, active, false); err != nil {
 				return err
 			}
 		}
@@ -1158,16 +1621,19 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func newListCmd() *cobra.Command {
+func newSessionsCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List active findings with IDs (for stet dismiss)",
-		RunE:  runList,
+		Use:   "sessions",
+		Short: "List active review sessions (default and named via --session)",
+		Args:  cobra.NoArgs,
+		RunE:  runSessions,
 	}
 	return cmd
 }
 
-func runList(cmd *cobra.Command, args []string) error {
+// runSessions prints one line per active session: name, baseline, active finding count
+// and the target branch when started with --against. The default session is shown as "(default)".
+func runSessions(cmd *cobra.Command, args []string) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return erruser.New("Could not determine current directory.", err)
@@ -1181,44 +1647,450 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	stateDir := cfg.EffectiveStateDir(repoRoot)
-	s, err := session.Load(stateDir)
+	names, err := session.Names(stateDir)
 	if err != nil {
 		return err
 	}
-	if s.BaselineRef == "" {
-		fmt.Fprintln(os.Stderr, "No active session. Run 'stet start' to begin a review.")
-		return errExit(1)
+	var listed int
+	for _, name := range names {
+		sessionDir := session.Dir(stateDir, name)
+		s, err := session.Load(sessionDir)
+		if err != nil {
+			return err
+		}
+		if s.BaselineRef == "" {
+			continue
+		}
+		active, err := activeFindings(sessionDir)
+		if err != nil {
+			return err
+		}
+		label := name
+		if label == "" {
+			label = "(default)"
+		}
+		line := fmt.Sprintf("%s  %.12s  %d finding(s)", label, s.BaselineRef, len(active))
+		if s.TargetBranch != "" {
+			line += "  target: " + s.TargetBranch
+		}
+		fmt.Fprintln(os.Stdout, line)
+		listed++
+	}
+	if listed == 0 {
+		fmt.Fprintln(os.Stderr, "No active sessions. Run 'stet start' to begin a review.")
 	}
-	return writeFindingsWithIDs(os.Stdout, stateDir)
+	return nil
 }
 
-func newDismissCmd() *cobra.Command {
+func newSessionCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "dismiss <id> [reason]",
-		Short: "Mark a finding as dismissed so it does not resurface",
-		Long: `Mark a finding as dismissed so it does not resurface. The id can be the full finding id or a unique prefix (e.g. first 7 characters). Optional reason is recorded for the optimizer.
-
-Valid reasons:
-  false_positive   — Finding is not a real issue
-  already_correct  — Code is already correct as-is
-  wrong_suggestion — Suggestion is wrong or not applicable
-  out_of_scope     — Out of scope for this review
+		Use:   "session",
+		Short: "Hand a review session to a teammate (export, import)",
+		Long: `Export the active review session (findings with status and comments, prompt contexts, dismissals and the review settings) to a file,
+and import it in another clone of the repository to continue triage there.`,
+	}
+	cmd.AddCommand(newSessionExportCmd())
+	cmd.AddCommand(newSessionImportCmd())
+	return cmd
+}
 
-For guidance on choosing a reason, see docs/review-quality.md.`,
-		RunE: runDismiss,
+func newSessionExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Write the active session to a portable file (gzipped JSON)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSessionExport,
 	}
+	addSessionFlag(cmd)
 	return cmd
 }
 
-// runDismiss adds the finding id to the session's dismissed list so it does not resurface.
-// Optional second argument is a dismissal reason (false_positive, already_correct, wrong_suggestion, out_of_scope)
-// for the optimizer. Phase 4.5: appends to .review/history.jsonl on dismiss.
-func runDismiss(cmd *cobra.Command, args []string) error {
-	if len(args) < 1 {
-		return errors.New("dismiss requires a finding id (e.g. stet dismiss <id>)")
+func newSessionImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Continue a review exported with stet session export",
+		Long:  "Make an exported review the active session: the baseline and last reviewed commits must exist locally and be in the history of HEAD (fetch and check out the reviewed branch first). Creates the review worktree. Fails when the target session is already active; use --session to import into a named session.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSessionImport,
 	}
-	id := strings.TrimSpace(args[0])
-	if id == "" {
+	addSessionFlag(cmd)
+	return cmd
+}
+
+// runSessionExport writes the active session, the review settings and the exporter's git identity to the file.
+func runSessionExport(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	s, err := session.Load(session.Dir(stateDir, sessionName))
+	if err != nil {
+		return err
+	}
+	strictness := cfg.Strictness
+	if s.Strictness != "" {
+		strictness = s.Strictness
+	}
+	exportedBy := ""
+	if name, email, err := git.UserIdentity(repoRoot); err == nil {
+		exportedBy = strings.TrimSpace(name + " <" + email + ">")
+		if email == "" {
+			exportedBy = name
+		}
+	}
+	err = run.Export(run.ExportOptions{
+		StateDir:   stateDir,
+		Session:    sessionName,
+		Path:       args[0],
+		Config:     history.NewRunConfigSnapshot(cfg.Model, strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky),
+		ExportedBy: exportedBy,
+	})
+	if errors.Is(err, run.ErrNoSession) {
+		fmt.Fprintf(os.Stderr, "No active session. Run 'stet start%s' to begin a review.\n", sessionArg(sessionName))
+		return errExit(1)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported review of %.12s with %d finding(s) to %s.\n", s.BaselineRef, len(s.Findings), args[0])
+	return nil
+}
+
+// runSessionImport makes the exported session active and recreates its worktree.
+func runSessionImport(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	b, err := run.Import(run.ImportOptions{
+		RepoRoot:     repoRoot,
+		StateDir:     stateDir,
+		WorktreeRoot: cfg.WorktreeRoot,
+		Session:      sessionName,
+		Path:         args[0],
+	})
+	if err != nil {
+		if errors.Is(err, run.ErrSessionExists) {
+			fmt.Fprintf(errHintOut, "Hint: Run 'stet finish%s' first, or import into a named session with --session <name>.\n", sessionArg(sessionName))
+			return errors.New("finish or cleanup current review first")
+		}
+		if errors.Is(err, git.ErrWorktreeExists) {
+			fmt.Fprintf(errHintOut, "Hint: Run 'stet cleanup' to remove the leftover worktree, then import again.\n")
+			return errors.New("worktree already exists")
+		}
+		return err
+	}
+	from := ""
+	if b.ExportedBy != "" {
+		from = " from " + b.ExportedBy
+	}
+	active := 0
+	now := time.Now()
+	for _, f := range session.FindingsWithStatus(&b.Session, now) {
+		if findings.NeedsAttention(f, now) {
+			active++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Imported review of %.12s%s: %d finding(s), %d active.\n", b.Session.BaselineRef, from, len(b.Session.Findings), active)
+	if b.Config != nil && b.Config.Model != "" && b.Config.Model != cfg.Model {
+		fmt.Fprintf(os.Stderr, "Note: the review used model %s; stet run here uses %s.\n", b.Config.Model, cfg.Model)
+	}
+	return nil
+}
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List active findings with IDs (for stet dismiss)",
+		RunE:  runList,
+	}
+	cmd.Flags().String("commit", "", "Only list findings from this commit (SHA or ref) of a per-commit review")
+	cmd.Flags().Bool("all", false, "List all findings, including dismissed, fixed and deferred, with their status")
+	cmd.Flags().Bool("json", false, "Emit findings as JSON ({\"findings\": [...]}), each with its rank score")
+	addOrderFlags(cmd)
+	addSessionFlag(cmd)
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if s.BaselineRef == "" {
+		fmt.Fprintf(os.Stderr, "No active session. Run 'stet start%s' to begin a review.\n", sessionArg(sessionName))
+		return errExit(1)
+	}
+	commit, _ := cmd.Flags().GetString("commit")
+	if commit = strings.TrimSpace(commit); commit != "" {
+		sha, err := git.RevParse(repoRoot, commit+"^{commit}")
+		if err != nil {
+			return erruser.New(fmt.Sprintf("Could not resolve commit %q.", commit), err)
+		}
+		commit = sha
+	}
+	all, _ := cmd.Flags().GetBool("all")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	list, err := listedFindings(sessionDir, commit, all)
+	if err != nil {
+		return err
+	}
+	list, err = orderFindings(cmd, repoRoot, &s, list, asJSON)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return writeFindingListJSON(os.Stdout, list)
+	}
+	return writeFindingsWithIDs(os.Stdout, list, all)
+}
+
+func newDiffFindingsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-findings [old.json new.json]",
+		Short: "Compare two finding sets and report new, gone and changed findings",
+		Long: `Compare two finding sets and report new, gone and changed findings. Findings are matched by id, then by baseline fingerprint, then fuzzily by file, nearby line and similar message.
+
+Without arguments, compares a review record in history (finish or rerun --replace; accept, defer, comment and dismiss records are skipped) with the current session: the most recent one, or the one given by --record (1 = most recent). For a record of 'stet rerun --replace' the old side is the findings it replaced, so right after a rerun --replace with another model or strictness this shows what changed.
+
+With two files, compares them. Each file holds stet JSON output ({"findings": [...]}, e.g. from stet start --json or stet list --json) or a JSON array of findings.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: runDiffFindings,
+	}
+	cmd.Flags().Int("record", 1, "Finish or rerun --replace record to compare with the session, counting back from the most recent (1)")
+	cmd.Flags().String("format", "human", "Output format: human or json")
+	addSessionFlag(cmd)
+	return cmd
+}
+
+// runDiffFindings compares two finding sets: two JSON files, or a history record and the session.
+func runDiffFindings(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "human" && format != "json" {
+		return errors.New("Invalid output format; use human or json.")
+	}
+	var before, after []findings.Finding
+	var oldLabel, newLabel string
+	switch len(args) {
+	case 2:
+		var err error
+		if before, err = readFindingsFile(args[0]); err != nil {
+			return err
+		}
+		if after, err = readFindingsFile(args[1]); err != nil {
+			return err
+		}
+		oldLabel, newLabel = args[0], args[1]
+	case 1:
+		return erruser.New("diff-findings takes two files (old and new), or none to compare history with the session.", nil)
+	default:
+		n, _ := cmd.Flags().GetInt("record")
+		if n < 1 {
+			return erruser.New("--record must be 1 (most recent) or more.", nil)
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			return erruser.New("Could not determine current directory.", err)
+		}
+		repoRoot, err := git.RepoRoot(cwd)
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+		if err != nil {
+			return err
+		}
+		stateDir := cfg.EffectiveStateDir(repoRoot)
+		sessionName, err := sessionFromFlag(cmd)
+		if err != nil {
+			return err
+		}
+		sessionDir := session.Dir(stateDir, sessionName)
+		s, err := session.Load(sessionDir)
+		if err != nil {
+			return err
+		}
+		if s.BaselineRef == "" {
+			fmt.Fprintf(os.Stderr, "No active session. Run 'stet start%s' to begin a review.\n", sessionArg(sessionName))
+			return errExit(1)
+		}
+		all, err := history.ReadRecords(stateDir)
+		if err != nil {
+			return err
+		}
+		// Status and dismiss records mirror the session; only review results are worth comparing.
+		var records []history.Record
+		for _, r := range all {
+			if k := history.Kind(r); k == history.KindFinish || k == history.KindReplace {
+				records = append(records, r)
+			}
+		}
+		if n > len(records) {
+			return erruser.New(fmt.Sprintf("History has %d finish or replace record(s); cannot compare with record %d.", len(records), n), nil)
+		}
+		rec := records[len(records)-n]
+		before = rec.ReviewOutput
+		oldLabel = fmt.Sprintf("history record %d", n)
+		if rec.UserAction.ReplaceFindings && rec.ReplacedOutput != nil {
+			before = rec.ReplacedOutput
+			oldLabel += " (findings before rerun --replace)"
+		}
+		if rec.RunConfig != nil && rec.RunConfig.Model != "" {
+			oldLabel += ", model " + rec.RunConfig.Model
+		}
+		after = session.FindingsWithStatus(&s, time.Now())
+		newLabel = "the current session"
+	}
+	cmp := findings.Compare(before, after)
+	w := os.Stdout
+	if format == "json" {
+		if cmp.New == nil {
+			cmp.New = []findings.Finding{}
+		}
+		if cmp.Gone == nil {
+			cmp.Gone = []findings.Finding{}
+		}
+		if cmp.Changed == nil {
+			cmp.Changed = []findings.Change{}
+		}
+		data, err := json.Marshal(cmp)
+		if err != nil {
+			return erruser.New("Could not write finding comparison.", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	fmt.Fprintf(w, "Comparing %s with %s.\n", oldLabel, newLabel)
+	if len(cmp.New) > 0 {
+		fmt.Fprintf(w, "\nNew (%d):\n", len(cmp.New))
+		for _, f := range cmp.New {
+			fmt.Fprintf(w, "  + %s\n", formatFindingLine(f))
+		}
+	}
+	if len(cmp.Gone) > 0 {
+		fmt.Fprintf(w, "\nGone (%d):\n", len(cmp.Gone))
+		for _, f := range cmp.Gone {
+			fmt.Fprintf(w, "  - %s\n", formatFindingLine(f))
+		}
+	}
+	if len(cmp.Changed) > 0 {
+		fmt.Fprintf(w, "\nChanged (%d):\n", len(cmp.Changed))
+		for _, c := range cmp.Changed {
+			fmt.Fprintf(w, "  ~ %s  [%s match; %s]\n", formatFindingLine(c.New), c.Match, strings.Join(c.Fields, ", "))
+			fmt.Fprintf(w, "      was: %s\n", formatFindingLine(c.Old))
+		}
+	}
+	fmt.Fprintf(w, "\n%d new, %d gone, %d changed, %d unchanged.\n", len(cmp.New), len(cmp.Gone), len(cmp.Changed), cmp.Unchanged)
+	return nil
+}
+
+// formatFindingLine returns "id  file:line  SEVERITY  message" as printed by stet list.
+func formatFindingLine(f findings.Finding) string {
+	line := f.Line
+	if f.Range != nil {
+		line = f.Range.Start
+	}
+	return fmt.Sprintf("%s  %s:%d  %s  %s", findings.ShortID(f.ID), f.File, line, strings.ToUpper(string(f.Severity)), f.Message)
+}
+
+// readFindingsFile reads findings from a JSON file holding {"findings": [...]} or a bare array.
+func readFindingsFile(path string) ([]findings.Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("Could not read findings file %s.", path), err)
+	}
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		var list []findings.Finding
+		if err := json.Unmarshal([]byte(trimmed), &list); err != nil {
+			return nil, erruser.New(fmt.Sprintf("Findings file %s is not valid JSON.", path), err)
+		}
+		return list, nil
+	}
+	var payload struct {
+		Findings *[]findings.Finding `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &payload); err != nil {
+		return nil, erruser.New(fmt.Sprintf("Findings file %s is not valid JSON.", path), err)
+	}
+	if payload.Findings == nil {
+		return nil, erruser.New(fmt.Sprintf("Findings file %s has no \"findings\" array.", path), nil)
+	}
+	return *payload.Findings, nil
+}
+
+func newDismissCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dismiss <id> [reason]",
+		Short: "Mark a finding as dismissed so it does not resurface",
+		Long: `Mark a finding as dismissed so it does not resurface. The id can be the full finding id or a unique prefix (e.g. first 7 characters). Optional reason is recorded for the optimizer.
+
+Valid reasons:
+  false_positive   — Finding is not a real issue
+  already_correct  — Code is already correct as-is
+  wrong_suggestion — Suggestion is wrong or not applicable
+  out_of_scope     — Out of scope for this review
+
+For guidance on choosing a reason, see docs/review-quality.md.`,
+		RunE: runDismiss,
+	}
+	addSessionFlag(cmd)
+	return cmd
+}
+
+// runDismiss adds the finding id to the session's dismissed list so it does not resurface.
+// Optional second argument is a dismissal reason (false_positive, already_correct, wrong_suggestion, out_of_scope)
+// for the optimizer. Phase 4.5: appends to .review/history.jsonl on dismiss.
+func runDismiss(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("dismiss requires a finding id (e.g. stet dismiss <id>)")
+	}
+	id := strings.TrimSpace(args[0])
+	if id == "" {
 		return errors.New("dismiss requires a non-empty finding id")
 	}
 	var reason string
@@ -1230,69 +2102,413 @@ func runDismiss(cmd *cobra.Command, args []string) error {
 	}
 	cwd, err := os.Getwd()
 	if err != nil {
-		return erruser.New("Could not determine current directory.", err)
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if s.BaselineRef == "" {
+		fmt.Fprintln(os.Stderr, run.ErrNoSession.Error())
+		return errExit(1)
+	}
+	fullID, err := findings.ResolveFindingIDByPrefix(s.Findings, id)
+	if err != nil {
+		return err
+	}
+	alreadyDismissed := false
+	for _, d := range s.DismissedIDs {
+		if d == fullID {
+			alreadyDismissed = true
+			break
+		}
+	}
+	if !alreadyDismissed {
+		if s.FindingPromptContext == nil {
+			s.FindingPromptContext = make(map[string]string)
+		}
+		if ctx := s.FindingPromptContext[fullID]; ctx != "" {
+			note := findings.CommentText(session.Comments(&s, fullID))
+			s.PromptShadows = append(s.PromptShadows, session.PromptShadow{FindingID: fullID, PromptContext: ctx, Note: note})
+		}
+	}
+	session.SetStatus(&s, fullID, findings.StatusDismissed, "", reason)
+	if err := session.Save(sessionDir, &s); err != nil {
+		return err
+	}
+	diffRef := s.LastReviewedAt
+	if diffRef == "" {
+		diffRef = s.BaselineRef
+	}
+	ua := history.UserAction{DismissedIDs: []string{fullID}}
+	comments := session.Comments(&s, fullID)
+	if reason != "" || len(comments) > 0 {
+		d := history.Dismissal{FindingID: fullID, Reason: reason, Comments: comments}
+		if s.FindingPromptContext != nil && s.FindingPromptContext[fullID] != "" {
+			d.PromptContext = s.FindingPromptContext[fullID]
+		}
+		ua.Dismissals = []history.Dismissal{d}
+	}
+	rec := history.Record{
+		DiffRef:      diffRef,
+		ReviewOutput: session.FindingsWithStatus(&s, time.Now()),
+		UserAction:   ua,
+		RunConfig:    history.NewRunConfigSnapshot(cfg.Model, cfg.Strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky),
+	}
+	if err := history.Append(stateDir, rec, history.DefaultMaxRecords); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newAcceptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accept <id>",
+		Short: "Mark a finding as accepted (valid, being addressed)",
+		Long:  "Mark a finding as accepted: it is valid and you intend to address it. Accepted findings stay in stet list until the fix is reviewed (they are then marked fixed). The id can be the full finding id or a unique prefix. Accepting a dismissed or deferred finding reopens it.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAccept,
+	}
+	addSessionFlag(cmd)
+	return cmd
+}
+
+func runAccept(cmd *cobra.Command, args []string) error {
+	return runSetStatus(cmd, args[0], findings.StatusAccepted, "")
+}
+
+func newDeferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "defer <id> --until YYYY-MM-DD",
+		Short: "Hide a valid finding until a date",
+		Long:  "Mark a finding as deferred: it is valid but will be addressed later. Deferred findings are hidden from stet list and review output until the --until date, then they are open again. The id can be the full finding id or a unique prefix.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDefer,
+	}
+	cmd.Flags().String("until", "", "Date (YYYY-MM-DD) until which the finding is hidden (required)")
+	addSessionFlag(cmd)
+	return cmd
+}
+
+func runDefer(cmd *cobra.Command, args []string) error {
+	untilFlag, _ := cmd.Flags().GetString("until")
+	if strings.TrimSpace(untilFlag) == "" {
+		return errors.New("defer requires --until YYYY-MM-DD")
+	}
+	until, err := findings.ParseDeferUntil(strings.TrimSpace(untilFlag), time.Now())
+	if err != nil {
+		return err
+	}
+	return runSetStatus(cmd, args[0], findings.StatusDeferred, until)
+}
+
+func newCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment <id> <text>",
+		Short: "Add a note to a finding",
+		Long:  "Attach a timestamped free-text comment (with your git user.name and user.email) to a finding, e.g. why a suggestion is wrong. Comments are shown by stet list and in JSON output, recorded in history with the dismissal, and added to the prompt shadow of a dismissed finding so later reviews see them. The id can be the full finding id or a unique prefix.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runComment,
+	}
+	addSessionFlag(cmd)
+	return cmd
+}
+
+// runComment appends a comment to the finding's thread in the session. For a dismissed
+// finding it also appends a history record with the comment thread in its dismissal.
+func runComment(cmd *cobra.Command, args []string) error {
+	id := strings.TrimSpace(args[0])
+	text := strings.TrimSpace(args[1])
+	if id == "" {
+		return errors.New("comment requires a non-empty finding id")
+	}
+	if text == "" {
+		return errors.New("comment requires non-empty text")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if s.BaselineRef == "" {
+		fmt.Fprintln(os.Stderr, run.ErrNoSession.Error())
+		return errExit(1)
+	}
+	fullID, err := findings.ResolveFindingIDByPrefix(s.Findings, id)
+	if err != nil {
+		return err
+	}
+	name, email, err := git.UserIdentity(repoRoot)
+	if err != nil {
+		return err
+	}
+	session.AddComment(&s, fullID, findings.Comment{Author: name, Email: email, At: time.Now().UTC().Format(time.RFC3339), Text: text})
+	if err := session.Save(sessionDir, &s); err != nil {
+		return err
+	}
+	changed := changedFinding(&s, fullID, time.Now())
+	if len(changed) == 0 || changed[0].Status != findings.StatusDismissed {
+		return nil
+	}
+	diffRef := s.LastReviewedAt
+	if diffRef == "" {
+		diffRef = s.BaselineRef
+	}
+	// Only the commented finding, with its thread: the dismissal itself was recorded
+	// by stet dismiss, and history.SuppressionExamples takes the latest comments by ID.
+	rec := history.Record{
+		DiffRef:      diffRef,
+		ReviewOutput: changed,
+		RunConfig:    history.NewRunConfigSnapshot(cfg.Model, cfg.Strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky),
+	}
+	return history.Append(stateDir, rec, history.DefaultMaxRecords)
+}
+
+// runSetStatus sets the status of the finding with id (full id or unique prefix) in the
+// current session and appends a history record so quality stats see the decision.
+func runSetStatus(cmd *cobra.Command, id string, st findings.Status, until string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New("a non-empty finding id is required")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	sessionName, err := sessionFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+	sessionDir := session.Dir(stateDir, sessionName)
+	s, err := session.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if s.BaselineRef == "" {
+		fmt.Fprintln(os.Stderr, run.ErrNoSession.Error())
+		return errExit(1)
+	}
+	fullID, err := findings.ResolveFindingIDByPrefix(s.Findings, id)
+	if err != nil {
+		return err
+	}
+	session.SetStatus(&s, fullID, st, until, "")
+	if err := session.Save(sessionDir, &s); err != nil {
+		return err
+	}
+	diffRef := s.LastReviewedAt
+	if diffRef == "" {
+		diffRef = s.BaselineRef
+	}
+	rec := history.Record{
+		DiffRef:      diffRef,
+		ReviewOutput: changedFinding(&s, fullID, time.Now()),
+		RunConfig:    history.NewRunConfigSnapshot(cfg.Model, cfg.Strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky),
+	}
+	return history.Append(stateDir, rec, history.DefaultMaxRecords)
+}
+
+// changedFinding returns the finding with id, with its current status, as the
+// ReviewOutput of a status or comment history record. Such records carry only
+// the finding that changed, not a snapshot of the session, so they do not count
+// as reviews.
+func changedFinding(s *session.Session, id string, now time.Time) []findings.Finding {
+	for _, f := range session.FindingsWithStatus(s, now) {
+		if f.ID == id {
+			return []findings.Finding{f}
+		}
+	}
+	return nil
+}
+
+func newBaselineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Manage the committed suppression baseline (.stet/baseline.json)",
+		Long: `The baseline lists fingerprints (file, semantic hunk ID and message stem) of findings the team has accepted as known.
+Reviews drop findings that match it. Commit .stet/baseline.json so every teammate shares it.`,
+	}
+	cmd.AddCommand(newBaselineAddCmd())
+	cmd.AddCommand(newBaselineUpdateCmd())
+	return cmd
+}
+
+func newBaselineAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <id>",
+		Short: "Add a finding to the baseline so future reviews drop it",
+		Long:  "Add the finding's fingerprint to .stet/baseline.json and dismiss it in the session. The id can be the full finding id or a unique prefix.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runBaselineAdd,
+	}
+	addSessionFlag(cmd)
+	return cmd
+}
+
+func newBaselineUpdateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Add all active findings to the baseline and drop entries for deleted files",
+		Long:  "Add every active (open or accepted) finding of the session to .stet/baseline.json and dismiss them, and remove baseline entries whose file no longer exists. Use it to adopt stet on an existing codebase or to accept the current findings as known.",
+		Args:  cobra.NoArgs,
+		RunE:  runBaselineUpdate,
+	}
+	addSessionFlag(cmd)
+	return cmd
+}
+
+// loadBaselineSession returns the repo root and the active session (with its directory)
+// for the baseline commands. It exits 1 when there is no active session.
+func loadBaselineSession(cmd *cobra.Command) (repoRoot, sessionDir string, s session.Session, err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", s, erruser.New("Could not determine current directory.", err)
 	}
-	repoRoot, err := git.RepoRoot(cwd)
+	repoRoot, err = git.RepoRoot(cwd)
 	if err != nil {
-		return err
+		return "", "", s, err
 	}
 	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
 	if err != nil {
-		return err
+		return "", "", s, err
 	}
-	stateDir := cfg.EffectiveStateDir(repoRoot)
-	s, err := session.Load(stateDir)
+	sessionName, err := sessionFromFlag(cmd)
 	if err != nil {
-		return err
+		return "", "", s, err
+	}
+	sessionDir = session.Dir(cfg.EffectiveStateDir(repoRoot), sessionName)
+	s, err = session.Load(sessionDir)
+	if err != nil {
+		return "", "", s, err
 	}
 	if s.BaselineRef == "" {
 		fmt.Fprintln(os.Stderr, run.ErrNoSession.Error())
-		return errExit(1)
+		return "", "", s, errExit(1)
+	}
+	return repoRoot, sessionDir, s, nil
+}
+
+func runBaselineAdd(cmd *cobra.Command, args []string) error {
+	id := strings.TrimSpace(args[0])
+	if id == "" {
+		return errors.New("baseline add requires a non-empty finding id")
+	}
+	repoRoot, sessionDir, s, err := loadBaselineSession(cmd)
+	if err != nil {
+		return err
 	}
 	fullID, err := findings.ResolveFindingIDByPrefix(s.Findings, id)
 	if err != nil {
 		return err
 	}
-	alreadyDismissed := false
-	for _, d := range s.DismissedIDs {
-		if d == fullID {
-			alreadyDismissed = true
-			break
+	var f findings.Finding
+	for _, sf := range s.Findings {
+		if sf.ID == fullID {
+			f = sf
 		}
 	}
-	if !alreadyDismissed {
-		s.DismissedIDs = append(s.DismissedIDs, fullID)
-		if s.FindingPromptContext == nil {
-			s.FindingPromptContext = make(map[string]string)
-		}
-		if ctx := s.FindingPromptContext[fullID]; ctx != "" {
-			s.PromptShadows = append(s.PromptShadows, session.PromptShadow{FindingID: fullID, PromptContext: ctx})
-		}
-		if err := session.Save(stateDir, &s); err != nil {
+	if f.Fingerprint == "" {
+		return erruser.New(fmt.Sprintf("Finding %s has no baseline fingerprint (reviewed by an older stet); run 'stet rerun' and add it again.", findings.ShortID(fullID)), nil)
+	}
+	bl, err := baseline.Load(repoRoot)
+	if err != nil {
+		return err
+	}
+	if bl.Add(f) {
+		if err := baseline.Save(repoRoot, bl); err != nil {
 			return err
 		}
 	}
-	diffRef := s.LastReviewedAt
-	if diffRef == "" {
-		diffRef = s.BaselineRef
+	session.SetStatus(&s, fullID, findings.StatusDismissed, "", "")
+	if err := session.Save(sessionDir, &s); err != nil {
+		return err
 	}
-	ua := history.UserAction{DismissedIDs: []string{fullID}}
-	if reason != "" {
-		d := history.Dismissal{FindingID: fullID, Reason: reason}
-		if s.FindingPromptContext != nil && s.FindingPromptContext[fullID] != "" {
-			d.PromptContext = s.FindingPromptContext[fullID]
+	fmt.Fprintf(os.Stdout, "Added %s to %s; commit it to share with your team.\n", findings.ShortID(fullID), filepath.Join(baseline.Dirname, baseline.Filename))
+	return nil
+}
+
+func runBaselineUpdate(cmd *cobra.Command, args []string) error {
+	repoRoot, sessionDir, s, err := loadBaselineSession(cmd)
+	if err != nil {
+		return err
+	}
+	bl, err := baseline.Load(repoRoot)
+	if err != nil {
+		return err
+	}
+	kept := bl.Findings[:0]
+	for _, e := range bl.Findings {
+		if _, err := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(e.File))); err == nil {
+			kept = append(kept, e)
 		}
-		ua.Dismissals = []history.Dismissal{d}
 	}
-	rec := history.Record{
-		DiffRef:      diffRef,
-		ReviewOutput: s.Findings,
-		UserAction:   ua,
-		RunConfig:    history.NewRunConfigSnapshot(cfg.Model, cfg.Strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky),
+	removed := len(bl.Findings) - len(kept)
+	bl.Findings = kept
+	added, skipped := 0, 0
+	now := time.Now()
+	for _, f := range session.FindingsWithStatus(&s, now) {
+		if !findings.NeedsAttention(f, now) {
+			continue
+		}
+		if f.Fingerprint == "" {
+			skipped++
+			continue
+		}
+		if bl.Add(f) {
+			added++
+		}
+		session.SetStatus(&s, f.ID, findings.StatusDismissed, "", "")
 	}
-	if err := history.Append(stateDir, rec, history.DefaultMaxRecords); err != nil {
+	if err := baseline.Save(repoRoot, bl); err != nil {
+		return err
+	}
+	if err := session.Save(sessionDir, &s); err != nil {
 		return err
 	}
+	fmt.Fprintf(os.Stdout, "%s: %d added, %d removed, %d total.\n", filepath.Join(baseline.Dirname, baseline.Filename), added, removed, len(bl.Findings))
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d finding(s) without a baseline fingerprint were skipped; run 'stet rerun' and update again.\n", skipped)
+	}
 	return nil
 }
 
@@ -1455,10 +2671,22 @@ func runCommitMsg(cmd *cobra.Command, args []string) error {
 		RAGCallersMax:                cfg.RAGCallersMax,
 		RAGCalleesMax:                cfg.RAGCalleesMax,
 		RAGCallGraphMaxTokens:        cfg.RAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:            cfg.RAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:        cfg.RAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:       cfg.RAGRelatedTestsEnabled,
+		CoverageProfile:              cfg.CoverageProfile,
+		SummaryEnabled:               cfg.SummaryEnabled,
+		DanglingRefsEnabled:          cfg.DanglingRefsEnabled,
+		HunkMaxLines:                 cfg.HunkMaxLines,
+		HunkMergeGap:                 cfg.HunkMergeGap,
+		FileBatchingEnabled:          cfg.FileBatchingEnabled,
+		HunkIDNormalization:          cfg.HunkIDNormalization,
 		MinConfidenceKeep:            minKeep,
 		MinConfidenceMaintainability: minMaint,
 		ApplyFPKillList:              &applyFP,
 		Nitpicky:                     cfg.Nitpicky,
+		Filters:                      cfg.Filters,
+		CalibrationEnabled:           cfg.CalibrationEnabled,
 		SuppressionEnabled:           cfg.SuppressionEnabled,
 		SuppressionHistoryCount:     cfg.SuppressionHistoryCount,
 	}
@@ -1492,10 +2720,22 @@ func runCommitMsg(cmd *cobra.Command, args []string) error {
 			RAGCallersMax:                  cfg.RAGCallersMax,
 			RAGCalleesMax:                  cfg.RAGCalleesMax,
 			RAGCallGraphMaxTokens:          cfg.RAGCallGraphMaxTokens,
+			RAGGoTypesEnabled:              cfg.RAGGoTypesEnabled,
+			RAGSymbolIndexEnabled:          cfg.RAGSymbolIndexEnabled,
+			RAGRelatedTestsEnabled:         cfg.RAGRelatedTestsEnabled,
+			CoverageProfile:                cfg.CoverageProfile,
+			SummaryEnabled:                 cfg.SummaryEnabled,
+			DanglingRefsEnabled:            cfg.DanglingRefsEnabled,
+			HunkMaxLines:                   cfg.HunkMaxLines,
+			HunkMergeGap:                   cfg.HunkMergeGap,
+			FileBatchingEnabled:            cfg.FileBatchingEnabled,
+			HunkIDNormalization:            cfg.HunkIDNormalization,
 			MinConfidenceKeep:              minKeep,
 			MinConfidenceMaintainability:   minMaint,
 			ApplyFPKillList:                &applyFP,
 			Nitpicky:                       cfg.Nitpicky,
+			Filters:                        cfg.Filters,
+			CalibrationEnabled:             cfg.CalibrationEnabled,
 			PersistContextLimit:            persistContextLimit,
 			PersistNumCtx:                  persistNumCtx,
 			SuppressionEnabled:            cfg.SuppressionEnabled,
@@ -1529,6 +2769,7 @@ func runCommitMsg(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	warnStaleSuppressions(runStats)
 	w := findingsWriter()
 	return writeFindingsHuman(w, stateDir, &runStats)
 }
@@ -1764,14 +3005,375 @@ func runSkill(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Query review history (list, show, grep)",
+		Long: `Query the review history (history.jsonl and its rotated archives) without external tools.
+Records are numbered from 1 (oldest) in the current history; numbers shift when old archives are pruned.`,
+	}
+	cmd.AddCommand(newHistoryListCmd())
+	cmd.AddCommand(newHistoryShowCmd())
+	cmd.AddCommand(newHistoryGrepCmd())
+	return cmd
+}
+
+func newHistoryListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List history records (number, date, kind, model, finding counts)",
+		Long:  "List history records, oldest first. With --reason, --category or --file, only records with matching findings are listed and counts are of the matching findings.",
+		Args:  cobra.NoArgs,
+		RunE:  runHistoryList,
+	}
+	addHistoryFilterFlags(cmd)
+	cmd.Flags().Int("limit", 0, "Show only the N most recent matching records (0 = all)")
+	cmd.Flags().String("format", "human", "Output format: human or json")
+	return cmd
+}
+
+func newHistoryShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [n]",
+		Short: "Show one history record with its findings and dismissals (default: the latest)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runHistoryShow,
+	}
+	cmd.Flags().String("format", "human", "Output format: human or json")
+	return cmd
+}
+
+func newHistoryGrepCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grep <pattern>",
+		Short: "Search finding messages and suggestions in history (Go regular expression, case-insensitive)",
+		Long:  "Search finding messages and suggestions across history. Each finding is reported once, from the latest record that matches the filters, with its status there.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runHistoryGrep,
+	}
+	addHistoryFilterFlags(cmd)
+	cmd.Flags().String("format", "human", "Output format: human or json")
+	return cmd
+}
+
+// addHistoryFilterFlags adds the record and finding filters shared by history list and grep.
+func addHistoryFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("since", "", "Only records on or after this date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().String("until", "", "Only records on or before this date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().String("model", "", "Only records reviewed with this model")
+	cmd.Flags().String("strictness", "", "Only records reviewed with this strictness preset")
+	cmd.Flags().String("reason", "", "Only findings dismissed with this reason (false_positive, already_correct, wrong_suggestion, out_of_scope)")
+	cmd.Flags().String("category", "", "Only findings in this category")
+	cmd.Flags().String("file", "", "Only findings whose file matches this glob (\"**\" matches any number of directories)")
+}
+
+// historyFilterFromFlags returns the validated history filter from cmd's flags.
+func historyFilterFromFlags(cmd *cobra.Command) (history.Filter, error) {
+	var q history.Filter
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	var err error
+	if q.Since, err = history.ParseDate(since, false); err != nil {
+		return q, err
+	}
+	if q.Until, err = history.ParseDate(until, true); err != nil {
+		return q, err
+	}
+	q.Model, _ = cmd.Flags().GetString("model")
+	q.Strictness, _ = cmd.Flags().GetString("strictness")
+	q.Reason, _ = cmd.Flags().GetString("reason")
+	q.Category, _ = cmd.Flags().GetString("category")
+	q.FileGlob, _ = cmd.Flags().GetString("file")
+	q.Reason = strings.ToLower(strings.TrimSpace(q.Reason))
+	return q, q.Validate()
+}
+
+// historyFormat returns the validated --format flag of cmd.
+func historyFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "human" && format != "json" {
+		return "", errors.New("Invalid output format; use human or json.")
+	}
+	return format, nil
+}
+
+// loadHistory returns all history records of the repository, oldest first. A missing state directory has no history.
+func loadHistory() ([]history.Record, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return nil, err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	if _, err := os.Stat(stateDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return history.ReadRecords(stateDir)
+}
+
+// formatRecordTime returns the record time in local time ("2006-01-02 15:04"), or "-" when unknown.
+func formatRecordTime(r history.Record) string {
+	if t, ok := history.Time(r); ok {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return "-"
+}
+
+// historyRecordSummary is one record in stet history list --format=json.
+type historyRecordSummary struct {
+	Index      int    `json:"index"`
+	RecordedAt string `json:"recorded_at,omitempty"`
+	Kind       string `json:"kind"`
+	DiffRef    string `json:"diff_ref"`
+	Model      string `json:"model,omitempty"`
+	Strictness string `json:"strictness,omitempty"`
+	Findings   int    `json:"findings"`
+	Dismissed  int    `json:"dismissed"`
+}
+
+// runHistoryList prints one line per history record that passes the filters.
+func runHistoryList(cmd *cobra.Command, args []string) error {
+	format, err := historyFormat(cmd)
+	if err != nil {
+		return err
+	}
+	q, err := historyFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	var rows []historyRecordSummary
+	for i, r := range records {
+		if !q.MatchRecord(r) {
+			continue
+		}
+		matched := q.Findings(r)
+		if q.HasFindingFilter() && len(matched) == 0 {
+			continue
+		}
+		dismissed := 0
+		for _, f := range matched {
+			if f.Status == findings.StatusDismissed || (f.Status == "" && slices.Contains(r.UserAction.DismissedIDs, f.ID)) {
+				dismissed++
+			}
+		}
+		row := historyRecordSummary{Index: i + 1, Kind: history.Kind(r), DiffRef: r.DiffRef, Model: history.Model(r), Findings: len(matched), Dismissed: dismissed}
+		if t, ok := history.Time(r); ok {
+			row.RecordedAt = t.UTC().Format(time.RFC3339)
+		}
+		if r.RunConfig != nil {
+			row.Strictness = r.RunConfig.Strictness
+		}
+		rows = append(rows, row)
+	}
+	if limit > 0 && len(rows) > limit {
+		rows = rows[len(rows)-limit:]
+	}
+	w := os.Stdout
+	if format == "json" {
+		if rows == nil {
+			rows = []historyRecordSummary{}
+		}
+		data, err := json.Marshal(map[string]any{"records": rows})
+		if err != nil {
+			return erruser.New("Could not write history.", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No matching history records.")
+		return nil
+	}
+	for _, row := range rows {
+		r := records[row.Index-1]
+		model, strictness := row.Model, row.Strictness
+		if model == "" {
+			model = "-"
+		}
+		if strictness == "" {
+			strictness = "-"
+		}
+		fmt.Fprintf(w, "#%d  %s  %-7s  %.12s  %s  %s  %d finding(s), %d dismissed\n", row.Index, formatRecordTime(r), row.Kind, row.DiffRef, model, strictness, row.Findings, row.Dismissed)
+	}
+	return nil
+}
+
+// runHistoryShow prints one record: its metadata, then each finding with status and dismissal reason.
+func runHistoryShow(cmd *cobra.Command, args []string) error {
+	format, err := historyFormat(cmd)
+	if err != nil {
+		return err
+	}
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return erruser.New("No review history yet.", nil)
+	}
+	n := len(records)
+	if len(args) == 1 {
+		v, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(args[0]), "#"))
+		if err != nil || v < 1 || v > len(records) {
+			return erruser.New(fmt.Sprintf("Invalid record %q; use a number from 1 to %d (see stet history list).", args[0], len(records)), nil)
+		}
+		n = v
+	}
+	r := records[n-1]
+	w := os.Stdout
+	if format == "json" {
+		data, err := json.Marshal(struct {
+			Index  int            `json:"index"`
+			Kind   string         `json:"kind"`
+			Record history.Record `json:"record"`
+		}{n, history.Kind(r), r})
+		if err != nil {
+			return erruser.New("Could not write history.", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	fmt.Fprintf(w, "record: #%d (%s)\n", n, history.Kind(r))
+	fmt.Fprintf(w, "recorded_at: %s\n", formatRecordTime(r))
+	fmt.Fprintf(w, "diff_ref: %s\n", r.DiffRef)
+	if m := history.Model(r); m != "" {
+		fmt.Fprintf(w, "model: %s\n", m)
+	}
+	if r.RunConfig != nil && r.RunConfig.Strictness != "" {
+		fmt.Fprintf(w, "strictness: %s\n", r.RunConfig.Strictness)
+	}
+	fmt.Fprintf(w, "findings: %d\n", len(r.ReviewOutput))
+	fmt.Fprintf(w, "dismissed: %d\n", len(r.UserAction.DismissedIDs))
+	if len(r.ReplacedOutput) > 0 {
+		fmt.Fprintf(w, "replaced: %d (see stet diff-findings)\n", len(r.ReplacedOutput))
+	}
+	if len(r.ReviewOutput) > 0 {
+		fmt.Fprintln(w, "---")
+	}
+	for _, f := range r.ReviewOutput {
+		fmt.Fprintf(w, "%s%s\n", formatFindingLine(f), historyFindingNote(r, f))
+		for _, c := range f.Comments {
+			fmt.Fprintf(w, "    %s\n", formatComment(c))
+		}
+	}
+	return nil
+}
+
+// historyFindingNote returns "  [status]  (reason)" for f in r; parts that are unknown are omitted.
+func historyFindingNote(r history.Record, f findings.Finding) string {
+	note := ""
+	st := f.Status
+	if st == "" && slices.Contains(r.UserAction.DismissedIDs, f.ID) {
+		st = findings.StatusDismissed
+	}
+	if st != "" {
+		note += "  [" + string(st) + "]"
+	}
+	if reason := history.DismissReason(r, f); reason != "" {
+		note += "  (" + reason + ")"
+	}
+	return note
+}
+
+// runHistoryGrep prints the findings whose message or suggestion matches the pattern, once per finding id
+// (from the latest matching record).
+func runHistoryGrep(cmd *cobra.Command, args []string) error {
+	format, err := historyFormat(cmd)
+	if err != nil {
+		return err
+	}
+	q, err := historyFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	re, err := regexp.Compile("(?i)" + args[0])
+	if err != nil {
+		return erruser.New(fmt.Sprintf("Invalid pattern %q.", args[0]), err)
+	}
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	type match struct {
+		Index      int              `json:"index"`
+		RecordedAt string           `json:"recorded_at,omitempty"`
+		Reason     string           `json:"reason,omitempty"`
+		Finding    findings.Finding `json:"finding"`
+	}
+	var matches []match
+	latest := make(map[string]int)
+	for i, r := range records {
+		if !q.MatchRecord(r) {
+			continue
+		}
+		for _, f := range q.Findings(r) {
+			if !re.MatchString(f.Message) && !re.MatchString(f.Suggestion) {
+				continue
+			}
+			if f.Status == "" && slices.Contains(r.UserAction.DismissedIDs, f.ID) {
+				f.Status = findings.StatusDismissed
+			}
+			m := match{Index: i + 1, Reason: history.DismissReason(r, f), Finding: f}
+			if t, ok := history.Time(r); ok {
+				m.RecordedAt = t.UTC().Format(time.RFC3339)
+			}
+			key := f.ID
+			if key == "" {
+				key = fmt.Sprintf("%s:%d:%s", f.File, f.Line, f.Message)
+			}
+			if j, ok := latest[key]; ok {
+				matches[j] = m
+				continue
+			}
+			latest[key] = len(matches)
+			matches = append(matches, m)
+		}
+	}
+	w := os.Stdout
+	if format == "json" {
+		if matches == nil {
+			matches = []match{}
+		}
+		data, err := json.Marshal(map[string]any{"matches": matches})
+		if err != nil {
+			return erruser.New("Could not write history.", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	if len(matches) == 0 {
+		fmt.Fprintln(w, "No matching findings in history.")
+		return nil
+	}
+	for _, m := range matches {
+		r := records[m.Index-1]
+		fmt.Fprintf(w, "#%d  %s  %s%s\n", m.Index, formatRecordTime(r), formatFindingLine(m.Finding), historyFindingNote(r, m.Finding))
+	}
+	fmt.Fprintf(w, "%d finding(s).\n", len(matches))
+	return nil
+}
+
 func newStatsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stats",
-		Short: "Impact reporting (volume, quality, energy)",
+		Short: "Impact reporting (volume, quality, energy, calibration)",
 	}
 	cmd.AddCommand(newStatsVolumeCmd())
 	cmd.AddCommand(newStatsQualityCmd())
 	cmd.AddCommand(newStatsEnergyCmd())
+	cmd.AddCommand(newStatsCalibrationCmd())
 	return cmd
 }
 
@@ -1893,6 +3495,83 @@ func runStatsQuality(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func newStatsCalibrationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calibration",
+		Short: "Show the confidence calibration curves fitted from history",
+		Long: "Show, per model and category, how the model's self-reported confidence maps to the rate at which its findings were kept (not dismissed). " +
+			"The curves are refitted from history on 'stet finish' and applied before the confidence thresholds; use --refit to refit now.",
+		RunE: runStatsCalibration,
+	}
+	cmd.Flags().String("format", "human", "Output format: human or json")
+	cmd.Flags().Bool("refit", false, "Refit the curves from history before showing them")
+	return cmd
+}
+
+func runStatsCalibration(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return erruser.New("Could not determine current directory.", err)
+	}
+	repoRoot, err := git.RepoRoot(cwd)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(context.Background(), config.LoadOptions{RepoRoot: repoRoot})
+	if err != nil {
+		return err
+	}
+	stateDir := cfg.EffectiveStateDir(repoRoot)
+	format, _ := cmd.Flags().GetString("format")
+	if format != "human" && format != "json" {
+		return errors.New("Invalid output format; use human or json.")
+	}
+	refit, _ := cmd.Flags().GetBool("refit")
+	var cal *calibration.Calibration
+	if refit {
+		cal, err = calibration.Refit(stateDir, time.Now())
+	} else {
+		cal, err = calibration.Load(stateDir)
+	}
+	if err != nil {
+		return err
+	}
+	w := os.Stdout
+	if format == "json" {
+		if cal == nil {
+			cal = &calibration.Calibration{Version: calibration.Version, Curves: []calibration.Curve{}}
+		}
+		data, err := json.Marshal(cal)
+		if err != nil {
+			return erruser.New("Could not write calibration.", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	if cal == nil || len(cal.Curves) == 0 {
+		fmt.Fprintf(w, "No calibration curves yet: a curve needs %d decided findings of one model in history. Curves are refitted on 'stet finish'.\n", calibration.MinSamples)
+		if !cfg.CalibrationEnabled {
+			fmt.Fprintln(w, "Calibration is disabled (calibration_enabled = false).")
+		}
+		return nil
+	}
+	fmt.Fprintf(w, "Fitted %s from history.\n", cal.FittedAt)
+	if !cfg.CalibrationEnabled {
+		fmt.Fprintln(w, "Calibration is disabled (calibration_enabled = false); reviews use the model's confidence.")
+	}
+	for _, c := range cal.Curves {
+		category := c.Category
+		if category == calibration.AllCategories {
+			category = "all categories"
+		}
+		fmt.Fprintf(w, "\n%s  %s  (%d findings, %.0f%% kept)\n", c.Model, category, c.Samples, c.KeptRate*100)
+		for _, p := range c.Points {
+			fmt.Fprintf(w, "  %.2f -> %.2f  (%d)\n", p.Confidence, p.Calibrated, p.Count)
+		}
+	}
+	return nil
+}
+
 func newStatsEnergyCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "energy",
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -14,7 +15,10 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"stet/cli/internal/baseline"
+	"stet/cli/internal/calibration"
 	"stet/cli/internal/config"
 	"stet/cli/internal/findings"
 	"stet/cli/internal/git"
@@ -509,6 +513,38 @@ func TestRunCLI_strictnessInvalidExits1(t *testing.T) {
 	}
 }
 
+func TestRunCLI_startAgainstWithRefFails(t *testing.T) {
+	// Do not run in parallel: test changes process cwd and stderr.
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Chdir(orig)
+	}()
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	oldStderr := os.Stderr
+	os.Stderr = w
+	t.Cleanup(func() { os.Stderr = oldStderr })
+	got := runCLI([]string{"start", "HEAD~1", "--against", "HEAD~1", "--dry-run"})
+	_ = w.Close()
+	var stderr bytes.Buffer
+	_, _ = io.Copy(&stderr, r)
+	if got != 1 {
+		t.Errorf("runCLI(start HEAD~1 --against HEAD~1) = %d, want 1", got)
+	}
+	if !strings.Contains(stderr.String(), "--against") {
+		t.Errorf("stderr should explain the conflict; got:\n%s", stderr.String())
+	}
+}
+
 func TestRunCLI_startWithStrictnessThenRunUsesSessionStrictness(t *testing.T) {
 	// Start with --strictness=lenient; run without --strictness should use session strictness.
 	repo := initRepo(t)
@@ -1333,6 +1369,215 @@ func TestRunCLI_listPrintsFindingsWithIds(t *testing.T) {
 	}
 }
 
+func TestRunCLI_startPerCommitThenListByCommit(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	if got := runCLI([]string{"start", "HEAD~2", "--per-commit", "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(start --per-commit --dry-run) = %d, want 0", got)
+	}
+	var out struct {
+		Findings []findings.Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil || len(out.Findings) != 2 {
+		t.Fatalf("want two findings (one per commit); err=%v findings=%d", err, len(out.Findings))
+	}
+	for _, f := range out.Findings {
+		if f.Commit == "" {
+			t.Errorf("finding %s has no commit in JSON", f.ID)
+		}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = oldStdout })
+	if got := runCLI([]string{"list", "--commit", "HEAD~1"}); got != 0 {
+		t.Fatalf("runCLI(list --commit HEAD~1) = %d, want 0", got)
+	}
+	_ = w.Close()
+	var stdout bytes.Buffer
+	_, _ = io.Copy(&stdout, r)
+	outStr := stdout.String()
+	if !strings.Contains(outStr, "f1.txt") || strings.Contains(outStr, "f2.txt") {
+		t.Errorf("list --commit HEAD~1 should list only f1.txt (commit c1); got:\n%s", outStr)
+	}
+	if got := runCLI([]string{"list", "--commit", "no-such-commit"}); got == 0 {
+		t.Error("runCLI(list --commit no-such-commit) = 0, want non-zero")
+	}
+}
+
+func TestRunCLI_listSortTopJSON(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return io.Discard }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	if got := runCLI([]string{"start", "HEAD~2", "--per-commit", "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(start --per-commit --dry-run) = %d, want 0", got)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = oldStdout })
+	if got := runCLI([]string{"list", "--sort=rank", "--top", "1", "--json"}); got != 0 {
+		t.Fatalf("runCLI(list --sort=rank --top 1 --json) = %d, want 0", got)
+	}
+	_ = w.Close()
+	var out struct {
+		Findings []findings.Finding `json:"findings"`
+	}
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		t.Fatalf("decode list --json: %v", err)
+	}
+	if len(out.Findings) != 1 || out.Findings[0].Rank <= 0 {
+		t.Errorf("list --top 1 --json = %+v, want one finding with a rank", out.Findings)
+	}
+	if got := runCLI([]string{"list", "--sort=confidence"}); got == 0 {
+		t.Error("runCLI(list --sort=confidence) = 0, want non-zero")
+	}
+}
+
+func TestRunCLI_diffFindings(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	if got := runCLI([]string{"start", "HEAD~1", "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(start --dry-run) = %d, want 0", got)
+	}
+	var started struct {
+		Findings []findings.Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &started); err != nil || len(started.Findings) == 0 {
+		t.Fatalf("need at least one finding; err=%v", err)
+	}
+	// Record a rerun --replace whose replaced findings had another severity and one more finding.
+	replaced := append([]findings.Finding(nil), started.Findings...)
+	replaced[0].Severity = findings.SeverityError
+	replaced = append(replaced, findings.Finding{ID: "gone1", File: "other.go", Line: 3, Severity: findings.SeverityWarning, Category: findings.CategoryBug, Message: "Old finding."})
+	rec := history.Record{DiffRef: "HEAD", ReviewOutput: started.Findings, UserAction: history.UserAction{ReplaceFindings: true}, ReplacedOutput: replaced}
+	if err := history.Append(filepath.Join(repo, ".review"), rec, history.DefaultMaxRecords); err != nil {
+		t.Fatal(err)
+	}
+	capture := func(args ...string) (int, string) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		oldStdout := os.Stdout
+		os.Stdout = w
+		code := runCLI(args)
+		os.Stdout = oldStdout
+		_ = w.Close()
+		var out bytes.Buffer
+		_, _ = io.Copy(&out, r)
+		return code, out.String()
+	}
+	// Triage after the replace appends a status record; the default still compares with the replace.
+	if code, out := capture("accept", started.Findings[0].ID); code != 0 {
+		t.Fatalf("runCLI(accept) = %d, want 0\noutput: %s", code, out)
+	}
+	code, out := capture("diff-findings", "--format=json")
+	if code != 0 {
+		t.Fatalf("runCLI(diff-findings) = %d, want 0\noutput: %s", code, out)
+	}
+	var cmp findings.Comparison
+	if err := json.Unmarshal([]byte(out), &cmp); err != nil {
+		t.Fatalf("parse diff-findings JSON: %v\noutput: %s", err, out)
+	}
+	if len(cmp.New) != 0 || len(cmp.Gone) != 1 || cmp.Gone[0].ID != "gone1" || len(cmp.Changed) != 1 || cmp.Changed[0].Match != findings.MatchID || cmp.Unchanged != len(started.Findings)-1 {
+		t.Errorf("diff-findings = %+v", cmp)
+	}
+
+	oldFile := filepath.Join(t.TempDir(), "old.json")
+	newFile := filepath.Join(t.TempDir(), "new.json")
+	if err := os.WriteFile(oldFile, []byte(`[]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newFile, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	code, out = capture("diff-findings", oldFile, newFile)
+	if code != 0 || !strings.Contains(out, fmt.Sprintf("%d new, 0 gone, 0 changed, 0 unchanged.", len(started.Findings))) {
+		t.Errorf("diff-findings files = %d, output:\n%s", code, out)
+	}
+	if code, _ := capture("diff-findings", oldFile); code == 0 {
+		t.Error("diff-findings with one file: want non-zero exit")
+	}
+	if code, _ := capture("diff-findings", "--record", "5"); code == 0 {
+		t.Error("diff-findings --record beyond history: want non-zero exit")
+	}
+}
+
+func TestRunCLI_sessionExportImport(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return io.Discard }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	bundle := filepath.Join(t.TempDir(), "review.stet.gz")
+	if got := runCLI([]string{"session", "export", bundle}); got != 1 {
+		t.Errorf("runCLI(session export) without session = %d, want 1", got)
+	}
+	if got := runCLI([]string{"start", "HEAD~1", "--dry-run", "--quiet"}); got != 0 {
+		t.Fatalf("runCLI(start --dry-run) = %d, want 0", got)
+	}
+	if got := runCLI([]string{"session", "export", bundle}); got != 0 {
+		t.Fatalf("runCLI(session export) = %d, want 0", got)
+	}
+	if got := runCLI([]string{"session", "import", bundle}); got == 0 {
+		t.Error("runCLI(session import) into the active session = 0, want non-zero")
+	}
+	if got := runCLI([]string{"session", "import", bundle, "--session", "teammate"}); got != 0 {
+		t.Fatalf("runCLI(session import --session teammate) = %d, want 0", got)
+	}
+	if got := runCLI([]string{"list", "--session", "teammate"}); got != 0 {
+		t.Errorf("runCLI(list --session teammate) = %d, want 0", got)
+	}
+}
+
 func TestRunCLI_listNoSessionExitsNonZero(t *testing.T) {
 	repo := initRepo(t)
 	orig, err := os.Getwd()
@@ -1521,6 +1766,270 @@ func TestRunCLI_dismissIdempotent(t *testing.T) {
 	}
 }
 
+func TestRunCLI_acceptAndDefer(t *testing.T) {
+	// Do not run in parallel: test changes cwd, os.Stdout and getFindingsOut.
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	if got := runCLI([]string{"start", "HEAD~1", "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(start --dry-run) = %d, want 0", got)
+	}
+	var out struct {
+		Findings []map[string]interface{} `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil || len(out.Findings) == 0 {
+		t.Fatalf("need at least one finding; err=%v", err)
+	}
+	id, _ := out.Findings[0]["id"].(string)
+	if st, _ := out.Findings[0]["status"].(string); st != "open" {
+		t.Errorf("JSON status = %q, want open", st)
+	}
+	list := func(args ...string) string {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		oldStdout := os.Stdout
+		os.Stdout = w
+		code := runCLI(append([]string{"list"}, args...))
+		os.Stdout = oldStdout
+		_ = w.Close()
+		var b bytes.Buffer
+		_, _ = io.Copy(&b, r)
+		if code != 0 {
+			t.Fatalf("runCLI(list %v) = %d", args, code)
+		}
+		return b.String()
+	}
+	short := findings.ShortID(id)
+
+	if got := runCLI([]string{"dismiss", id, "false_positive"}); got != 0 {
+		t.Fatalf("dismiss = %d", got)
+	}
+	if got := runCLI([]string{"accept", short}); got != 0 {
+		t.Fatalf("accept = %d", got)
+	}
+	cfg, _ := loadConfigForTest(repo)
+	stateDir := cfg.EffectiveStateDir(repo)
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	if len(s.DismissedIDs) != 0 {
+		t.Errorf("accept should reopen the dismissed finding; DismissedIDs = %v", s.DismissedIDs)
+	}
+	if l := list("--all"); !strings.Contains(l, short) || !strings.Contains(l, "[accepted]") {
+		t.Errorf("list --all after accept = %q", l)
+	}
+
+	if got := runCLI([]string{"defer", short}); got == 0 {
+		t.Error("defer without --until should fail")
+	}
+	if got := runCLI([]string{"defer", short, "--until", "2000-01-01"}); got == 0 {
+		t.Error("defer with a past date should fail")
+	}
+	until := time.Now().AddDate(0, 0, 7).Format(findings.DeferDateLayout)
+	if got := runCLI([]string{"defer", short, "--until", until}); got != 0 {
+		t.Fatalf("defer = %d", got)
+	}
+	if l := list(); strings.Contains(l, short) {
+		t.Errorf("deferred finding should be hidden from list; got %q", l)
+	}
+	if l := list("--all"); !strings.Contains(l, "[deferred until "+until+"]") {
+		t.Errorf("list --all after defer = %q", l)
+	}
+	recs, err := history.ReadRecords(stateDir)
+	if err != nil || len(recs) == 0 {
+		t.Fatalf("ReadRecords: %v (%d records)", err, len(recs))
+	}
+	last := recs[len(recs)-1]
+	for _, f := range last.ReviewOutput {
+		if f.ID == id && (f.Status != findings.StatusDeferred || f.DeferredUntil != until) {
+			t.Errorf("history status = %q until %q, want deferred until %s", f.Status, f.DeferredUntil, until)
+		}
+	}
+}
+
+func TestRunCLI_comment(t *testing.T) {
+	// Do not run in parallel: test changes cwd, os.Stdout and getFindingsOut.
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	if got := runCLI([]string{"start", "HEAD~1", "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(start --dry-run) = %d, want 0", got)
+	}
+	var out struct {
+		Findings []findings.Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil || len(out.Findings) == 0 {
+		t.Fatalf("need at least one finding; err=%v", err)
+	}
+	id := out.Findings[0].ID
+	if got := runCLI([]string{"comment", id, ""}); got == 0 {
+		t.Error("comment with empty text should fail")
+	}
+	if got := runCLI([]string{"comment", findings.ShortID(id), "Caller already\nchecks nil"}); got != 0 {
+		t.Fatalf("comment = %d", got)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	code := runCLI([]string{"list"})
+	os.Stdout = oldStdout
+	_ = w.Close()
+	var listOut bytes.Buffer
+	_, _ = io.Copy(&listOut, r)
+	if code != 0 {
+		t.Fatalf("list = %d", code)
+	}
+	if !regexp.MustCompile(`(?m)^    \d{4}-\d{2}-\d{2} \d{2}:\d{2} Test: Caller already checks nil$`).MatchString(listOut.String()) {
+		t.Errorf("list should show the comment under the finding; got:\n%s", listOut.String())
+	}
+
+	if got := runCLI([]string{"dismiss", id, "wrong_suggestion"}); got != 0 {
+		t.Fatalf("dismiss = %d", got)
+	}
+	if got := runCLI([]string{"comment", id, "Confirmed by lead"}); got != 0 {
+		t.Fatalf("second comment = %d", got)
+	}
+	cfg, _ := loadConfigForTest(repo)
+	stateDir := cfg.EffectiveStateDir(repo)
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	thread := session.Comments(&s, id)
+	if len(thread) != 2 || thread[0].Author != "Test" || thread[0].Email != "test@stet.local" || thread[0].At == "" {
+		t.Errorf("session comments = %+v", thread)
+	}
+	for _, sh := range s.PromptShadows {
+		if sh.FindingID == id && sh.Note != "Caller already checks nil; Confirmed by lead" {
+			t.Errorf("prompt shadow note = %q", sh.Note)
+		}
+	}
+	recs, err := history.ReadRecords(stateDir)
+	if err != nil || len(recs) < 2 {
+		t.Fatalf("ReadRecords: %v (%d records)", err, len(recs))
+	}
+	dismissRec, commentRec := recs[len(recs)-2], recs[len(recs)-1]
+	if len(dismissRec.UserAction.Dismissals) != 1 || len(dismissRec.UserAction.Dismissals[0].Comments) != 1 {
+		t.Errorf("dismiss record dismissals = %+v, want one with the first comment", dismissRec.UserAction.Dismissals)
+	}
+	if len(commentRec.UserAction.DismissedIDs) != 0 || len(commentRec.UserAction.Dismissals) != 0 {
+		t.Errorf("comment record user_action = %+v, want no new dismissal", commentRec.UserAction)
+	}
+	if out := commentRec.ReviewOutput; len(out) != 1 || out[0].ID != id || out[0].Status != findings.StatusDismissed || len(out[0].Comments) != 2 {
+		t.Errorf("comment record review_output = %+v, want only the dismissed finding with its thread", out)
+	}
+	examples, err := history.SuppressionExamples(stateDir, 10, 10)
+	if err != nil || len(examples) != 1 || !strings.Contains(examples[0], "Confirmed by lead") {
+		t.Errorf("SuppressionExamples = %q, %v; want one example with the whole thread", examples, err)
+	}
+}
+
+func TestRunCLI_baselineAddAndUpdate(t *testing.T) {
+	// Do not run in parallel: test changes cwd, os.Stdout and getFindingsOut.
+	repo := initRepo(t)
+	writeFile(t, repo, "f3.txt", "c\n")
+	runGit(t, repo, "git", "add", "f3.txt")
+	runGit(t, repo, "git", "commit", "-m", "c3")
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	startJSON := func(ref string) []findings.Finding {
+		t.Helper()
+		buf.Reset()
+		if got := runCLI([]string{"start", ref, "--dry-run", "--json"}); got != 0 {
+			t.Fatalf("runCLI(start %s --dry-run) = %d, want 0", ref, got)
+		}
+		var out struct {
+			Findings []findings.Finding `json:"findings"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("unmarshal findings: %v", err)
+		}
+		return out.Findings
+	}
+	first := startJSON("HEAD~2")
+	if len(first) < 2 {
+		t.Fatalf("need at least two findings, got %d", len(first))
+	}
+	oldStdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = devNull
+	t.Cleanup(func() { os.Stdout = oldStdout; _ = devNull.Close() })
+	if got := runCLI([]string{"baseline", "add", findings.ShortID(first[0].ID)}); got != 0 {
+		t.Fatalf("baseline add = %d", got)
+	}
+	bl, err := baseline.Load(repo)
+	if err != nil || len(bl.Findings) != 1 || bl.Findings[0].Fingerprint != first[0].Fingerprint {
+		t.Fatalf("baseline after add = %+v, %v", bl, err)
+	}
+	if got := runCLI([]string{"baseline", "update"}); got != 0 {
+		t.Fatalf("baseline update = %d", got)
+	}
+	bl, err = baseline.Load(repo)
+	if err != nil || len(bl.Findings) != len(first) {
+		t.Fatalf("baseline after update has %d entries (err %v), want %d", len(bl.Findings), err, len(first))
+	}
+	cfg, _ := loadConfigForTest(repo)
+	s, err := session.Load(cfg.EffectiveStateDir(repo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.DismissedIDs) != len(first) {
+		t.Errorf("baselined findings should be dismissed in the session; DismissedIDs = %v", s.DismissedIDs)
+	}
+	if got := runCLI([]string{"finish"}); got != 0 {
+		t.Fatalf("finish = %d", got)
+	}
+	runGit(t, repo, "git", "add", ".stet")
+	runGit(t, repo, "git", "commit", "-m", "baseline")
+	os.Stdout = oldStdout
+	for _, f := range startJSON("HEAD~3") {
+		if bl.Contains(f.Fingerprint) {
+			t.Errorf("baselined finding %s:%d reported again", f.File, f.Line)
+		}
+	}
+}
+
 func TestRunCLI_dismissWritesPromptShadows(t *testing.T) {
 	// Do not run in parallel: test changes cwd and overrides getFindingsOut to capture output.
 	repo := initRepo(t)
@@ -2328,6 +2837,140 @@ func TestRunCLI_statsQualityJSONInRepo(t *testing.T) {
 	}
 }
 
+func TestRunCLI_historyListShowGrep(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	stateDir := filepath.Join(repo, ".review")
+	recs := []history.Record{
+		{DiffRef: "aaa", RecordedAt: "2026-02-01T10:00:00Z", RunConfig: history.NewRunConfigSnapshot("model-a", "default", 0, 0, false),
+			ReviewOutput: []findings.Finding{{ID: "f1", File: "pkg/a.go", Line: 3, Severity: findings.SeverityWarning, Category: findings.CategoryStyle, Message: "Variable name is unclear."}},
+			UserAction:   history.UserAction{DismissedIDs: []string{"f1"}, Dismissals: []history.Dismissal{{FindingID: "f1", Reason: history.ReasonFalsePositive}}}},
+		{DiffRef: "bbb", RecordedAt: "2026-03-01T10:00:00Z", RunConfig: history.NewRunConfigSnapshot("model-b", "strict", 0, 0, false),
+			ReviewOutput: []findings.Finding{{ID: "f2", File: "cmd/main.go", Line: 7, Severity: findings.SeverityError, Category: findings.CategoryBug, Message: "Error from Close is ignored."}},
+			UserAction:   history.UserAction{FinishedAt: "2026-03-01T10:00:00Z"}},
+	}
+	for _, rec := range recs {
+		if err := history.Append(stateDir, rec, history.DefaultMaxRecords); err != nil {
+			t.Fatal(err)
+		}
+	}
+	capture := func(args ...string) (int, string) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		oldStdout := os.Stdout
+		os.Stdout = w
+		code := runCLI(args)
+		os.Stdout = oldStdout
+		_ = w.Close()
+		var out bytes.Buffer
+		_, _ = io.Copy(&out, r)
+		return code, out.String()
+	}
+	code, out := capture("history", "list", "--since", "2026-02-15", "--format=json")
+	var list struct {
+		Records []struct {
+			Index int    `json:"index"`
+			Kind  string `json:"kind"`
+			Model string `json:"model"`
+		} `json:"records"`
+	}
+	if code != 0 || json.Unmarshal([]byte(out), &list) != nil || len(list.Records) != 1 || list.Records[0].Index != 2 || list.Records[0].Kind != history.KindFinish || list.Records[0].Model != "model-b" {
+		t.Errorf("history list --since = %d, %s", code, out)
+	}
+	code, out = capture("history", "list", "--reason", "false_positive")
+	if code != 0 || !strings.Contains(out, "#1  ") || strings.Contains(out, "#2  ") {
+		t.Errorf("history list --reason = %d, output:\n%s", code, out)
+	}
+	code, out = capture("history", "show", "1")
+	if code != 0 || !strings.Contains(out, "model: model-a") || !strings.Contains(out, "[dismissed]  (false_positive)") {
+		t.Errorf("history show 1 = %d, output:\n%s", code, out)
+	}
+	code, out = capture("history", "grep", "ignored", "--file", "cmd/**")
+	if code != 0 || !strings.Contains(out, "Error from Close is ignored.") || !strings.Contains(out, "1 finding(s).") {
+		t.Errorf("history grep = %d, output:\n%s", code, out)
+	}
+	if code, _ := capture("history", "show", "3"); code == 0 {
+		t.Error("history show 3 (out of range): want non-zero exit")
+	}
+	if code, _ := capture("history", "list", "--category", "nonsense"); code == 0 {
+		t.Error("history list --category nonsense: want non-zero exit")
+	}
+}
+
+func TestRunCLI_statsCalibrationRefit(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var out []findings.Finding
+	var dismissed []string
+	for i := 0; i < calibration.MinSamples; i++ {
+		id := fmt.Sprintf("f%02d", i)
+		f := findings.Finding{ID: id, File: "a.go", Line: i + 1, Severity: findings.SeverityWarning, Category: findings.CategoryStyle, Confidence: 0.9, Message: "m"}
+		if i%2 == 0 {
+			dismissed = append(dismissed, id)
+		} else {
+			f.Status = findings.StatusAccepted
+		}
+		out = append(out, f)
+	}
+	rec := history.Record{DiffRef: "HEAD", ReviewOutput: out, UserAction: history.UserAction{DismissedIDs: dismissed}, RunConfig: history.NewRunConfigSnapshot("model-x", "default", 0, 0, false)}
+	if err := history.Append(filepath.Join(repo, ".review"), rec, history.DefaultMaxRecords); err != nil {
+		t.Fatal(err)
+	}
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = oldStdout })
+	got := runCLI([]string{"stats", "calibration", "--refit", "--format=json"})
+	_ = w.Close()
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	if got != 0 {
+		t.Fatalf("runCLI(stats calibration --refit) = %d, want 0\noutput: %s", got, buf.String())
+	}
+	var cal calibration.Calibration
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &cal); err != nil {
+		t.Fatalf("parse calibration JSON: %v\noutput: %s", err, buf.Bytes())
+	}
+	if len(cal.Curves) != 2 || cal.Curves[0].Model != "model-x" || cal.Curves[0].KeptRate != 0.5 {
+		t.Errorf("curves = %+v, want model-x all-categories and style curves, half kept", cal.Curves)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".review", calibration.Filename)); err != nil {
+		t.Errorf("calibration file not saved: %v", err)
+	}
+	r2, w2, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = w2
+	got = runCLI([]string{"stats", "calibration"})
+	_ = w2.Close()
+	buf.Reset()
+	_, _ = io.Copy(&buf, r2)
+	if got != 0 || !strings.Contains(buf.String(), "model-x  style  (20 findings, 50% kept)") || !strings.Contains(buf.String(), "0.90 -> 0.50") {
+		t.Errorf("runCLI(stats calibration) = %d, output:\n%s", got, buf.String())
+	}
+}
+
 func TestRunCLI_statsEnergyFromNonRepoExits1(t *testing.T) {
 	dir := t.TempDir()
 	orig, err := os.Getwd()
@@ -2439,3 +3082,136 @@ func TestParseContextPreset(t *testing.T) {
 		})
 	}
 }
+
+func TestRunCLI_reviewPatchFromFileAndStdin(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	patchFile := filepath.Join(t.TempDir(), "change.patch")
+	patch := "--- f1.txt\n+++ f1.txt\n@@ -1 +1,2 @@\n a\n+added\n--- /dev/null\n+++ f3.txt\n@@ -0,0 +1 @@\n+new\n"
+	if err := os.WriteFile(patchFile, []byte(patch), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+
+	if got := runCLI([]string{"review-patch", patchFile, "--dry-run", "--json"}); got != 0 {
+		t.Fatalf("runCLI(review-patch file --dry-run --json) = %d, want 0", got)
+	}
+	var out struct {
+		Findings []findings.Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(out.Findings) != 2 || out.Findings[0].File != "f1.txt" || out.Findings[1].File != "f3.txt" {
+		t.Errorf("want one canned finding each in f1.txt and f3.txt; got %+v", out.Findings)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".review", "session.json")); !os.IsNotExist(err) {
+		t.Errorf("review-patch must not create a session; stat err = %v", err)
+	}
+
+	f, err := os.Open(patchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	oldStdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() { os.Stdin = oldStdin })
+	buf.Reset()
+	if got := runCLI([]string{"review-patch", "-", "--dry-run", "--quiet"}); got != 0 {
+		t.Fatalf("runCLI(review-patch - --dry-run) = %d, want 0", got)
+	}
+	if !strings.Contains(buf.String(), "f3.txt:1") || !strings.Contains(buf.String(), "2 finding(s).") {
+		t.Errorf("human output from stdin patch:\n%s", buf.String())
+	}
+
+	if got := runCLI([]string{"review-patch", filepath.Join(repo, "missing.patch"), "--dry-run"}); got == 0 {
+		t.Error("runCLI(review-patch missing file) = 0, want non-zero")
+	}
+}
+
+func TestRunCLI_namedSessions(t *testing.T) {
+	repo := initRepo(t)
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	origOut := getFindingsOut
+	getFindingsOut = func() io.Writer { return &buf }
+	t.Cleanup(func() { getFindingsOut = origOut })
+	captureStdout := func(args ...string) (int, string) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		oldStdout := os.Stdout
+		os.Stdout = w
+		code := runCLI(args)
+		os.Stdout = oldStdout
+		_ = w.Close()
+		var out bytes.Buffer
+		_, _ = io.Copy(&out, r)
+		return code, out.String()
+	}
+
+	// Two sessions at the same baseline run side by side.
+	if got := runCLI([]string{"start", "HEAD~1", "--dry-run", "--quiet"}); got != 0 {
+		t.Fatalf("runCLI(start) = %d, want 0", got)
+	}
+	if got := runCLI([]string{"start", "HEAD~1", "--session", "colleague", "--dry-run", "--quiet"}); got != 0 {
+		t.Fatalf("runCLI(start --session colleague) = %d, want 0", got)
+	}
+	code, out := captureStdout("sessions")
+	if code != 0 || !strings.Contains(out, "(default)") || !strings.Contains(out, "colleague") {
+		t.Fatalf("sessions = %d:\n%s\nwant both sessions listed", code, out)
+	}
+	code, out = captureStdout("list", "--session", "colleague")
+	if code != 0 || !strings.Contains(out, "f2.txt") {
+		t.Errorf("list --session colleague = %d:\n%s", code, out)
+	}
+	if got := runCLI([]string{"status", "--session", "nobody"}); got == 0 {
+		t.Error("runCLI(status --session nobody) = 0, want non-zero (no such session)")
+	}
+	if got := runCLI([]string{"list", "--session", "../escape"}); got == 0 {
+		t.Error("runCLI(list --session ../escape) = 0, want non-zero (invalid name)")
+	}
+
+	if got := runCLI([]string{"finish", "--session", "colleague"}); got != 0 {
+		t.Fatalf("runCLI(finish --session colleague) = %d, want 0", got)
+	}
+	// Cleanup keeps the default session's worktree.
+	if got := runCLI([]string{"cleanup"}); got != 0 {
+		t.Fatalf("runCLI(cleanup) = %d, want 0", got)
+	}
+	code, out = captureStdout("status")
+	if code != 0 {
+		t.Fatalf("status after finishing the named session = %d, want 0", code)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if wt, ok := strings.CutPrefix(line, "worktree: "); ok {
+			if _, err := os.Stat(wt); err != nil {
+				t.Errorf("default session worktree should survive cleanup: %v", err)
+			}
+		}
+	}
+	code, out = captureStdout("sessions")
+	if code != 0 || strings.Contains(out, "colleague") {
+		t.Errorf("sessions after finish = %d:\n%s\nwant colleague gone", code, out)
+	}
+}
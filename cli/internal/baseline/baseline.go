@@ -0,0 +1,163 @@
+// Package baseline provides the committed suppression baseline: .stet/baseline.json
+// lists fingerprints of accepted findings so every teammate's reviews drop them
+// (like a linter baseline), unlike .review/history.jsonl which is per-developer.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/findings"
+	"stet/cli/internal/hunkid"
+)
+
+// Version is the current baseline file format version.
+const Version = 1
+
+// Dirname and Filename locate the baseline under the repository root.
+const (
+	Dirname  = ".stet"
+	Filename = "baseline.json"
+)
+
+// maxStemLen bounds the message stem so long explanations do not make the
+// fingerprint sensitive to wording beyond the first sentence.
+const maxStemLen = 80
+
+// Entry is one baselined finding. File, Message and Category are informational
+// (they make the committed file reviewable); matching uses Fingerprint only.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file"`
+	Message     string `json:"message,omitempty"`
+	Category    string `json:"category,omitempty"`
+}
+
+// Baseline is the content of .stet/baseline.json.
+type Baseline struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+}
+
+// Path returns the baseline file path for repoRoot.
+func Path(repoRoot string) string {
+	return filepath.Join(repoRoot, Dirname, Filename)
+}
+
+// Load reads the baseline of repoRoot. A missing file yields an empty baseline
+// and no error; invalid JSON or a newer version is an error.
+func Load(repoRoot string) (*Baseline, error) {
+	data, err := os.ReadFile(Path(repoRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Baseline{Version: Version}, nil
+		}
+		return nil, erruser.New("Could not read baseline file.", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, erruser.New("Baseline file "+filepath.Join(Dirname, Filename)+" is invalid.", err)
+	}
+	if b.Version > Version {
+		return nil, erruser.New("Baseline file "+filepath.Join(Dirname, Filename)+" was written by a newer stet; upgrade stet.", nil)
+	}
+	b.Version = Version
+	return &b, nil
+}
+
+// Save writes b to the baseline file of repoRoot, creating .stet if needed.
+// Entries are sorted by file and fingerprint so the committed file diffs cleanly.
+func Save(repoRoot string, b *Baseline) error {
+	if b == nil {
+		return erruser.New("Cannot save nil baseline.", nil)
+	}
+	b.Version = Version
+	if b.Findings == nil {
+		b.Findings = []Entry{}
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		if b.Findings[i].File != b.Findings[j].File {
+			return b.Findings[i].File < b.Findings[j].File
+		}
+		return b.Findings[i].Fingerprint < b.Findings[j].Fingerprint
+	})
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return erruser.New("Could not save baseline.", err)
+	}
+	if err := os.MkdirAll(filepath.Join(repoRoot, Dirname), 0755); err != nil {
+		return erruser.New("Could not create baseline directory.", err)
+	}
+	if err := os.WriteFile(Path(repoRoot), append(data, '\n'), 0644); err != nil {
+		return erruser.New("Could not save baseline.", err)
+	}
+	return nil
+}
+
+// Contains reports whether fingerprint is in the baseline. Safe on a nil Baseline.
+func (b *Baseline) Contains(fingerprint string) bool {
+	if b == nil || fingerprint == "" {
+		return false
+	}
+	for _, e := range b.Findings {
+		if e.Fingerprint == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds an entry for f (which must have a Fingerprint) unless its
+// fingerprint is already present. Reports whether an entry was added.
+func (b *Baseline) Add(f findings.Finding) bool {
+	if f.Fingerprint == "" || b.Contains(f.Fingerprint) {
+		return false
+	}
+	b.Findings = append(b.Findings, Entry{Fingerprint: f.Fingerprint, File: f.File, Message: f.Message, Category: string(f.Category)})
+	return true
+}
+
+// Filter returns the findings whose Fingerprint is not in the baseline and the
+// number dropped. With an empty baseline, list is returned unchanged.
+func (b *Baseline) Filter(list []findings.Finding) ([]findings.Finding, int) {
+	if b == nil || len(b.Findings) == 0 {
+		return list, 0
+	}
+	kept := make([]findings.Finding, 0, len(list))
+	for _, f := range list {
+		if !b.Contains(f.Fingerprint) {
+			kept = append(kept, f)
+		}
+	}
+	return kept, len(list) - len(kept)
+}
+
+// Fingerprint returns the baseline fingerprint of a finding in file, reported
+// on the hunk with hunkContent: a hash of the file, the hunk's token-level
+// semantic ID (independent of line numbers, layout and comments, and of the
+// hunk_id_normalization setting so all teammates agree) and the message stem.
+func Fingerprint(file, hunkContent, message string) string {
+	hunkID := hunkid.SemanticHunkIDLevel(file, hunkContent, hunkid.LevelTokens)
+	h := sha256.Sum256([]byte(file + "\x00" + hunkID + "\x00" + MessageStem(message)))
+	return hex.EncodeToString(h[:])
+}
+
+// MessageStem normalizes a finding message for fingerprints: the first sentence,
+// lowercased, whitespace collapsed and trailing punctuation removed, capped at
+// 80 characters. Rewordings after the first sentence do not change the stem.
+func MessageStem(message string) string {
+	s := strings.ToLower(strings.Join(strings.Fields(message), " "))
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i]
+	}
+	if r := []rune(s); len(r) > maxStemLen {
+		s = string(r[:maxStemLen])
+	}
+	return strings.TrimRight(s, " .:;,!")
+}
@@ -0,0 +1,104 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/findings"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+	hunk := "@@ -10,2 +10,3 @@\n func f(p *T) {\n+\treturn p.x\n }"
+	base := Fingerprint("a.go", hunk, "Possible nil dereference of p. Check p before use.")
+	tests := []struct {
+		name    string
+		file    string
+		hunk    string
+		message string
+		same    bool
+	}{
+		{"shifted lines", "a.go", "@@ -40,2 +40,3 @@\n func f(p *T) {\n+\treturn p.x\n }", "Possible nil dereference of p. Check p before use.", true},
+		{"reworded explanation", "a.go", hunk, "possible nil  dereference of p.  Guard it first.", true},
+		{"comment added", "a.go", "@@ -10,2 +10,3 @@\n func f(p *T) { // f\n+\treturn p.x\n }", "Possible nil dereference of p.", true},
+		{"other file", "b.go", hunk, "Possible nil dereference of p.", false},
+		{"other code", "a.go", "@@ -10,2 +10,3 @@\n func f(p *T) {\n+\treturn p.y\n }", "Possible nil dereference of p.", false},
+		{"other message", "a.go", hunk, "Unused parameter p.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fingerprint(tt.file, tt.hunk, tt.message)
+			if (got == base) != tt.same {
+				t.Errorf("Fingerprint equal to base = %v, want %v", got == base, tt.same)
+			}
+		})
+	}
+}
+
+func TestMessageStem(t *testing.T) {
+	t.Parallel()
+	if got := MessageStem("  Missing error check.\nThe result of Close is ignored. "); got != "missing error check" {
+		t.Errorf("MessageStem = %q", got)
+	}
+	if got := MessageStem(strings.Repeat("é", 100)); len([]rune(got)) != maxStemLen {
+		t.Errorf("MessageStem length = %d runes, want %d", len([]rune(got)), maxStemLen)
+	}
+}
+
+func TestLoadSaveAddFilter(t *testing.T) {
+	t.Parallel()
+	repo := t.TempDir()
+	b, err := Load(repo)
+	if err != nil || len(b.Findings) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v; want empty baseline", b, err)
+	}
+	f1 := findings.Finding{ID: "1", File: "z.go", Message: "m1", Category: findings.CategoryCorrectness, Fingerprint: "fp1"}
+	f2 := findings.Finding{ID: "2", File: "a.go", Message: "m2", Fingerprint: "fp2"}
+	if !b.Add(f1) || !b.Add(f2) || b.Add(f1) {
+		t.Fatal("Add: want true, true, false (duplicate)")
+	}
+	if b.Add(findings.Finding{File: "x.go"}) {
+		t.Error("Add without fingerprint: want false")
+	}
+	if err := Save(repo, b); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, ".stet", "baseline.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "}\n") || strings.Index(string(data), `"a.go"`) > strings.Index(string(data), `"z.go"`) {
+		t.Errorf("saved baseline not sorted by file or missing newline:\n%s", data)
+	}
+	loaded, err := Load(repo)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	kept, dropped := loaded.Filter([]findings.Finding{f1, {ID: "3", Fingerprint: "other"}, {ID: "4"}})
+	if dropped != 1 || len(kept) != 2 || kept[0].ID != "3" || kept[1].ID != "4" {
+		t.Errorf("Filter = %+v, %d dropped; want findings 3 and 4, 1 dropped", kept, dropped)
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	t.Parallel()
+	for name, content := range map[string]string{
+		"bad json":      "{",
+		"newer version": `{"version": 99, "findings": []}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(repo, Dirname), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(Path(repo), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(repo); err == nil {
+				t.Error("Load: want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Package calibration maps a model's self-reported finding confidence to the
+// rate at which its findings were kept (not dismissed) in review history. One
+// isotonic (monotone non-decreasing) curve is fitted per model and category,
+// plus one per model over all categories, and stored in the state directory
+// as calibration.json. The review pipeline replaces Confidence with the
+// calibrated value before the abstention filter.
+package calibration
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/findings"
+	"stet/cli/internal/history"
+)
+
+// Version is the current calibration file format version.
+const Version = 1
+
+// Filename is the calibration file name in the state directory.
+const Filename = "calibration.json"
+
+// AllCategories is the Category of a model's curve fitted over every category;
+// it is used for categories without enough history of their own.
+const AllCategories = "*"
+
+// MinSamples is the number of decided findings a curve needs; with fewer, the
+// model's confidence is used as is.
+const MinSamples = 20
+
+// Point is one block of the fitted step function: findings whose raw confidence
+// averaged Confidence were kept at rate Calibrated.
+type Point struct {
+	Confidence float64 `json:"confidence"`
+	Calibrated float64 `json:"calibrated"`
+	Count      int     `json:"count"`
+}
+
+// Curve is the calibration of one model and category. Points are sorted by
+// Confidence and Calibrated is non-decreasing.
+type Curve struct {
+	Model    string  `json:"model"`
+	Category string  `json:"category"`
+	Samples  int     `json:"samples"`
+	KeptRate float64 `json:"kept_rate"`
+	Points   []Point `json:"points"`
+}
+
+// Calibration is the content of calibration.json.
+type Calibration struct {
+	Version  int     `json:"version"`
+	FittedAt string  `json:"fitted_at,omitempty"`
+	Curves   []Curve `json:"curves"`
+}
+
+// Sample is one decided finding from history.
+type Sample struct {
+	Model      string
+	Category   string
+	Confidence float64 // raw model confidence
+	Kept       bool    // not dismissed
+}
+
+// Path returns the calibration file path in stateDir.
+func Path(stateDir string) string {
+	return filepath.Join(stateDir, Filename)
+}
+
+// Load reads the calibration of stateDir. A missing file yields nil and no error.
+func Load(stateDir string) (*Calibration, error) {
+	data, err := os.ReadFile(Path(stateDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, erruser.New("Could not read confidence calibration.", err)
+	}
+	var c Calibration
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, erruser.New("Confidence calibration file "+Filename+" is invalid; run 'stet stats calibration --refit'.", err)
+	}
+	if c.Version > Version {
+		return nil, erruser.New("Confidence calibration file "+Filename+" was written by a newer stet; upgrade stet.", nil)
+	}
+	return &c, nil
+}
+
+// Save writes c to stateDir.
+func Save(stateDir string, c *Calibration) error {
+	if c == nil {
+		return erruser.New("Cannot save nil calibration.", nil)
+	}
+	c.Version = Version
+	if c.Curves == nil {
+		c.Curves = []Curve{}
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return erruser.New("Could not save confidence calibration.", err)
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return erruser.New("Could not create state directory.", err)
+	}
+	if err := os.WriteFile(Path(stateDir), append(data, '\n'), 0644); err != nil {
+		return erruser.New("Could not save confidence calibration.", err)
+	}
+	return nil
+}
+
+// Refit fits a calibration from the history in stateDir (including rotated
+// archives), saves it and returns it.
+func Refit(stateDir string, now time.Time) (*Calibration, error) {
+	records, err := history.ReadRecords(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	c := Fit(SamplesFromHistory(records), MinSamples)
+	c.FittedAt = now.UTC().Format(time.RFC3339)
+	if err := Save(stateDir, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SamplesFromHistory returns one sample per finding ID in records, from the
+// latest record that contains it. The model is the record's run config model
+// (or usage model). Only decided findings are sampled: a finding counts as kept
+// when its latest status is accepted or fixed (fixed findings auto-dismissed by
+// a re-review included), and as dismissed when its latest status is dismissed,
+// or when it has no status and a record lists it in DismissedIDs. Open and
+// deferred findings, and findings without a model, are skipped.
+func SamplesFromHistory(records []history.Record) []Sample {
+	dismissed := make(map[string]bool)
+	latest := make(map[string]findings.Finding)
+	models := make(map[string]string)
+	var order []string
+	for _, rec := range records {
+		for _, id := range rec.UserAction.DismissedIDs {
+			dismissed[id] = true
+		}
+		model := ""
+		if rec.RunConfig != nil {
+			model = rec.RunConfig.Model
+		}
+		if model == "" && rec.UsageData != nil {
+			model = rec.UsageData.Model
+		}
+		for _, f := range rec.ReviewOutput {
+			if f.ID == "" {
+				continue
+			}
+			if _, seen := latest[f.ID]; !seen {
+				order = append(order, f.ID)
+			}
+			latest[f.ID] = f
+			if model != "" {
+				models[f.ID] = model
+			}
+		}
+	}
+	var out []Sample
+	for _, id := range order {
+		f := latest[id]
+		if models[id] == "" {
+			continue
+		}
+		conf := f.Confidence
+		if f.RawConfidence > 0 {
+			conf = f.RawConfidence
+		}
+		var kept bool
+		switch f.Status {
+		case findings.StatusAccepted, findings.StatusFixed:
+			kept = true
+		case findings.StatusDismissed:
+			kept = false
+		case "", findings.StatusOpen:
+			if !dismissed[id] {
+				continue
+			}
+			kept = false
+		default:
+			continue
+		}
+		out = append(out, Sample{Model: models[id], Category: string(f.Category), Confidence: conf, Kept: kept})
+	}
+	return out
+}
+
+// Fit fits one curve per model and category with at least minSamples samples,
+// and one per model over all categories (Category AllCategories). Curves are
+// sorted by model, with the all-categories curve first.
+func Fit(samples []Sample, minSamples int) *Calibration {
+	groups := make(map[[2]string][]Sample)
+	for _, s := range samples {
+		groups[[2]string{s.Model, AllCategories}] = append(groups[[2]string{s.Model, AllCategories}], s)
+		groups[[2]string{s.Model, s.Category}] = append(groups[[2]string{s.Model, s.Category}], s)
+	}
+	c := &Calibration{Version: Version, Curves: []Curve{}}
+	for key, group := range groups {
+		if len(group) < minSamples {
+			continue
+		}
+		c.Curves = append(c.Curves, fitCurve(key[0], key[1], group))
+	}
+	sort.Slice(c.Curves, func(i, j int) bool {
+		a, b := c.Curves[i], c.Curves[j]
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return a.Category < b.Category // "*" sorts before letters
+	})
+	return c
+}
+
+// fitCurve runs pool-adjacent-violators over the samples sorted by confidence,
+// then smooths each block's rate with add-one (Laplace) counts so small blocks
+// do not map to exactly 0 or 1.
+func fitCurve(model, category string, samples []Sample) Curve {
+	sorted := append([]Sample(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Confidence < sorted[j].Confidence })
+	type block struct {
+		sumConf, kept float64
+		n             int
+		lastConf      float64
+	}
+	// One block per distinct confidence, then pool adjacent blocks whose rates decrease.
+	var blocks []block
+	keptTotal := 0
+	for _, s := range sorted {
+		k := 0.0
+		if s.Kept {
+			k = 1
+			keptTotal++
+		}
+		if len(blocks) > 0 && blocks[len(blocks)-1].lastConf == s.Confidence {
+			last := &blocks[len(blocks)-1]
+			last.sumConf += s.Confidence
+			last.kept += k
+			last.n++
+			continue
+		}
+		blocks = append(blocks, block{sumConf: s.Confidence, kept: k, n: 1, lastConf: s.Confidence})
+	}
+	var pooled []block
+	for _, b := range blocks {
+		pooled = append(pooled, b)
+		for len(pooled) > 1 {
+			a, b := pooled[len(pooled)-2], pooled[len(pooled)-1]
+			if a.kept/float64(a.n) <= b.kept/float64(b.n) {
+				break
+			}
+			pooled = append(pooled[:len(pooled)-2], block{sumConf: a.sumConf + b.sumConf, kept: a.kept + b.kept, n: a.n + b.n})
+		}
+	}
+	cur := Curve{Model: model, Category: category, Samples: len(samples), KeptRate: round(float64(keptTotal) / float64(len(samples)))}
+	prev := 0.0
+	for _, b := range pooled {
+		rate := math.Max((b.kept+1)/float64(b.n+2), prev)
+		prev = rate
+		cur.Points = append(cur.Points, Point{Confidence: round(b.sumConf / float64(b.n)), Calibrated: round(rate), Count: b.n})
+	}
+	return cur
+}
+
+// Map returns the calibrated confidence for raw: linear interpolation between
+// the curve's points, clamped to the first and last point.
+func (c Curve) Map(raw float64) float64 {
+	pts := c.Points
+	if len(pts) == 0 {
+		return raw
+	}
+	if raw <= pts[0].Confidence {
+		return pts[0].Calibrated
+	}
+	for i := 1; i < len(pts); i++ {
+		if raw <= pts[i].Confidence {
+			a, b := pts[i-1], pts[i]
+			t := (raw - a.Confidence) / (b.Confidence - a.Confidence)
+			return round(a.Calibrated + t*(b.Calibrated-a.Calibrated))
+		}
+	}
+	return pts[len(pts)-1].Calibrated
+}
+
+// Lookup returns the curve for model and category, else the model's
+// all-categories curve, else nil. Safe on a nil Calibration.
+func (c *Calibration) Lookup(model, category string) *Curve {
+	if c == nil {
+		return nil
+	}
+	var all *Curve
+	for i := range c.Curves {
+		cur := &c.Curves[i]
+		if cur.Model != model {
+			continue
+		}
+		if strings.EqualFold(cur.Category, category) {
+			return cur
+		}
+		if cur.Category == AllCategories {
+			all = cur
+		}
+	}
+	return all
+}
+
+// Apply replaces the Confidence of each finding in list (in place) with the
+// calibrated value for model and the finding's category, keeping the model's
+// value in RawConfidence. Findings without a curve are unchanged. Returns the
+// number of findings calibrated.
+func (c *Calibration) Apply(list []findings.Finding, model string) int {
+	n := 0
+	for i := range list {
+		cur := c.Lookup(model, string(list[i].Category))
+		if cur == nil {
+			continue
+		}
+		raw := list[i].Confidence
+		if list[i].RawConfidence > 0 {
+			raw = list[i].RawConfidence
+		}
+		list[i].RawConfidence = raw
+		list[i].Confidence = cur.Map(raw)
+		n++
+	}
+	return n
+}
+
+func round(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
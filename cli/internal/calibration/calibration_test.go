@@ -0,0 +1,158 @@
+package calibration
+
+import (
+	"testing"
+	"time"
+
+	"stet/cli/internal/findings"
+	"stet/cli/internal/history"
+)
+
+// samples returns n samples at conf of which kept are kept.
+func samples(model, category string, conf float64, n, kept int) []Sample {
+	out := make([]Sample, n)
+	for i := range out {
+		out[i] = Sample{Model: model, Category: category, Confidence: conf, Kept: i < kept}
+	}
+	return out
+}
+
+func TestFit_monotoneAndGrouped(t *testing.T) {
+	t.Parallel()
+	var in []Sample
+	in = append(in, samples("m", "style", 0.9, 10, 2)...)
+	in = append(in, samples("m", "style", 0.7, 10, 5)...) // higher kept rate at lower confidence: pooled
+	in = append(in, samples("m", "bug", 0.95, 10, 9)...)
+	in = append(in, samples("other", "bug", 0.9, 5, 5)...)
+	c := Fit(in, 20)
+	if len(c.Curves) != 2 {
+		t.Fatalf("curves = %+v, want m/* and m/style", c.Curves)
+	}
+	all, style := c.Curves[0], c.Curves[1]
+	if all.Category != AllCategories || all.Samples != 30 || style.Category != "style" || style.Samples != 20 {
+		t.Fatalf("curves = %+v", c.Curves)
+	}
+	if len(style.Points) != 1 || style.Points[0].Calibrated != round(8.0/22) {
+		t.Errorf("style points = %+v, want one pooled block at 8/22", style.Points)
+	}
+	for _, cur := range c.Curves {
+		for i := 1; i < len(cur.Points); i++ {
+			if cur.Points[i].Calibrated < cur.Points[i-1].Calibrated || cur.Points[i].Confidence <= cur.Points[i-1].Confidence {
+				t.Errorf("%s/%s not monotone: %+v", cur.Model, cur.Category, cur.Points)
+			}
+		}
+	}
+}
+
+func TestCurveMap(t *testing.T) {
+	t.Parallel()
+	cur := Curve{Points: []Point{{Confidence: 0.6, Calibrated: 0.2}, {Confidence: 0.8, Calibrated: 0.6}}}
+	tests := []struct{ raw, want float64 }{
+		{0.5, 0.2}, {0.6, 0.2}, {0.7, 0.4}, {0.8, 0.6}, {1.0, 0.6},
+	}
+	for _, tt := range tests {
+		if got := cur.Map(tt.raw); got != tt.want {
+			t.Errorf("Map(%v) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+	c := &Calibration{Curves: []Curve{
+		{Model: "m", Category: AllCategories, Points: []Point{{Confidence: 0.5, Calibrated: 0.5}}},
+		{Model: "m", Category: "security", Points: []Point{{Confidence: 0.9, Calibrated: 0.95}}},
+	}}
+	list := []findings.Finding{
+		{ID: "1", Category: findings.CategorySecurity, Confidence: 0.8},
+		{ID: "2", Category: findings.CategoryStyle, Confidence: 0.9},
+	}
+	if n := c.Apply(list, "m"); n != 2 {
+		t.Errorf("Apply = %d, want 2", n)
+	}
+	if list[0].Confidence != 0.95 || list[0].RawConfidence != 0.8 || list[1].Confidence != 0.5 || list[1].RawConfidence != 0.9 {
+		t.Errorf("calibrated = %+v", list)
+	}
+	// Re-applying calibrates from the raw value, not the calibrated one.
+	c.Apply(list, "m")
+	if list[0].RawConfidence != 0.8 {
+		t.Errorf("RawConfidence after second Apply = %v, want 0.8", list[0].RawConfidence)
+	}
+	var nilCal *Calibration
+	if n := nilCal.Apply(list, "m"); n != 0 {
+		t.Errorf("nil Apply = %d, want 0", n)
+	}
+	if n := c.Apply(list, "unknown"); n != 0 {
+		t.Errorf("Apply for unknown model = %d, want 0", n)
+	}
+}
+
+func TestSamplesFromHistory(t *testing.T) {
+	t.Parallel()
+	cfg := history.NewRunConfigSnapshot("m", "default", 0, 0, false)
+	records := []history.Record{
+		{
+			ReviewOutput: []findings.Finding{
+				{ID: "a", Category: findings.CategoryBug, Confidence: 0.9, Status: findings.StatusAccepted},
+				{ID: "b", Category: findings.CategoryStyle, Confidence: 0.7, RawConfidence: 0.95},
+				{ID: "c", Category: findings.CategoryBug, Confidence: 0.8, Status: findings.StatusFixed},
+				{ID: "d", Category: findings.CategoryBug, Confidence: 0.8},
+			},
+			UserAction: history.UserAction{DismissedIDs: []string{"b", "c"}},
+			RunConfig:  cfg,
+		},
+		{ReviewOutput: []findings.Finding{{ID: "d", Category: findings.CategoryBug, Confidence: 0.8, Status: findings.StatusDismissed}}},
+		{ReviewOutput: []findings.Finding{{ID: "e", Category: findings.CategoryBug, Confidence: 0.8}}},
+		{ReviewOutput: []findings.Finding{{ID: "f", Category: findings.CategoryBug, Confidence: 0.6}}, RunConfig: cfg},
+		{ReviewOutput: []findings.Finding{{ID: "g", Category: findings.CategoryBug, Confidence: 0.6, Status: findings.StatusDeferred}}, RunConfig: cfg},
+	}
+	got := SamplesFromHistory(records)
+	want := []Sample{
+		{Model: "m", Category: "bug", Confidence: 0.9, Kept: true},
+		{Model: "m", Category: "style", Confidence: 0.95, Kept: false},
+		{Model: "m", Category: "bug", Confidence: 0.8, Kept: true},
+		{Model: "m", Category: "bug", Confidence: 0.8, Kept: false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("samples = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRefitLoad(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if c, err := Load(dir); c != nil || err != nil {
+		t.Fatalf("Load(missing) = %v, %v; want nil, nil", c, err)
+	}
+	var out []findings.Finding
+	for i := 0; i < MinSamples; i++ {
+		f := findings.Finding{ID: string(rune('a' + i)), File: "a.go", Category: findings.CategoryBug, Confidence: 0.9, Message: "m"}
+		if i >= 2 {
+			f.Status = findings.StatusAccepted // a and b are dismissed below
+		}
+		out = append(out, f)
+	}
+	rec := history.Record{ReviewOutput: out, UserAction: history.UserAction{DismissedIDs: []string{"a", "b"}}, RunConfig: history.NewRunConfigSnapshot("m", "", 0, 0, false)}
+	if err := history.Append(dir, rec, history.DefaultMaxRecords); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := Refit(dir, now); err != nil {
+		t.Fatalf("Refit: %v", err)
+	}
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.FittedAt != "2026-03-01T12:00:00Z" || c.Lookup("m", "bug") == nil || c.Lookup("m", "style") == nil {
+		t.Errorf("loaded calibration = %+v", c)
+	}
+	if got := c.Lookup("m", "bug").Map(0.9); got != round(19.0/22) {
+		t.Errorf("Map(0.9) = %v, want %v", got, round(19.0/22))
+	}
+}
@@ -13,10 +13,21 @@
 //   - STET_OPTIMIZER_SCRIPT (command to run for stet optimize; e.g. python3 scripts/optimize.py).
 //   - STET_RAG_SYMBOL_MAX_DEFINITIONS, STET_RAG_SYMBOL_MAX_TOKENS (RAG-lite symbol lookup; Sub-phase 6.8).
 //   - STET_RAG_CALL_GRAPH_ENABLED, STET_RAG_CALLERS_MAX, STET_RAG_CALLEES_MAX, STET_RAG_CALL_GRAPH_MAX_TOKENS (RAG call-graph for Go).
+//   - STET_RAG_GO_TYPES_ENABLED (type-checked Go symbol resolution via go/packages: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_RAG_SYMBOL_INDEX_ENABLED (persistent symbol index for RAG lookups instead of git grep: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_RAG_RELATED_TESTS_ENABLED (include tests that exercise the changed function: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_COVERAGE_PROFILE (Go coverprofile or LCOV file; relative paths are resolved against the repo root).
+//   - STET_SUMMARY_ENABLED (run a whole-change summary pass before the per-hunk review: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_DANGLING_REFS_ENABLED (report references left behind by removed declarations: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_HUNK_MAX_LINES (split hunks longer than this many lines at declaration boundaries before review; 0 = never split).
+//   - STET_HUNK_MERGE_GAP (merge hunks of the same file separated by at most this many unchanged lines into one prompt; 0 = never merge).
+//   - STET_FILE_BATCHING_ENABLED (review all hunks of a small file in one prompt: 1/true/yes/on = true, 0/false/no/off = false).
+//   - STET_HUNK_ID_NORMALIZATION (how hunks are normalized to match already-reviewed ones: text, tokens, ast).
 //   - STET_STRICTNESS (review strictness preset: strict, default, lenient, strict+, default+, lenient+).
 //   - STET_NITPICKY (enable nitpicky mode: 1/true/yes/on = true, 0/false/no/off = false).
 //   - STET_SUPPRESSION_ENABLED (history-based suppression: 1/true/yes/on = true, 0/false/no/off = false).
 //   - STET_SUPPRESSION_HISTORY_COUNT (max history records to scan for dismissals; non-negative integer).
+//   - STET_CALIBRATION_ENABLED (calibrate finding confidence from history: 1/true/yes/on = true, 0/false/no/off = false).
 //   - STET_CRITIC_ENABLED (optional second-pass critic: 1/true/yes/on = true, 0/false/no/off = false).
 //   - STET_CRITIC_MODEL (model name for the critic; default qwen3-coder:30b, same as main model).
 package config
@@ -35,6 +46,8 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"stet/cli/internal/erruser"
+	"stet/cli/internal/findings"
+	"stet/cli/internal/hunkid"
 )
 
 // Config holds all Stet configuration. Empty string or zero values for
@@ -67,6 +80,40 @@ type Config struct {
 	RAGCalleesMax int `toml:"rag_callees_max"`
 	// RAGCallGraphMaxTokens caps the call-graph block size (0 = use fraction of RAG budget). Default 0.
 	RAGCallGraphMaxTokens int `toml:"rag_call_graph_max_tokens"`
+	// RAGGoTypesEnabled resolves Go symbols from go/packages type information instead of grep
+	// (methods, interface implementations, struct fields); falls back to grep when loading fails. Default false.
+	RAGGoTypesEnabled bool `toml:"rag_go_types_enabled"`
+	// RAGSymbolIndexEnabled serves RAG definition lookups from a symbol index stored per tree SHA
+	// under the state dir (updated incrementally) instead of one git grep per symbol. Default true.
+	RAGSymbolIndexEnabled bool `toml:"rag_symbol_index_enabled"`
+	// RAGRelatedTestsEnabled includes tests related to each hunk (Go _test.go callers of the enclosing
+	// function, test_*.py, *.spec.ts / *.test.ts) in the prompt. Default false.
+	RAGRelatedTestsEnabled bool `toml:"rag_related_tests_enabled"`
+	// CoverageProfile is a Go coverprofile or LCOV file; when set, each hunk's uncovered added lines are
+	// listed in the prompt. Relative paths are resolved against the repo root. Default "" (disabled).
+	CoverageProfile string `toml:"coverage_profile"`
+	// SummaryEnabled runs one extra model call over a compressed view of the whole diff (commit messages,
+	// file list, hunk headers) and injects the resulting change summary into every hunk's system prompt. Default false.
+	SummaryEnabled bool `toml:"summary_enabled"`
+	// DanglingRefsEnabled searches HEAD for references to top-level declarations a hunk removes (git grep,
+	// using the RAG resolvers' definition patterns) and reports each remaining reference. Default true.
+	DanglingRefsEnabled bool `toml:"dangling_refs_enabled"`
+	// HunkMaxLines splits hunks with more lines than this into pieces before review, cutting at declaration
+	// boundaries where possible (fixed windows with a few lines of context overlap otherwise). It also caps
+	// hunks merged by HunkMergeGap. 0 disables splitting. Default 300.
+	HunkMaxLines int `toml:"hunk_max_lines"`
+	// HunkMergeGap coalesces hunks of the same file separated by at most this many unchanged lines into one
+	// prompt (the gap is added as context). 0 disables merging. Default 0 (opt-in, since merging changes the
+	// hunk boundaries that findings and prompt contexts of existing sessions were recorded against).
+	HunkMergeGap int `toml:"hunk_merge_gap"`
+	// FileBatchingEnabled sends all hunks of a file in one prompt when they fit the batch token budget
+	// (a quarter of ContextLimit, capped); findings are attributed back to the hunk containing their line. Default false.
+	FileBatchingEnabled bool `toml:"file_batching_enabled"`
+	// HunkIDNormalization is how far hunks are normalized when matching them against already-reviewed hunks
+	// in incremental runs: text (comments and whitespace), tokens (also formatting and line shifts) or ast (also Go
+	// import order and local variable names). Default text, the matching of earlier releases, so upgrading
+	// does not change which hunks existing sessions treat as reviewed.
+	HunkIDNormalization string `toml:"hunk_id_normalization"`
 	// Strictness is the review preset: strict, default, lenient, strict+, default+, lenient+ (case-insensitive).
 	Strictness string `toml:"strictness"`
 	// Nitpicky enables convention- and typo-aware review; when true, FP kill list is not applied.
@@ -75,10 +122,18 @@ type Config struct {
 	SuppressionEnabled bool `toml:"suppression_enabled"`
 	// SuppressionHistoryCount is the max number of history records to scan for dismissals (0 = do not use history). Default 50.
 	SuppressionHistoryCount int `toml:"suppression_history_count"`
+	// CalibrationEnabled replaces each finding's confidence with the value calibrated from history
+	// (calibration.json in the state dir, refitted on finish). The abstention filter still checks the
+	// raw model confidence, which its thresholds are set for. Default false.
+	CalibrationEnabled bool `toml:"calibration_enabled"`
 	// CriticEnabled runs a second LLM pass (critic) on each finding; when true, findings the critic rejects are dropped. Default false.
 	CriticEnabled bool `toml:"critic_enabled"`
 	// CriticModel is the model name for the critic. Default matches main model (qwen3-coder:30b) so one model stays loaded on memory-constrained machines; set to a different model to use a separate critic model (loads a second model). Used only when CriticEnabled.
 	CriticModel string `toml:"critic_model"`
+	// Filters are team-specific finding filters ([filters] table; config files only): extra banned phrases and
+	// message regexes, drop rules by category/path/message and severity remapping. Global and repo lists are
+	// concatenated. Applied after the FP kill list and removed-symbol findings, also in nitpicky mode.
+	Filters findings.FilterRules `toml:"filters"`
 }
 
 // Overrides represents optional CLI flag overrides. Non-nil pointer means
@@ -102,6 +157,16 @@ type Overrides struct {
 	RAGCallersMax           *int
 	RAGCalleesMax           *int
 	RAGCallGraphMaxTokens   *int
+	RAGGoTypesEnabled       *bool
+	RAGSymbolIndexEnabled   *bool
+	RAGRelatedTestsEnabled  *bool
+	CoverageProfile         *string
+	SummaryEnabled          *bool
+	DanglingRefsEnabled     *bool
+	HunkMaxLines            *int
+	HunkMergeGap            *int
+	FileBatchingEnabled     *bool
+	HunkIDNormalization     *string
 	Strictness              *string
 	Nitpicky                *bool
 	SuppressionEnabled       *bool
@@ -139,6 +204,15 @@ const (
 	_defaultRAGCallersMax         = 3
 	_defaultRAGCalleesMax         = 3
 	_defaultRAGCallGraphMaxTokens = 0
+	_defaultRAGGoTypesEnabled     = false
+	_defaultRAGSymbolIndexEnabled = true
+	_defaultRAGRelatedTestsEnabled = false
+	_defaultSummaryEnabled         = false
+	_defaultDanglingRefsEnabled    = true
+	_defaultHunkMaxLines           = 300
+	_defaultHunkMergeGap           = 0
+	_defaultFileBatchingEnabled    = false
+	_defaultHunkIDNormalization    = "text"
 	_defaultStrictness             = "default"
 	_defaultSuppressionHistoryCount = 50
 	_defaultCriticModel            = "qwen3-coder:30b"
@@ -191,10 +265,20 @@ func DefaultConfig() Config {
 		RAGCallersMax:           _defaultRAGCallersMax,
 		RAGCalleesMax:           _defaultRAGCalleesMax,
 		RAGCallGraphMaxTokens:   _defaultRAGCallGraphMaxTokens,
+		RAGGoTypesEnabled:       _defaultRAGGoTypesEnabled,
+		RAGSymbolIndexEnabled:   _defaultRAGSymbolIndexEnabled,
+		RAGRelatedTestsEnabled:  _defaultRAGRelatedTestsEnabled,
+		SummaryEnabled:          _defaultSummaryEnabled,
+		DanglingRefsEnabled:     _defaultDanglingRefsEnabled,
+		HunkMaxLines:            _defaultHunkMaxLines,
+		HunkMergeGap:            _defaultHunkMergeGap,
+		FileBatchingEnabled:     _defaultFileBatchingEnabled,
+		HunkIDNormalization:     _defaultHunkIDNormalization,
 		Strictness:                _defaultStrictness,
 		Nitpicky:                  false,
 		SuppressionEnabled:        true,
 		SuppressionHistoryCount:   _defaultSuppressionHistoryCount,
+		CalibrationEnabled:        false,
 		CriticEnabled:             false,
 		CriticModel:               _defaultCriticModel,
 	}
@@ -301,12 +385,24 @@ func mergeFile(cfg *Config, path string) error {
 		RAGCallersMax           *int64  `toml:"rag_callers_max"`
 		RAGCalleesMax           *int64  `toml:"rag_callees_max"`
 		RAGCallGraphMaxTokens   *int64  `toml:"rag_call_graph_max_tokens"`
+		RAGGoTypesEnabled       *bool   `toml:"rag_go_types_enabled"`
+		RAGSymbolIndexEnabled   *bool   `toml:"rag_symbol_index_enabled"`
+		RAGRelatedTestsEnabled  *bool   `toml:"rag_related_tests_enabled"`
+		CoverageProfile         *string `toml:"coverage_profile"`
+		SummaryEnabled          *bool   `toml:"summary_enabled"`
+		DanglingRefsEnabled     *bool   `toml:"dangling_refs_enabled"`
+		HunkMaxLines            *int64  `toml:"hunk_max_lines"`
+		HunkMergeGap            *int64  `toml:"hunk_merge_gap"`
+		FileBatchingEnabled     *bool   `toml:"file_batching_enabled"`
+		HunkIDNormalization     *string `toml:"hunk_id_normalization"`
 		Strictness               *string `toml:"strictness"`
 		Nitpicky                 *bool   `toml:"nitpicky"`
 		SuppressionEnabled       *bool   `toml:"suppression_enabled"`
 		SuppressionHistoryCount  *int64  `toml:"suppression_history_count"`
+		CalibrationEnabled       *bool   `toml:"calibration_enabled"`
 		CriticEnabled            *bool   `toml:"critic_enabled"`
 		CriticModel              *string `toml:"critic_model"`
+		Filters                  *findings.FilterRules `toml:"filters"`
 	}
 	if _, err := toml.Decode(string(data), &file); err != nil {
 		return erruser.New("Invalid configuration in .review/config.toml.", err)
@@ -409,6 +505,48 @@ func mergeFile(cfg *Config, path string) error {
 		}
 		cfg.RAGCallGraphMaxTokens = v
 	}
+	if file.RAGGoTypesEnabled != nil {
+		cfg.RAGGoTypesEnabled = *file.RAGGoTypesEnabled
+	}
+	if file.RAGSymbolIndexEnabled != nil {
+		cfg.RAGSymbolIndexEnabled = *file.RAGSymbolIndexEnabled
+	}
+	if file.RAGRelatedTestsEnabled != nil {
+		cfg.RAGRelatedTestsEnabled = *file.RAGRelatedTestsEnabled
+	}
+	if file.CoverageProfile != nil {
+		cfg.CoverageProfile = *file.CoverageProfile
+	}
+	if file.SummaryEnabled != nil {
+		cfg.SummaryEnabled = *file.SummaryEnabled
+	}
+	if file.DanglingRefsEnabled != nil {
+		cfg.DanglingRefsEnabled = *file.DanglingRefsEnabled
+	}
+	if file.HunkMaxLines != nil && *file.HunkMaxLines >= 0 {
+		v, err := int64ToInt(*file.HunkMaxLines)
+		if err != nil {
+			return erruser.New("Configuration hunk_max_lines value out of range.", err)
+		}
+		cfg.HunkMaxLines = v
+	}
+	if file.HunkMergeGap != nil && *file.HunkMergeGap >= 0 {
+		v, err := int64ToInt(*file.HunkMergeGap)
+		if err != nil {
+			return erruser.New("Configuration hunk_merge_gap value out of range.", err)
+		}
+		cfg.HunkMergeGap = v
+	}
+	if file.FileBatchingEnabled != nil {
+		cfg.FileBatchingEnabled = *file.FileBatchingEnabled
+	}
+	if file.HunkIDNormalization != nil && *file.HunkIDNormalization != "" {
+		level, err := hunkid.ParseLevel(*file.HunkIDNormalization)
+		if err != nil {
+			return err
+		}
+		cfg.HunkIDNormalization = string(level)
+	}
 	if file.Strictness != nil && *file.Strictness != "" {
 		norm, err := validateStrictness(*file.Strictness)
 		if err != nil {
@@ -429,12 +567,24 @@ func mergeFile(cfg *Config, path string) error {
 		}
 		cfg.SuppressionHistoryCount = v
 	}
+	if file.CalibrationEnabled != nil {
+		cfg.CalibrationEnabled = *file.CalibrationEnabled
+	}
 	if file.CriticEnabled != nil {
 		cfg.CriticEnabled = *file.CriticEnabled
 	}
 	if file.CriticModel != nil && *file.CriticModel != "" {
 		cfg.CriticModel = *file.CriticModel
 	}
+	if file.Filters != nil {
+		if _, err := findings.NewFilter(*file.Filters); err != nil {
+			return err
+		}
+		cfg.Filters.BannedPhrases = append(cfg.Filters.BannedPhrases, file.Filters.BannedPhrases...)
+		cfg.Filters.BannedPatterns = append(cfg.Filters.BannedPatterns, file.Filters.BannedPatterns...)
+		cfg.Filters.Drop = append(cfg.Filters.Drop, file.Filters.Drop...)
+		cfg.Filters.Remap = append(cfg.Filters.Remap, file.Filters.Remap...)
+	}
 	return nil
 }
 
@@ -482,10 +632,21 @@ const (
 	envRAGCallersMax            = "STET_RAG_CALLERS_MAX"
 	envRAGCalleesMax            = "STET_RAG_CALLEES_MAX"
 	envRAGCallGraphMaxTokens    = "STET_RAG_CALL_GRAPH_MAX_TOKENS"
+	envRAGGoTypesEnabled        = "STET_RAG_GO_TYPES_ENABLED"
+	envRAGSymbolIndexEnabled    = "STET_RAG_SYMBOL_INDEX_ENABLED"
+	envRAGRelatedTestsEnabled   = "STET_RAG_RELATED_TESTS_ENABLED"
+	envCoverageProfile          = "STET_COVERAGE_PROFILE"
+	envSummaryEnabled           = "STET_SUMMARY_ENABLED"
+	envDanglingRefsEnabled      = "STET_DANGLING_REFS_ENABLED"
+	envHunkMaxLines             = "STET_HUNK_MAX_LINES"
+	envHunkMergeGap             = "STET_HUNK_MERGE_GAP"
+	envFileBatchingEnabled      = "STET_FILE_BATCHING_ENABLED"
+	envHunkIDNormalization      = "STET_HUNK_ID_NORMALIZATION"
 	envStrictness               = "STET_STRICTNESS"
 	envNitpicky                 = "STET_NITPICKY"
 	envSuppressionEnabled       = "STET_SUPPRESSION_ENABLED"
 	envSuppressionHistoryCount  = "STET_SUPPRESSION_HISTORY_COUNT"
+	envCalibrationEnabled       = "STET_CALIBRATION_ENABLED"
 	envCriticEnabled            = "STET_CRITIC_ENABLED"
 	envCriticModel              = "STET_CRITIC_MODEL"
 	envProvider                 = "STET_PROVIDER"
@@ -662,6 +823,82 @@ func applyEnv(cfg *Config, env []string) error {
 			}
 		}
 	}
+	if v, ok := vals[envRAGGoTypesEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_RAG_GO_TYPES_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.RAGGoTypesEnabled = b
+	}
+	if v, ok := vals[envRAGSymbolIndexEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_RAG_SYMBOL_INDEX_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.RAGSymbolIndexEnabled = b
+	}
+	if v, ok := vals[envRAGRelatedTestsEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_RAG_RELATED_TESTS_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.RAGRelatedTestsEnabled = b
+	}
+	if v, ok := vals[envCoverageProfile]; ok && v != "" {
+		cfg.CoverageProfile = v
+	}
+	if v, ok := vals[envSummaryEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_SUMMARY_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.SummaryEnabled = b
+	}
+	if v, ok := vals[envDanglingRefsEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_DANGLING_REFS_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.DanglingRefsEnabled = b
+	}
+	if v, ok := vals[envHunkMaxLines]; ok && v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return erruser.New("STET_HUNK_MAX_LINES must be a valid number.", err)
+		}
+		if n >= 0 {
+			cfg.HunkMaxLines, err = int64ToInt(n)
+			if err != nil {
+				return erruser.New("STET_HUNK_MAX_LINES value out of range.", err)
+			}
+		}
+	}
+	if v, ok := vals[envHunkMergeGap]; ok && v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return erruser.New("STET_HUNK_MERGE_GAP must be a valid number.", err)
+		}
+		if n >= 0 {
+			cfg.HunkMergeGap, err = int64ToInt(n)
+			if err != nil {
+				return erruser.New("STET_HUNK_MERGE_GAP value out of range.", err)
+			}
+		}
+	}
+	if v, ok := vals[envFileBatchingEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_FILE_BATCHING_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.FileBatchingEnabled = b
+	}
+	if v, ok := vals[envHunkIDNormalization]; ok && v != "" {
+		level, err := hunkid.ParseLevel(v)
+		if err != nil {
+			return err
+		}
+		cfg.HunkIDNormalization = string(level)
+	}
 	if v, ok := vals[envStrictness]; ok && v != "" {
 		norm, err := validateStrictness(v)
 		if err != nil {
@@ -706,6 +943,13 @@ func applyEnv(cfg *Config, env []string) error {
 	if v, ok := vals[envCriticModel]; ok && v != "" {
 		cfg.CriticModel = v
 	}
+	if v, ok := vals[envCalibrationEnabled]; ok && v != "" {
+		b, err := parseBool(v)
+		if err != nil {
+			return erruser.New("STET_CALIBRATION_ENABLED must be 1/true/yes/on or 0/false/no/off.", err)
+		}
+		cfg.CalibrationEnabled = b
+	}
 	return nil
 }
 
@@ -794,6 +1038,38 @@ func applyOverrides(cfg *Config, o *Overrides) {
 		}
 		cfg.RAGCallGraphMaxTokens = v
 	}
+	if o.RAGGoTypesEnabled != nil {
+		cfg.RAGGoTypesEnabled = *o.RAGGoTypesEnabled
+	}
+	if o.RAGSymbolIndexEnabled != nil {
+		cfg.RAGSymbolIndexEnabled = *o.RAGSymbolIndexEnabled
+	}
+	if o.RAGRelatedTestsEnabled != nil {
+		cfg.RAGRelatedTestsEnabled = *o.RAGRelatedTestsEnabled
+	}
+	if o.CoverageProfile != nil {
+		cfg.CoverageProfile = *o.CoverageProfile
+	}
+	if o.SummaryEnabled != nil {
+		cfg.SummaryEnabled = *o.SummaryEnabled
+	}
+	if o.DanglingRefsEnabled != nil {
+		cfg.DanglingRefsEnabled = *o.DanglingRefsEnabled
+	}
+	if o.HunkMaxLines != nil {
+		cfg.HunkMaxLines = *o.HunkMaxLines
+	}
+	if o.HunkMergeGap != nil {
+		cfg.HunkMergeGap = *o.HunkMergeGap
+	}
+	if o.FileBatchingEnabled != nil {
+		cfg.FileBatchingEnabled = *o.FileBatchingEnabled
+	}
+	if o.HunkIDNormalization != nil && *o.HunkIDNormalization != "" {
+		if level, err := hunkid.ParseLevel(*o.HunkIDNormalization); err == nil {
+			cfg.HunkIDNormalization = string(level)
+		}
+	}
 	if o.Strictness != nil && *o.Strictness != "" {
 		if norm, err := validateStrictness(*o.Strictness); err == nil {
 			cfg.Strictness = norm
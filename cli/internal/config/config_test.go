@@ -62,6 +62,9 @@ func TestDefaultConfig_fieldsMatchExpectedDefaults(t *testing.T) {
 	if c.SuppressionHistoryCount != _defaultSuppressionHistoryCount {
 		t.Errorf("SuppressionHistoryCount = %d, want %d", c.SuppressionHistoryCount, _defaultSuppressionHistoryCount)
 	}
+	if !c.RAGSymbolIndexEnabled {
+		t.Errorf("RAGSymbolIndexEnabled = false, want true (default)")
+	}
 }
 
 func TestLoad_defaultsOnly(t *testing.T) {
@@ -269,6 +272,7 @@ func TestLoad_allOverridesApplied(t *testing.T) {
 			RAGCallersMax:           ptrInt(5),
 			RAGCalleesMax:           ptrInt(5),
 			RAGCallGraphMaxTokens:   ptrInt(2000),
+			RAGGoTypesEnabled:       ptrBool(true),
 			Strictness:              ptrStr("strict"),
 			Nitpicky:                ptrBool(true),
 			SuppressionEnabled:      ptrBool(false),
@@ -286,7 +290,7 @@ func TestLoad_allOverridesApplied(t *testing.T) {
 		cfg.Temperature != 0.3 || cfg.NumCtx != 16384 || cfg.OptimizerScript != "python3 over.py" ||
 		cfg.RAGSymbolMaxDefinitions != 15 || cfg.RAGSymbolMaxTokens != 1000 ||
 		!cfg.RAGCallGraphEnabled || cfg.RAGCallersMax != 5 || cfg.RAGCalleesMax != 5 ||
-		cfg.RAGCallGraphMaxTokens != 2000 || cfg.Strictness != "strict" || !cfg.Nitpicky ||
+		cfg.RAGCallGraphMaxTokens != 2000 || !cfg.RAGGoTypesEnabled || cfg.Strictness != "strict" || !cfg.Nitpicky ||
 		cfg.SuppressionEnabled || cfg.SuppressionHistoryCount != 25 ||
 		!cfg.CriticEnabled || cfg.CriticModel != "critic-model" {
 		t.Errorf("got %+v", cfg)
@@ -989,6 +993,287 @@ func TestLoad_suppressionEnabledInvalidFromEnv(t *testing.T) {
 	}
 }
 
+func TestLoad_ragGoTypesEnabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	reviewDir := filepath.Join(dir, ".review")
+	if err := os.MkdirAll(reviewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(reviewDir, "config.toml"), []byte("rag_go_types_enabled = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.RAGGoTypesEnabled {
+		t.Error("RAGGoTypesEnabled = false from TOML, want true")
+	}
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_GO_TYPES_ENABLED=off"},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RAGGoTypesEnabled {
+		t.Error("RAGGoTypesEnabled = true with STET_RAG_GO_TYPES_ENABLED=off, want false")
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_GO_TYPES_ENABLED=maybe"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "STET_RAG_GO_TYPES_ENABLED") {
+		t.Errorf("Load: want STET_RAG_GO_TYPES_ENABLED error, got %v", err)
+	}
+}
+
+func TestLoad_ragSymbolIndexEnabledFromEnv(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_SYMBOL_INDEX_ENABLED=0"},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RAGSymbolIndexEnabled {
+		t.Error("RAGSymbolIndexEnabled = true with STET_RAG_SYMBOL_INDEX_ENABLED=0, want false")
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_SYMBOL_INDEX_ENABLED=sometimes"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "STET_RAG_SYMBOL_INDEX_ENABLED") {
+		t.Errorf("Load: want STET_RAG_SYMBOL_INDEX_ENABLED error, got %v", err)
+	}
+}
+
+func TestLoad_relatedTestsAndCoverageProfile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	reviewDir := filepath.Join(dir, ".review")
+	if err := os.MkdirAll(reviewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	toml := "rag_related_tests_enabled = true\ncoverage_profile = \"cover.out\"\n"
+	if err := os.WriteFile(filepath.Join(reviewDir, "config.toml"), []byte(toml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.RAGRelatedTestsEnabled || cfg.CoverageProfile != "cover.out" {
+		t.Errorf("from TOML: RAGRelatedTestsEnabled=%v CoverageProfile=%q, want true, cover.out", cfg.RAGRelatedTestsEnabled, cfg.CoverageProfile)
+	}
+	profile := "lcov.info"
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_RELATED_TESTS_ENABLED=no", "STET_COVERAGE_PROFILE=env.out"},
+		Overrides:        &Overrides{CoverageProfile: &profile},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RAGRelatedTestsEnabled {
+		t.Error("RAGRelatedTestsEnabled = true with STET_RAG_RELATED_TESTS_ENABLED=no, want false")
+	}
+	if cfg.CoverageProfile != "lcov.info" {
+		t.Errorf("CoverageProfile = %q, want override lcov.info", cfg.CoverageProfile)
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_RAG_RELATED_TESTS_ENABLED=maybe"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "STET_RAG_RELATED_TESTS_ENABLED") {
+		t.Errorf("Load: want STET_RAG_RELATED_TESTS_ENABLED error, got %v", err)
+	}
+}
+
+func TestLoad_summaryEnabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	reviewDir := filepath.Join(dir, ".review")
+	if err := os.MkdirAll(reviewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.SummaryEnabled {
+		t.Error("SummaryEnabled default = true, want false")
+	}
+	if err := os.WriteFile(filepath.Join(reviewDir, "config.toml"), []byte("summary_enabled = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.SummaryEnabled {
+		t.Error("SummaryEnabled from TOML = false, want true")
+	}
+	off := false
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_SUMMARY_ENABLED=1"},
+		Overrides:        &Overrides{SummaryEnabled: &off},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.SummaryEnabled {
+		t.Error("SummaryEnabled = true with override false, want false")
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_SUMMARY_ENABLED=maybe"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "STET_SUMMARY_ENABLED") {
+		t.Errorf("Load: want STET_SUMMARY_ENABLED error, got %v", err)
+	}
+}
+
+func TestLoad_danglingRefsEnabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.DanglingRefsEnabled {
+		t.Error("DanglingRefsEnabled default = false, want true")
+	}
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_DANGLING_REFS_ENABLED=off"},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.DanglingRefsEnabled {
+		t.Error("DanglingRefsEnabled = true with STET_DANGLING_REFS_ENABLED=off, want false")
+	}
+}
+
+func TestLoad_hunkPlanner(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HunkMaxLines != 300 || cfg.HunkMergeGap != 0 {
+		t.Errorf("defaults HunkMaxLines=%d HunkMergeGap=%d, want 300 and 0", cfg.HunkMaxLines, cfg.HunkMergeGap)
+	}
+	repoDir := filepath.Join(dir, ".review")
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "config.toml"), []byte("hunk_max_lines = 120\nhunk_merge_gap = 8"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_HUNK_MERGE_GAP=0"},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HunkMaxLines != 120 {
+		t.Errorf("HunkMaxLines = %d, want 120 from repo config", cfg.HunkMaxLines)
+	}
+	if cfg.HunkMergeGap != 0 {
+		t.Errorf("HunkMergeGap = %d, want 0 from STET_HUNK_MERGE_GAP", cfg.HunkMergeGap)
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_HUNK_MAX_LINES=lots"},
+	})
+	if err == nil {
+		t.Error("Load with STET_HUNK_MAX_LINES=lots: want error")
+	}
+}
+
+func TestLoad_fileBatchingEnabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.FileBatchingEnabled {
+		t.Error("FileBatchingEnabled default = true, want false")
+	}
+	on := true
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_FILE_BATCHING_ENABLED=0"},
+		Overrides:        &Overrides{FileBatchingEnabled: &on},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.FileBatchingEnabled {
+		t.Error("FileBatchingEnabled = false with override true, want true")
+	}
+}
+
+func TestLoad_hunkIDNormalization(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HunkIDNormalization != "text" {
+		t.Errorf("HunkIDNormalization default = %q, want text", cfg.HunkIDNormalization)
+	}
+	cfg, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_HUNK_ID_NORMALIZATION=Tokens"},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HunkIDNormalization != "tokens" {
+		t.Errorf("HunkIDNormalization = %q, want tokens", cfg.HunkIDNormalization)
+	}
+	_, err = Load(ctx, LoadOptions{
+		RepoRoot:         dir,
+		GlobalConfigPath: filepath.Join(dir, "nope.toml"),
+		Env:              []string{"STET_HUNK_ID_NORMALIZATION=bytes"},
+	})
+	if err == nil {
+		t.Fatal("Load with invalid STET_HUNK_ID_NORMALIZATION: expected error")
+	}
+}
+
 func TestLoad_suppressionHistoryCountFromEnv(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -1116,3 +1401,84 @@ func TestLoad_optimizerScriptFromTOML(t *testing.T) {
 		t.Errorf("OptimizerScript = %q, want python3 scripts/optimize.py", cfg.OptimizerScript)
 	}
 }
+
+func TestLoad_filtersFromTOML(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "global.toml")
+	repoRoot := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(repoRoot, ".review"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(globalPath, []byte("[filters]\nbanned_phrases = [\"Consider renaming\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo := `[filters]
+banned_patterns = ['^TODO\b']
+
+[[filters.drop]]
+category = "style"
+path = "legacy/**"
+
+[[filters.remap]]
+category = "performance"
+path = "tests/**"
+severity = "info"
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, ".review", "config.toml"), []byte(repo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(context.Background(), LoadOptions{RepoRoot: repoRoot, GlobalConfigPath: globalPath, Env: []string{}})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	f := cfg.Filters
+	if len(f.BannedPhrases) != 1 || len(f.BannedPatterns) != 1 || len(f.Drop) != 1 || len(f.Remap) != 1 {
+		t.Fatalf("Filters = %+v, want global phrase plus repo pattern, drop and remap", f)
+	}
+	if f.Drop[0].Path != "legacy/**" || f.Remap[0].Category != "performance" || f.Remap[0].Severity != "info" {
+		t.Errorf("Filters = %+v", f)
+	}
+	if err := os.WriteFile(globalPath, []byte("[[filters.remap]]\nseverity = \"critical\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(context.Background(), LoadOptions{GlobalConfigPath: globalPath, Env: []string{}}); err == nil {
+		t.Error("Load with invalid remap severity: want error")
+	}
+}
+
+func TestLoad_calibrationEnabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cfg, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml"), Env: []string{}})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.CalibrationEnabled {
+		t.Error("CalibrationEnabled = true, want false (default)")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".review"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".review", "config.toml"), []byte("calibration_enabled = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml"), Env: []string{}})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.CalibrationEnabled {
+		t.Error("CalibrationEnabled = false, want true from TOML")
+	}
+	cfg, err = Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml"), Env: []string{"STET_CALIBRATION_ENABLED=off"}})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.CalibrationEnabled {
+		t.Error("CalibrationEnabled = true, want false from env")
+	}
+	if _, err := Load(ctx, LoadOptions{RepoRoot: dir, GlobalConfigPath: filepath.Join(dir, "nope.toml"), Env: []string{"STET_CALIBRATION_ENABLED=maybe"}}); err == nil {
+		t.Error("Load: want error for invalid STET_CALIBRATION_ENABLED")
+	}
+}
@@ -0,0 +1,268 @@
+// Package coverage reads line coverage from a Go coverprofile (go test
+// -coverprofile) or an LCOV tracefile and answers which added lines of a hunk
+// are not executed by any test. Used to tell the model about untested changes.
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"stet/cli/internal/erruser"
+)
+
+// Format names the profile syntax.
+const (
+	FormatGo   = "go"
+	FormatLCOV = "lcov"
+)
+
+const goModePrefix = "mode:"
+
+// goBlockRegex matches a coverprofile block: file:startLine.startCol,endLine.endCol numStmts count
+var goBlockRegex = regexp.MustCompile(`^(.+):(\d+)\.\d+,(\d+)\.\d+ \d+ (\d+)$`)
+
+// Profile holds per-file line hit counts. Lines without an entry are not
+// instrumented (comments, declarations) and are never reported as uncovered.
+type Profile struct {
+	Format string
+	// Path is the file the profile was read from (for prompts and trace).
+	Path  string
+	files map[string]map[int]int64 // profile file name -> line -> hits
+	// byRepoPath caches the profile key chosen for a repo-relative path ("" = none).
+	// Guarded by mu: hunks are prepared concurrently.
+	mu         sync.Mutex
+	byRepoPath map[string]string
+}
+
+// Load reads the profile at path, detecting the format from its first line
+// ("mode:" for Go coverprofiles, LCOV otherwise).
+func Load(path string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("Could not read coverage profile %s.", path), err)
+	}
+	defer f.Close()
+	p, err := Parse(f)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("Could not parse coverage profile %s.", path), err)
+	}
+	p.Path = path
+	return p, nil
+}
+
+// Parse reads a Go coverprofile or an LCOV tracefile from r.
+func Parse(r io.Reader) (*Profile, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	p := &Profile{files: make(map[string]map[int]int64), byRepoPath: make(map[string]string)}
+	first := true
+	var lcovFile string
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if first {
+			first = false
+			if strings.HasPrefix(line, goModePrefix) {
+				p.Format = FormatGo
+				continue
+			}
+			p.Format = FormatLCOV
+		}
+		if p.Format == FormatGo {
+			if err := p.addGoBlock(line); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			lcovFile = strings.TrimPrefix(line, "SF:")
+		case strings.HasPrefix(line, "DA:"):
+			if lcovFile == "" {
+				return nil, fmt.Errorf("coverage: DA record outside SF section: %q", line)
+			}
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("coverage: malformed DA record: %q", line)
+			}
+			n, err1 := strconv.Atoi(fields[0])
+			hits, err2 := strconv.ParseInt(fields[1], 10, 64)
+			if err1 != nil || err2 != nil || n <= 0 {
+				return nil, fmt.Errorf("coverage: malformed DA record: %q", line)
+			}
+			p.record(lcovFile, n, n, hits)
+		case line == "end_of_record":
+			lcovFile = ""
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if p.Format == "" {
+		return nil, fmt.Errorf("coverage: empty profile")
+	}
+	return p, nil
+}
+
+func (p *Profile) addGoBlock(line string) error {
+	m := goBlockRegex.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("coverage: malformed coverprofile line: %q", line)
+	}
+	start, _ := strconv.Atoi(m[2])
+	end, _ := strconv.Atoi(m[3])
+	hits, err := strconv.ParseInt(m[4], 10, 64)
+	if err != nil || start <= 0 || end < start {
+		return fmt.Errorf("coverage: malformed coverprofile line: %q", line)
+	}
+	p.record(m[1], start, end, hits)
+	return nil
+}
+
+// record adds hits to lines start..end. A line counts as covered when any
+// block or record touching it was executed, so the maximum is kept.
+func (p *Profile) record(file string, start, end int, hits int64) {
+	lines := p.files[file]
+	if lines == nil {
+		lines = make(map[int]int64)
+		p.files[file] = lines
+	}
+	for n := start; n <= end; n++ {
+		if old, ok := lines[n]; !ok || hits > old {
+			lines[n] = hits
+		}
+	}
+}
+
+// fileLines returns the line counts for a repo-relative path. Go profiles name
+// files by import path and LCOV often uses absolute paths, so an exact match
+// is tried first and then the shortest profile name ending in "/"+path.
+func (p *Profile) fileLines(repoPath string) map[int]int64 {
+	repoPath = filepath.ToSlash(filepath.Clean(repoPath))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.byRepoPath[repoPath]; ok {
+		return p.files[key]
+	}
+	key := ""
+	if _, ok := p.files[repoPath]; ok {
+		key = repoPath
+	} else {
+		var candidates []string
+		for name := range p.files {
+			slashed := filepath.ToSlash(name)
+			if strings.HasSuffix(slashed, "/"+repoPath) || strings.TrimPrefix(slashed, "./") == repoPath {
+				candidates = append(candidates, name)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if len(candidates[i]) != len(candidates[j]) {
+				return len(candidates[i]) < len(candidates[j])
+			}
+			return candidates[i] < candidates[j]
+		})
+		if len(candidates) > 0 {
+			key = candidates[0]
+		}
+	}
+	p.byRepoPath[repoPath] = key
+	if key == "" {
+		return nil
+	}
+	return p.files[key]
+}
+
+// Uncovered returns the lines (sorted) that are instrumented in the profile
+// but were never executed, and how many of lines are instrumented at all.
+// known is false when the profile has no data for the file.
+func (p *Profile) Uncovered(repoPath string, lines []int) (uncovered []int, instrumented int, known bool) {
+	if p == nil {
+		return nil, 0, false
+	}
+	counts := p.fileLines(repoPath)
+	if counts == nil {
+		return nil, 0, false
+	}
+	for _, n := range lines {
+		hits, ok := counts[n]
+		if !ok {
+			continue
+		}
+		instrumented++
+		if hits == 0 {
+			uncovered = append(uncovered, n)
+		}
+	}
+	sort.Ints(uncovered)
+	return uncovered, instrumented, true
+}
+
+// hunkHeaderRegex captures the new-file start line of "@@ -a,b +c,d @@".
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// AddedLines returns the new-file line numbers of the "+" lines in a unified
+// diff hunk (RawContent including the @@ header; each further @@ block of a
+// batched hunk restarts the numbering). Returns nil when the first header
+// cannot be parsed.
+func AddedLines(hunkContent string) []int {
+	lines := strings.Split(hunkContent, "\n")
+	if len(lines) == 0 {
+		return nil
+	}
+	m := hunkHeaderRegex.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil
+	}
+	var added []int
+	for _, line := range lines[1:] {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			// next block of a batched hunk (diff.BatchByFile)
+			if m := hunkHeaderRegex.FindStringSubmatch(line); m != nil {
+				if start, err := strconv.Atoi(m[1]); err == nil {
+					n = start
+				}
+			}
+		case strings.HasPrefix(line, "+"):
+			added = append(added, n)
+			n++
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, `\`):
+			// removed line or "\ No newline at end of file": no new-file line
+		default:
+			n++
+		}
+	}
+	return added
+}
+
+// FormatRanges renders sorted line numbers as "3-5, 9".
+func FormatRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, strconv.Itoa(lines[i])+"-"+strconv.Itoa(lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,151 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goProfile = `mode: set
+example.com/app/pkg/calc.go:5.30,7.16 2 1
+example.com/app/pkg/calc.go:7.16,9.3 1 0
+example.com/app/pkg/calc.go:10.2,10.10 1 1
+example.com/app/pkg/calc.go:12.40,14.2 1 0
+example.com/app/other/calc.go:5.1,6.2 1 0
+`
+
+const lcovProfile = `TN:
+SF:/home/ci/app/src/util.ts
+DA:3,4
+DA:4,0
+DA:5,0
+DA:8,1
+end_of_record
+SF:src/other.ts
+DA:1,0
+end_of_record
+`
+
+func TestParse_goProfile(t *testing.T) {
+	p, err := Parse(strings.NewReader(goProfile))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p.Format != FormatGo {
+		t.Errorf("Format = %q, want %q", p.Format, FormatGo)
+	}
+	// Line 7 is shared by a covered and an uncovered block: covered wins.
+	uncovered, instrumented, known := p.Uncovered("pkg/calc.go", []int{6, 7, 8, 9, 11, 13})
+	if !known {
+		t.Fatal("known = false, want true")
+	}
+	if want := []int{8, 9, 13}; !reflect.DeepEqual(uncovered, want) {
+		t.Errorf("uncovered = %v, want %v", uncovered, want)
+	}
+	if instrumented != 5 {
+		t.Errorf("instrumented = %d, want 5 (line 11 is not in any block)", instrumented)
+	}
+	if _, _, known := p.Uncovered("pkg/missing.go", []int{1}); known {
+		t.Error("Uncovered(pkg/missing.go): known = true, want false")
+	}
+}
+
+func TestParse_lcov(t *testing.T) {
+	p, err := Parse(strings.NewReader(lcovProfile))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p.Format != FormatLCOV {
+		t.Errorf("Format = %q, want %q", p.Format, FormatLCOV)
+	}
+	uncovered, instrumented, known := p.Uncovered("src/util.ts", []int{3, 4, 5, 6})
+	if !known || instrumented != 3 {
+		t.Errorf("known=%v instrumented=%d, want true, 3", known, instrumented)
+	}
+	if want := []int{4, 5}; !reflect.DeepEqual(uncovered, want) {
+		t.Errorf("uncovered = %v, want %v", uncovered, want)
+	}
+	uncovered, _, known = p.Uncovered("src/other.ts", []int{1})
+	if !known || !reflect.DeepEqual(uncovered, []int{1}) {
+		t.Errorf("relative SF path: uncovered = %v known = %v", uncovered, known)
+	}
+}
+
+func TestParse_malformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "bad go block", input: "mode: set\nnot a block\n"},
+		{name: "DA outside SF", input: "DA:1,1\n"},
+		{name: "bad DA", input: "SF:a.ts\nDA:x,1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse: want error")
+			}
+		})
+	}
+}
+
+func TestLoad_missingFile_returnsError(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "nope.out")); err == nil {
+		t.Fatal("Load: want error for missing file")
+	}
+	path := filepath.Join(t.TempDir(), "cover.out")
+	if err := os.WriteFile(path, []byte(goProfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p.Path != path {
+		t.Errorf("Path = %q, want %q", p.Path, path)
+	}
+}
+
+func TestAddedLines(t *testing.T) {
+	tests := []struct {
+		name string
+		hunk string
+		want []int
+	}{
+		{
+			name: "mixed",
+			hunk: "@@ -10,4 +10,5 @@ func f() {\n ctx\n-old\n+new1\n+new2\n ctx\n+tail\n",
+			want: []int{11, 12, 14},
+		},
+		{
+			name: "no newline marker",
+			hunk: "@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+b\n",
+			want: []int{1},
+		},
+		{
+			name: "batched blocks",
+			hunk: "@@ -1,1 +1,2 @@\n ctx\n+a\n@@ -40,1 +41,2 @@\n ctx\n+b\n",
+			want: []int{2, 42},
+		},
+		{name: "bad header", hunk: "not a hunk\n+x\n", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddedLines(tt.hunk); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddedLines = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRanges(t *testing.T) {
+	if got := FormatRanges([]int{3, 4, 5, 9, 11, 12}); got != "3-5, 9, 11-12" {
+		t.Errorf("FormatRanges = %q", got)
+	}
+	if got := FormatRanges(nil); got != "" {
+		t.Errorf("FormatRanges(nil) = %q, want empty", got)
+	}
+}
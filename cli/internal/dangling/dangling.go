@@ -0,0 +1,375 @@
+// Package dangling reviews removed code for references left behind: when a
+// hunk removes a top-level declaration (function, type, constant, ...), the
+// HEAD tree is searched for code that still uses the name. Declarations are
+// recognized with the RAG resolvers' definition patterns (rag.IndexerFor), so
+// every language with a registered DefinitionIndexer is supported.
+//
+// Lookups are best-effort: git errors yield no results, never a failed review.
+package dangling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/findings"
+	"stet/cli/internal/git"
+	"stet/cli/internal/hunkid"
+	"stet/cli/internal/rag"
+)
+
+const (
+	gitTimeout = 5 * time.Second
+	// maxSymbols caps the removed declarations checked per hunk (one git grep each).
+	maxSymbols = 10
+	// DefaultMaxReferences is the number of references reported per removed symbol.
+	DefaultMaxReferences = 5
+	// findingConfidence is below 1.0 because a word match may name a different
+	// symbol (e.g. a same-named local or field in another language or scope).
+	findingConfidence = 0.85
+)
+
+// skipNames are entry points or placeholders that are never referenced by name.
+var skipNames = map[string]bool{"_": true, "main": true, "init": true}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+\d+(?:,\d+)? @@`)
+
+// Reference is one remaining use of a removed symbol in the HEAD tree.
+type Reference struct {
+	File string
+	Line int
+	Text string
+}
+
+// Result is the outcome for one removed declaration. A symbol that is Moved
+// (still defined elsewhere in HEAD) or has no References is a safe removal.
+type Result struct {
+	Name       string
+	Line       int // line of the removed declaration in the baseline file
+	Moved      bool
+	References []Reference
+	More       int // references found beyond the reported maximum
+}
+
+// Safe reports whether the removal left nothing behind.
+func (r Result) Safe() bool {
+	return r.Moved || len(r.References) == 0
+}
+
+type symbol struct {
+	name string
+	line int
+}
+
+// Check finds the top-level declarations hunk removes (and does not re-add)
+// and searches the HEAD tree of repoRoot for remaining references to each,
+// reporting up to maxRefs per symbol (0 = DefaultMaxReferences). Returns nil
+// when the hunk removes no declarations or its language has no indexer.
+func Check(ctx context.Context, repoRoot string, hunk diff.Hunk, maxRefs int) []Result {
+	ix, ok := rag.IndexerFor(path.Ext(hunk.FilePath))
+	if !ok || repoRoot == "" {
+		return nil
+	}
+	if maxRefs <= 0 {
+		maxRefs = DefaultMaxReferences
+	}
+	syms := removedSymbols(ix, hunk.RawContent)
+	var results []Result
+	for _, sym := range syms {
+		res, err := lookup(ctx, repoRoot, ix, hunk.FilePath, sym, maxRefs)
+		if err != nil {
+			continue
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// removedSymbols returns the names defined by unindented removed lines that
+// no added line of the same hunk defines again, in order of appearance.
+func removedSymbols(ix rag.DefinitionIndexer, hunkContent string) []symbol {
+	lines := strings.Split(hunkContent, "\n")
+	if len(lines) == 0 {
+		return nil
+	}
+	m := hunkHeaderRe.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil
+	}
+	oldLine, _ := strconv.Atoi(m[1])
+	var removed []symbol
+	readded := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, line := range lines[1:] {
+		if line == "" {
+			oldLine++
+			continue
+		}
+		body := line[1:]
+		switch line[0] {
+		case '-':
+			if isTopLevel(body) {
+				for _, name := range ix.DefinedNames(body) {
+					if !skipNames[name] && !seen[name] {
+						seen[name] = true
+						removed = append(removed, symbol{name: name, line: oldLine})
+					}
+				}
+			}
+			oldLine++
+		case '+':
+			if isTopLevel(body) {
+				for _, name := range ix.DefinedNames(body) {
+					readded[name] = true
+				}
+			}
+		case ' ':
+			oldLine++
+		}
+	}
+	var out []symbol
+	for _, s := range removed {
+		if !readded[s.name] {
+			out = append(out, s)
+		}
+		if len(out) == maxSymbols {
+			break
+		}
+	}
+	return out
+}
+
+// isTopLevel reports whether a source line starts in column 0 and is code.
+func isTopLevel(line string) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return false
+	}
+	return !isComment(line)
+}
+
+func isComment(line string) bool {
+	t := strings.TrimSpace(line)
+	for _, p := range []string{"//", "/*", "*", "#"} {
+		if strings.HasPrefix(t, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup greps HEAD for sym.name in files of the same index language. A
+// top-level definition of the name means the symbol moved (for Go, only one in
+// the same package dir); other non-comment matches are references. Unexported
+// Go names are scoped to the package dir, and Go matches must use the name the
+// way a package-level identifier is used (see goReference), so same-named
+// fields, methods, parameters and other packages' identifiers are not reported.
+func lookup(ctx context.Context, repoRoot string, ix rag.DefinitionIndexer, filePath string, sym symbol, maxRefs int) (Result, error) {
+	res := Result{Name: sym.name, Line: sym.line}
+	out, err := runGit(ctx, repoRoot, "grep", "-n", "-w", "-I", "-F", "--null", "-e", sym.name, "HEAD", "--")
+	if err != nil {
+		return res, err
+	}
+	language := ix.IndexLanguage()
+	isGo := language == "go"
+	packageOnly := isGo && !startsUpper(sym.name)
+	dir := path.Dir(filePath)
+	var pkgName string
+	if isGo && !packageOnly {
+		pkgName = goPackageName(ctx, repoRoot, dir)
+	}
+	for _, rec := range strings.Split(out, "\n") {
+		// HEAD:<path>\0<line>\0<text>
+		parts := strings.SplitN(strings.TrimPrefix(rec, "HEAD:"), "\x00", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		file, text := parts[0], parts[2]
+		n, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		other, ok := rag.IndexerFor(path.Ext(file))
+		if !ok || other.IndexLanguage() != language {
+			continue
+		}
+		if packageOnly && path.Dir(file) != dir {
+			continue
+		}
+		if isComment(text) {
+			continue
+		}
+		if isTopLevel(text) && containsName(other.DefinedNames(text), sym.name) {
+			if isGo && path.Dir(file) != dir {
+				continue // another package's declaration; references to this one still break
+			}
+			res.Moved = true
+			res.References = nil
+			res.More = 0
+			return res, nil
+		}
+		if isGo && !goReference(text, sym.name, path.Dir(file) == dir, pkgName) {
+			continue
+		}
+		if len(res.References) < maxRefs {
+			res.References = append(res.References, Reference{File: file, Line: n, Text: strings.TrimSpace(text)})
+		} else {
+			res.More++
+		}
+	}
+	return res, nil
+}
+
+// Findings returns one correctness finding per remaining reference in
+// results, located at the referencing line, with stable IDs. Severity is
+// warning, not error: a textual match cannot prove the reference resolves to
+// the removed declaration.
+func Findings(hunk diff.Hunk, results []Result) []findings.Finding {
+	var out []findings.Finding
+	for _, r := range results {
+		if r.Moved {
+			continue
+		}
+		for _, ref := range r.References {
+			msg := fmt.Sprintf("%s was removed from %s but is still referenced here.", r.Name, hunk.FilePath)
+			out = append(out, findings.Finding{
+				ID:         hunkid.StableFindingID(ref.File, ref.Line, 0, 0, msg),
+				File:       ref.File,
+				Line:       ref.Line,
+				Severity:   findings.SeverityWarning,
+				Category:   findings.CategoryCorrectness,
+				Confidence: findingConfidence,
+				Message:    msg,
+				Suggestion: "Update or remove this reference, or keep the definition of " + r.Name + ".",
+			})
+		}
+	}
+	return out
+}
+
+// Summary returns a one-line description of r for traces, e.g.
+// "Foo (line 12): 2 references" or "Bar (line 30): moved (removal is safe)".
+func Summary(r Result) string {
+	switch {
+	case r.Moved:
+		return fmt.Sprintf("%s (line %d): still defined in HEAD (moved; removal is safe)", r.Name, r.Line)
+	case len(r.References) == 0:
+		return fmt.Sprintf("%s (line %d): no remaining references (removal is safe)", r.Name, r.Line)
+	default:
+		return fmt.Sprintf("%s (line %d): %d references", r.Name, r.Line, len(r.References)+r.More)
+	}
+}
+
+// goReference reports whether text uses name as a reference to a package-level
+// declaration: unqualified (not after "." and not declaring a same-named field
+// or parameter) when samePkg, else qualified by pkgName ("pkg.Name").
+// Selectors on other values and same-named identifiers of other packages do
+// not count.
+func goReference(text, name string, samePkg bool, pkgName string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		i = end
+		if (start > 0 && isIdentByte(text[start-1])) || (end < len(text) && isIdentByte(text[end])) {
+			continue
+		}
+		before := text[:start]
+		if samePkg {
+			if !strings.HasSuffix(before, ".") && !declaresName(text[end:]) {
+				return true
+			}
+			continue
+		}
+		if pkgName == "" || !strings.HasSuffix(before, pkgName+".") {
+			continue
+		}
+		if q := len(before) - len(pkgName) - 1; q == 0 || (!isIdentByte(before[q-1]) && before[q-1] != '.') {
+			return true
+		}
+	}
+}
+
+// declaresName reports whether rest, the text after a name, makes the name a
+// declaration of a field, parameter or variable ("Name int", "Name *T",
+// "Name []byte") rather than a use. Relies on gofmt spacing: in an expression
+// a binary operator is followed by a space and an index has none before it.
+func declaresName(rest string) bool {
+	t := strings.TrimLeft(rest, " \t")
+	if len(t) == len(rest) || t == "" {
+		return false
+	}
+	switch c := t[0]; {
+	case c == '*':
+		return len(t) > 1 && t[1] != ' '
+	case c == '[':
+		return true
+	default:
+		return isIdentByte(c) && (c < '0' || c > '9')
+	}
+}
+
+// goPackageName returns the package name declared by the non-test Go files of
+// dir in HEAD, or the directory name when none is left.
+func goPackageName(ctx context.Context, repoRoot, dir string) string {
+	out, _ := runGit(ctx, repoRoot, "grep", "-h", "-m", "1", "-E", "^package [A-Za-z_]", "HEAD", "--", ":(glob)"+path.Join(dir, "*.go"))
+	for _, line := range strings.Split(out, "\n") {
+		name := strings.TrimSpace(strings.TrimPrefix(line, "package "))
+		if i := strings.IndexAny(name, " \t/"); i >= 0 {
+			name = name[:i]
+		}
+		if name != "" && !strings.HasSuffix(name, "_test") {
+			return name
+		}
+	}
+	if dir == "." {
+		return ""
+	}
+	return path.Base(dir)
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func startsUpper(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func runGit(ctx context.Context, repoRoot string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoRoot
+	cmd.Env = git.MinimalEnv()
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 && args[0] == "grep" {
+			return "", nil
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,194 @@
+package dangling
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/rag"
+	_ "stet/cli/internal/rag/go"     // register Go resolver (DefinitionIndexer)
+	_ "stet/cli/internal/rag/python" // register Python resolver
+)
+
+func TestRemovedSymbols(t *testing.T) {
+	ix, ok := rag.IndexerFor(".go")
+	if !ok {
+		t.Fatal("no Go indexer registered")
+	}
+	hunk := "@@ -10,9 +10,4 @@\n" +
+		" // keep\n" +
+		"-func Old(a int) int {\n" +
+		"-\tvar local int\n" +
+		"-\treturn a\n" +
+		"-}\n" +
+		"-// type InComment struct{}\n" +
+		"-type Renamed struct{}\n" +
+		"+type Renamed struct{ X int }\n" +
+		"-func main() {}\n" +
+		" const Kept = 1"
+	got := removedSymbols(ix, hunk)
+	if len(got) != 1 || got[0].name != "Old" || got[0].line != 11 {
+		t.Errorf("removedSymbols = %+v, want [{Old 11}] (locals, comments, re-added and main skipped)", got)
+	}
+	if got := removedSymbols(ix, "not a hunk\n-func X() {}"); got != nil {
+		t.Errorf("bad header: got %+v, want nil", got)
+	}
+}
+
+func TestCheck_goReferencesMovedAndSafe(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"pkg/a.go":      "package pkg\n\nfunc Used() {}\n\nfunc Moved() {}\n\nfunc Unused() {}\n\nfunc helper() {}\n",
+		"pkg/b.go":      "package pkg\n\nfunc b() {\n\t// Used is gone\n\tUsed()\n\thelper()\n}\n",
+		"other/c.go":    "package other\n\nimport \"x/pkg\"\n\nfunc c() {\n\tpkg.Used()\n\tpkg.Moved()\n\thelper()\n}\n\nfunc helper() {}\n",
+		"other/note.py": "Used = 1\n",
+		"other/d.go":    "package other\n\nfunc d(s struct{ Used int }) int {\n\treturn s.Used + mine.Used\n}\n",
+		"pkg/e.go":      "package pkg\n\nfunc e(v struct{ Used int }) int {\n\treturn v.Used\n}\n",
+	})
+	// HEAD: a.go loses Used, Moved, Unused and helper; Moved reappears in pkg/moved.go.
+	writeFiles(t, dir, map[string]string{
+		"pkg/a.go":     "package pkg\n",
+		"pkg/moved.go": "package pkg\n\nfunc Moved() {}\n",
+	})
+	commit(t, dir, "remove")
+	hunk := diff.Hunk{
+		FilePath:   "pkg/a.go",
+		RawContent: "@@ -1,9 +1,1 @@\n package pkg\n-\n-func Used() {}\n-\n-func Moved() {}\n-\n-func Unused() {}\n-\n-func helper() {}",
+	}
+	results := Check(context.Background(), dir, hunk, 0)
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4: %+v", len(results), results)
+	}
+	byName := make(map[string]Result)
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	used := byName["Used"]
+	if used.Safe() || len(used.References) != 2 || used.References[0].File != "other/c.go" || used.References[1].File != "pkg/b.go" || used.References[1].Line != 5 {
+		t.Errorf("Used = %+v, want 2 Go references (comment, .py, fields and other qualifiers skipped)", used)
+	}
+	if !byName["Moved"].Moved || !byName["Moved"].Safe() {
+		t.Errorf("Moved = %+v, want Moved", byName["Moved"])
+	}
+	if !byName["Unused"].Safe() {
+		t.Errorf("Unused = %+v, want safe", byName["Unused"])
+	}
+	if h := byName["helper"]; len(h.References) != 1 || h.References[0].File != "pkg/b.go" {
+		t.Errorf("helper = %+v, want only the same-package reference", h)
+	}
+	if s := Summary(byName["Unused"]); !strings.Contains(s, "removal is safe") {
+		t.Errorf("Summary(Unused) = %q", s)
+	}
+
+	list := Findings(hunk, results)
+	if len(list) != 3 {
+		t.Fatalf("Findings: got %d, want 3 (Used x2, helper x1)", len(list))
+	}
+	f := list[0]
+	if f.File != "other/c.go" || f.Line != 6 || f.ID == "" || f.Category != "correctness" || !strings.Contains(f.Message, "Used was removed from pkg/a.go") {
+		t.Errorf("finding = %+v", f)
+	}
+	if err := f.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestCheck_sameNameInOtherPackageIsNotAMove(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"a/a.go":   "package a\n\nfunc New() int { return 1 }\n",
+		"b/b.go":   "package b\n\nfunc New() int { return 2 }\n",
+		"use/u.go": "package use\n\nimport (\n\t\"x/a\"\n\t\"x/b\"\n)\n\nfunc u() int {\n\treturn a.New() + b.New()\n}\n",
+	})
+	writeFiles(t, dir, map[string]string{"a/a.go": "package a\n"})
+	commit(t, dir, "remove")
+	hunk := diff.Hunk{FilePath: "a/a.go", RawContent: "@@ -1,3 +1,1 @@\n package a\n-\n-func New() int { return 1 }"}
+	results := Check(context.Background(), dir, hunk, 0)
+	if len(results) != 1 || results[0].Moved || len(results[0].References) != 1 || results[0].References[0].File != "use/u.go" || results[0].References[0].Line != 9 {
+		t.Fatalf("results = %+v, want New not moved, referenced once as a.New in use/u.go:9", results)
+	}
+}
+
+func TestCheck_maxReferencesAndUnsupported(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"lib.py":   "def gone():\n    pass\n",
+		"use.py":   "from lib import gone\ngone()\ngone()\ngone()\n",
+		"data.txt": "gone\n",
+	})
+	writeFiles(t, dir, map[string]string{"lib.py": "\n"})
+	commit(t, dir, "remove")
+	hunk := diff.Hunk{FilePath: "lib.py", RawContent: "@@ -1,2 +1,1 @@\n-def gone():\n-    pass\n+"}
+	results := Check(context.Background(), dir, hunk, 2)
+	if len(results) != 1 || len(results[0].References) != 2 || results[0].More != 2 {
+		t.Fatalf("results = %+v, want 2 references and 2 more", results)
+	}
+	if got := Check(context.Background(), dir, diff.Hunk{FilePath: "data.txt", RawContent: "@@ -1 +0,0 @@\n-gone"}, 0); got != nil {
+		t.Errorf("unsupported language: got %+v, want nil", got)
+	}
+}
+
+func setupRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	gitCmd(t, dir, "init", "-q")
+	gitCmd(t, dir, "config", "user.email", "test@stet.local")
+	gitCmd(t, dir, "config", "user.name", "Test")
+	writeFiles(t, dir, files)
+	commit(t, dir, "init")
+	return dir
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func commit(t *testing.T, dir, msg string) {
+	t.Helper()
+	gitCmd(t, dir, "add", "-A")
+	gitCmd(t, dir, "commit", "-q", "-m", msg)
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestGoReference(t *testing.T) {
+	tests := []struct {
+		text    string
+		samePkg bool
+		want    bool
+	}{
+		{"\tUsed()", true, true},
+		{"\tx := Used + 1", true, true},
+		{"\treturn s.Used", true, false},
+		{"\tUsed int", true, false},
+		{"func f(Used *T) {", true, false},
+		{"\tUsed []byte", true, false},
+		{"\tUsedMore()", true, false},
+		{"\tpkg.Used()", false, true},
+		{"\tmypkg.Used()", false, false},
+		{"\tx.pkg.Used()", false, false},
+		{"\tUsed()", false, false},
+	}
+	for _, tt := range tests {
+		if got := goReference(tt.text, "Used", tt.samePkg, "pkg"); got != tt.want {
+			t.Errorf("goReference(%q, samePkg=%v) = %v, want %v", tt.text, tt.samePkg, got, tt.want)
+		}
+	}
+}
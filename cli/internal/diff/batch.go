@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"strings"
+
+	"stet/cli/internal/tokens"
+)
+
+// BatchByFile combines all hunks of a file into one hunk when the file has
+// more than one hunk and their raw content together fits in maxTokens
+// (estimated). The combined hunk keeps the file metadata of its first hunk,
+// joins the @@ blocks in RawContent and lists the originals in Parts, so
+// findings can be attributed back to the hunk that contains their line.
+// Files that do not fit are left as separate hunks. Hunks of one file must be
+// adjacent, as produced by Hunks and Plan. maxTokens <= 0 returns hunks as is.
+func BatchByFile(hunks []Hunk, maxTokens int) []Hunk {
+	if maxTokens <= 0 || len(hunks) < 2 {
+		return hunks
+	}
+	out := make([]Hunk, 0, len(hunks))
+	for i := 0; i < len(hunks); {
+		j := i + 1
+		for j < len(hunks) && hunks[j].FilePath == hunks[i].FilePath {
+			j++
+		}
+		group := hunks[i:j]
+		i = j
+		if len(group) < 2 {
+			out = append(out, group...)
+			continue
+		}
+		blocks := make([]string, len(group))
+		sum := 0
+		for k, h := range group {
+			blocks[k] = h.RawContent
+			sum += tokens.Estimate(h.RawContent)
+		}
+		if sum > maxTokens {
+			out = append(out, group...)
+			continue
+		}
+		b := group[0]
+		b.RawContent = strings.Join(blocks, "\n")
+		b.Context = b.RawContent
+		b.Parts = append([]Hunk(nil), group...)
+		out = append(out, b)
+	}
+	return out
+}
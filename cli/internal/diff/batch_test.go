@@ -0,0 +1,32 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBatchByFile(t *testing.T) {
+	t.Parallel()
+	a1 := Hunk{FilePath: "a.go", Status: StatusModified, RawContent: "@@ -1,1 +1,1 @@\n-x\n+y"}
+	a2 := Hunk{FilePath: "a.go", Status: StatusModified, RawContent: "@@ -20,1 +20,2 @@\n z\n+w"}
+	b1 := Hunk{FilePath: "b.go", RawContent: "@@ -5,1 +5,1 @@\n-p\n+q"}
+	c1 := Hunk{FilePath: "c.go", RawContent: "@@ -1,1 +1,1 @@\n-" + strings.Repeat("long", 100)}
+	c2 := Hunk{FilePath: "c.go", RawContent: "@@ -9,1 +9,1 @@\n+v"}
+
+	got := BatchByFile([]Hunk{a1, a2, b1, c1, c2}, 50)
+	if len(got) != 4 {
+		t.Fatalf("len = %d, want 4 (a.go batched; b.go single; c.go over budget)", len(got))
+	}
+	if len(got[0].Parts) != 2 || got[0].FilePath != "a.go" || got[0].Status != StatusModified {
+		t.Errorf("batch = %+v, want a.go with 2 parts", got[0])
+	}
+	if want := a1.RawContent + "\n" + a2.RawContent; got[0].RawContent != want || got[0].Context != want {
+		t.Errorf("batch RawContent = %q, want %q", got[0].RawContent, want)
+	}
+	if got[1].Parts != nil || got[2].Parts != nil || got[3].Parts != nil {
+		t.Error("single and over-budget hunks must not have Parts")
+	}
+	if got := BatchByFile([]Hunk{a1, a2}, 0); len(got) != 2 {
+		t.Errorf("maxTokens 0: len = %d, want 2", len(got))
+	}
+}
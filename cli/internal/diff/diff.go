@@ -19,6 +19,15 @@
 // # Empty diff
 // When baseline..HEAD has no changes, Hunks returns a nil slice and no error.
 //
+// # Renames, copies and modes
+// The diff runs with rename detection (-M). A moved file whose content is
+// unchanged yields no hunks; a moved file with edits yields only the edited
+// hunks, keyed by the new path with OldPath set. Copy detection is off, so a
+// file created by copying is reviewed in full as an addition; copy headers in
+// patch files are still parsed. Each hunk carries its
+// file's Status, similarity and mode change (see Hunk.StatusNote). Mode-only
+// changes have no content to review and yield no hunks.
+//
 // # Merge commits
 // When HEAD is a merge commit, git diff baseline..HEAD shows the combined diff
 // (changes from the first parent of HEAD to HEAD). No special handling is
@@ -36,13 +45,61 @@ import (
 	"stet/cli/internal/erruser"
 )
 
+// FileStatus is the git change status of the file a hunk belongs to.
+type FileStatus string
+
+const (
+	StatusModified FileStatus = "modified"
+	StatusAdded    FileStatus = "added"
+	StatusDeleted  FileStatus = "deleted"
+	StatusRenamed  FileStatus = "renamed"
+	StatusCopied   FileStatus = "copied"
+)
+
 // Hunk is one diff block for review: a file path and the raw unified hunk
 // content (including @@ line and context). Context is set to RawContent for
-// use in prompts.
+// use in prompts. The file metadata (Status, OldPath, Similarity, modes) comes
+// from the extended header lines of `git diff -M`; it is the same for every
+// hunk of a file and is empty when a hunk was not built by ParseUnifiedDiff.
 type Hunk struct {
 	FilePath   string // path relative to repo root (HEAD side)
 	RawContent string
 	Context    string // same as RawContent for pipeline output; used when building prompts
+	Status     FileStatus
+	OldPath    string // baseline-side path when it differs from FilePath (renamed or copied)
+	Similarity int    // rename/copy similarity index in percent; 0 otherwise
+	OldMode    string // baseline-side file mode when it changed (e.g. "100644"), or the mode of a deleted file
+	NewMode    string // HEAD-side file mode when it changed (e.g. "100755"), or the mode of an added file
+	// Parts holds the hunks batched into this one by BatchByFile, in order; nil
+	// for a single hunk. RawContent is then their @@ blocks joined by newlines.
+	Parts []Hunk
+}
+
+// StatusNote returns a short description of h's file status for prompts and
+// output, e.g. "renamed from old.go, 92% similar" or "mode 100644 -> 100755".
+// Plain modifications with unchanged mode return "".
+func (h Hunk) StatusNote() string {
+	var parts []string
+	switch h.Status {
+	case StatusAdded:
+		parts = append(parts, "new file")
+	case StatusDeleted:
+		parts = append(parts, "deleted file")
+	case StatusRenamed, StatusCopied:
+		verb := "renamed"
+		if h.Status == StatusCopied {
+			verb = "copied"
+		}
+		note := verb + " from " + h.OldPath
+		if h.Similarity > 0 {
+			note += fmt.Sprintf(", %d%% similar", h.Similarity)
+		}
+		parts = append(parts, note)
+	}
+	if h.Status != StatusAdded && h.Status != StatusDeleted && h.OldMode != "" && h.NewMode != "" {
+		parts = append(parts, "mode "+h.OldMode+" -> "+h.NewMode)
+	}
+	return strings.Join(parts, "; ")
 }
 
 // Options configures the diff pipeline. Nil means use default exclusions.
@@ -65,7 +122,7 @@ var defaultExcludePatterns = []string{
 	"coverage/*",
 }
 
-// Hunks runs git diff baseline..head from repoRoot, parses the unified diff,
+// Hunks runs git diff -M baseline..head from repoRoot, parses the unified diff,
 // skips binary files, applies exclude patterns, and returns the list of hunks.
 // Context is used for cancellation when running git.
 func Hunks(ctx context.Context, repoRoot, baselineRef, headRef string, opts *Options) ([]Hunk, error) {
@@ -130,7 +187,7 @@ func CountHunkScope(hunks []Hunk) (linesAdded, linesRemoved, charsAdded, charsDe
 }
 
 func runGitDiff(ctx context.Context, repoRoot, baseline, head string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "diff", "--no-color", "--no-ext-diff", baseline+".."+head)
+	cmd := exec.CommandContext(ctx, "git", "diff", "--no-color", "--no-ext-diff", "-M", baseline+".."+head)
 	cmd.Dir = repoRoot
 	cmd.Env = minimalEnvForRepo(repoRoot)
 	out, err := cmd.CombinedOutput()
@@ -154,6 +154,83 @@ func TestHunks_integration_twoCommits(t *testing.T) {
 	}
 }
 
+func TestHunks_renameOnlyReviewsEdits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fix := initRepoDiff(t)
+	var body strings.Builder
+	for i := 0; i < 20; i++ {
+		body.WriteString("line " + string(rune('a'+i)) + "\n")
+	}
+	writeFile(t, fix.dir, "big.txt", body.String())
+	writeFile(t, fix.dir, "same.txt", body.String()+"same\n")
+	runGit(t, fix.dir, "git", "add", "big.txt", "same.txt")
+	runGit(t, fix.dir, "git", "commit", "-m", "add big")
+	base := gitHEAD(t, fix.dir)
+	runGit(t, fix.dir, "git", "mv", "big.txt", "moved.txt")
+	runGit(t, fix.dir, "git", "mv", "same.txt", "same_moved.txt")
+	writeFile(t, fix.dir, "moved.txt", strings.Replace(body.String(), "line c\n", "line C\n", 1))
+	runGit(t, fix.dir, "git", "add", "moved.txt")
+	runGit(t, fix.dir, "git", "commit", "-m", "move")
+	head := gitHEAD(t, fix.dir)
+
+	hunks, err := Hunks(ctx, fix.dir, base, head, nil)
+	if err != nil {
+		t.Fatalf("Hunks: %v", err)
+	}
+	if len(hunks) != 1 {
+		t.Fatalf("len(hunks) = %d, want 1 (edit in moved file only; pure rename skipped): %+v", len(hunks), hunks)
+	}
+	h := hunks[0]
+	if h.FilePath != "moved.txt" || h.OldPath != "big.txt" || h.Status != StatusRenamed || h.Similarity == 0 {
+		t.Errorf("hunk = %+v, want renamed big.txt -> moved.txt with similarity", h)
+	}
+	if strings.Contains(h.RawContent, "line t") {
+		t.Errorf("hunk should cover only the edit, not the whole file:\n%s", h.RawContent)
+	}
+}
+
+func TestHunks_copyReviewedAsAddition(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fix := initRepoDiff(t)
+	var body strings.Builder
+	for i := 0; i < 20; i++ {
+		body.WriteString("line " + string(rune('a'+i)) + "\n")
+	}
+	writeFile(t, fix.dir, "src.txt", body.String())
+	runGit(t, fix.dir, "git", "add", "src.txt")
+	runGit(t, fix.dir, "git", "commit", "-m", "add src")
+	base := gitHEAD(t, fix.dir)
+	// Copy src.txt and edit the source in the same commit, which git's copy
+	// detection (-C) would report as a pure copy with no hunks.
+	writeFile(t, fix.dir, "copy.txt", body.String())
+	writeFile(t, fix.dir, "src.txt", body.String()+"more\n")
+	runGit(t, fix.dir, "git", "add", "copy.txt", "src.txt")
+	runGit(t, fix.dir, "git", "commit", "-m", "copy")
+	head := gitHEAD(t, fix.dir)
+
+	hunks, err := Hunks(ctx, fix.dir, base, head, nil)
+	if err != nil {
+		t.Fatalf("Hunks: %v", err)
+	}
+	var copied *Hunk
+	for i := range hunks {
+		if hunks[i].FilePath == "copy.txt" {
+			copied = &hunks[i]
+		}
+	}
+	if copied == nil {
+		t.Fatalf("copied file should be reviewed; hunks: %+v", hunks)
+	}
+	if copied.Status != StatusAdded || copied.OldPath != "" {
+		t.Errorf("copy.txt hunk = %+v, want an addition", *copied)
+	}
+	if !strings.Contains(copied.RawContent, "+line t") {
+		t.Errorf("copy.txt hunk should cover the whole file:\n%s", copied.RawContent)
+	}
+}
+
 func TestHunks_emptyDiff(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
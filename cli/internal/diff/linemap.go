@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"stet/cli/internal/erruser"
+)
+
+// LineMap maps line numbers of files at one commit to their position at a
+// later commit. It is built from a zero-context diff between the two, so every
+// hunk is exactly the lines that changed.
+type LineMap struct {
+	files map[string]fileLineMap // keyed by path at the earlier commit
+}
+
+type fileLineMap struct {
+	path    string // path at the later commit
+	deleted bool
+	changes []planHunk // in old-line order; starts are first lines (see parsePlanHunk)
+}
+
+// Location is where a line range of the earlier commit is at the later one.
+// When Modified is true, some line of the range (or a line inserted inside it)
+// changed; Start and End then point at the replacing lines as a best guess.
+// Deleted means the file no longer exists; Start and End are unchanged.
+type Location struct {
+	Path       string
+	Start, End int
+	Modified   bool
+	Deleted    bool
+}
+
+// NewLineMap runs git diff -U0 -M fromRef..toRef from repoRoot and returns the
+// resulting line map. Files without changes map to themselves.
+func NewLineMap(ctx context.Context, repoRoot, fromRef, toRef string) (*LineMap, error) {
+	if repoRoot == "" {
+		return nil, erruser.New("Repository root is required.", nil)
+	}
+	if fromRef == toRef {
+		return &LineMap{}, nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "diff", "--no-color", "--no-ext-diff", "-U0", "-M", fromRef+".."+toRef)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnvForRepo(repoRoot)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, erruser.New("Could not compute diff since the last review.", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out))))
+	}
+	return parseLineMap(string(out))
+}
+
+func parseLineMap(out string) (*LineMap, error) {
+	m := &LineMap{files: make(map[string]fileLineMap)}
+	for _, section := range splitByFileSections(out) {
+		meta, blocks, err := parseFileSection(section)
+		if err != nil {
+			return nil, erruser.New("Could not parse diff output.", err)
+		}
+		// A copy leaves its source in place; only the new path changed.
+		if meta.Status == StatusCopied || meta.Status == StatusAdded {
+			continue
+		}
+		from := meta.FilePath
+		if meta.OldPath != "" {
+			from = meta.OldPath
+		}
+		fm := fileLineMap{path: meta.FilePath, deleted: meta.Status == StatusDeleted}
+		for _, b := range blocks {
+			if ph, ok := parsePlanHunk(b); ok {
+				fm.changes = append(fm.changes, ph)
+			}
+		}
+		m.files[from] = fm
+	}
+	return m, nil
+}
+
+// Locate returns where lines start..end (1-based, inclusive) of path at the
+// earlier commit are at the later commit. end < start is treated as start.
+func (m *LineMap) Locate(path string, start, end int) Location {
+	if end < start {
+		end = start
+	}
+	fm, ok := m.files[path]
+	if !ok {
+		return Location{Path: path, Start: start, End: end}
+	}
+	if fm.deleted {
+		return Location{Path: path, Start: start, End: end, Deleted: true}
+	}
+	loc := Location{Path: fm.path}
+	var touched bool
+	loc.Start, touched = fm.mapLine(start)
+	loc.Modified = touched
+	loc.End, touched = fm.mapLine(end)
+	loc.Modified = loc.Modified || touched
+	for _, c := range fm.changes {
+		if c.oldStart > end {
+			break
+		}
+		// Changed or inserted lines strictly inside the range.
+		if c.oldStart > start || (c.oldCount > 0 && c.oldStart+c.oldCount-1 >= start) {
+			loc.Modified = true
+		}
+	}
+	if loc.End < loc.Start {
+		loc.End = loc.Start
+	}
+	return loc
+}
+
+// mapLine returns the later-commit line for an earlier-commit line and
+// whether that line itself was changed or removed.
+func (fm fileLineMap) mapLine(line int) (int, bool) {
+	offset := 0
+	for _, c := range fm.changes {
+		if c.oldStart > line {
+			break
+		}
+		if c.oldCount == 0 {
+			// Pure insertion before c.oldStart.
+			offset += c.newCount
+			continue
+		}
+		if line <= c.oldStart+c.oldCount-1 {
+			n := c.newStart
+			if c.newCount > 0 {
+				n += min(line-c.oldStart, c.newCount-1)
+			}
+			return max(n, 1), true
+		}
+		offset += c.newCount - c.oldCount
+	}
+	return line + offset, false
+}
@@ -0,0 +1,72 @@
+package diff
+
+import "testing"
+
+func TestLineMap_Locate(t *testing.T) {
+	t.Parallel()
+	out := `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -2,0 +3,2 @@ func A() {
++	x()
++	y()
+@@ -10,2 +12 @@ func B() {
+-	old1()
+-	old2()
++	new()
+@@ -20 +19,0 @@ func C() {
+-	gone()
+diff --git a/old.go b/new.go
+similarity index 90%
+rename from old.go
+rename to new.go
+index 3333333..4444444 100644
+--- a/old.go
++++ b/new.go
+@@ -1 +1,2 @@
+ package p
++// doc
+diff --git a/moved.go b/moved2.go
+similarity index 100%
+rename from moved.go
+rename to moved2.go
+diff --git a/dead.go b/dead.go
+deleted file mode 100644
+index 5555555..0000000
+--- a/dead.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package p
+-func F() {}
+`
+	m, err := parseLineMap(out)
+	if err != nil {
+		t.Fatalf("parseLineMap: %v", err)
+	}
+	tests := []struct {
+		name       string
+		path       string
+		start, end int
+		want       Location
+	}{
+		{"before first change", "a.go", 1, 1, Location{Path: "a.go", Start: 1, End: 1}},
+		{"line before insertion keeps position", "a.go", 2, 2, Location{Path: "a.go", Start: 2, End: 2}},
+		{"shifted by insertion", "a.go", 3, 5, Location{Path: "a.go", Start: 5, End: 7}},
+		{"changed lines", "a.go", 10, 10, Location{Path: "a.go", Start: 12, End: 12, Modified: true}},
+		{"range spanning change", "a.go", 9, 12, Location{Path: "a.go", Start: 11, End: 13, Modified: true}},
+		{"after replacement", "a.go", 12, 12, Location{Path: "a.go", Start: 13, End: 13}},
+		{"deleted line", "a.go", 20, 20, Location{Path: "a.go", Start: 20, End: 20, Modified: true}},
+		{"after deletion", "a.go", 25, 25, Location{Path: "a.go", Start: 25, End: 25}},
+		{"later path is not mapped", "new.go", 1, 1, Location{Path: "new.go", Start: 1, End: 1}},
+		{"renamed with edit", "old.go", 1, 2, Location{Path: "new.go", Start: 1, End: 3, Modified: true}},
+		{"pure rename", "moved.go", 4, 4, Location{Path: "moved2.go", Start: 4, End: 4}},
+		{"deleted file", "dead.go", 2, 2, Location{Path: "dead.go", Start: 2, End: 2, Deleted: true}},
+		{"untouched file", "other.go", 7, 0, Location{Path: "other.go", Start: 7, End: 7}},
+	}
+	for _, tt := range tests {
+		if got := m.Locate(tt.path, tt.start, tt.end); got != tt.want {
+			t.Errorf("%s: Locate(%s, %d, %d) = %+v, want %+v", tt.name, tt.path, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
@@ -3,24 +3,35 @@ package diff
 import (
 	"bufio"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 // BinaryMarker is the prefix git uses when a file is binary.
 const binaryMarker = "Binary files "
 
+// devNull is the path diff uses for the missing side of an added or deleted file.
+const devNull = "/dev/null"
+
 // hunkHeader matches @@ -oldStart,oldCount +newStart,newCount @@ optional
 var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+\d+(?:,\d+)? @@`)
 
 // ParseUnifiedDiff parses the output of `git diff --no-color` and returns
 // hunks. Binary file blocks (git emits "Binary files a/x b/x differ") are
-// skipped; no hunks are produced for those files. Empty diff produces nil.
+// skipped; no hunks are produced for those files. Files without content
+// changes (pure renames or copies, mode-only changes) produce no hunks.
+// Extended header lines set each hunk's Status, OldPath, Similarity and modes.
+// Empty diff produces nil.
 func ParseUnifiedDiff(diffOutput string) ([]Hunk, error) {
 	if strings.TrimSpace(diffOutput) == "" {
 		return nil, nil
 	}
 
-	sections := splitByFileSections(diffOutput)
+	return parseSections(splitByFileSections(diffOutput))
+}
+
+// parseSections parses each file section and concatenates their hunks.
+func parseSections(sections []string) ([]Hunk, error) {
 	var hunks []Hunk
 	for _, section := range sections {
 		section = strings.TrimSpace(section)
@@ -30,16 +41,15 @@ func ParseUnifiedDiff(diffOutput string) ([]Hunk, error) {
 		if strings.Contains(section, binaryMarker) {
 			continue
 		}
-		filePath, hunkBlocks, err := parseFileSection(section)
+		meta, hunkBlocks, err := parseFileSection(section)
 		if err != nil {
 			return nil, err
 		}
 		for _, block := range hunkBlocks {
-			hunks = append(hunks, Hunk{
-				FilePath:   filePath,
-				RawContent: block,
-				Context:    block,
-			})
+			h := meta
+			h.RawContent = block
+			h.Context = block
+			hunks = append(hunks, h)
 		}
 	}
 	return hunks, nil
@@ -76,7 +86,9 @@ func splitByFileSections(out string) []string {
 	return sections
 }
 
-func parseFileSection(section string) (filePath string, hunkBlocks []string, err error) {
+// parseFileSection parses one file's section. meta carries FilePath and the
+// file status fields from the extended headers; hunkBlocks are the @@ blocks.
+func parseFileSection(section string) (meta Hunk, hunkBlocks []string, err error) {
 	scanner := bufio.NewScanner(strings.NewReader(section))
 	var (
 		pathA, pathB string
@@ -85,19 +97,32 @@ func parseFileSection(section string) (filePath string, hunkBlocks []string, err
 	)
 	for scanner.Scan() {
 		line := scanner.Text()
+		if !inHunk && parseExtendedHeader(line, &meta) {
+			continue
+		}
 		if strings.HasPrefix(line, "diff --git ") {
 			pathA, pathB = parseDiffGitLine(line)
 			continue
 		}
+		// Without a diff --git line (plain diff -u), /dev/null is the only
+		// sign of an added or deleted file.
 		if strings.HasPrefix(line, "--- ") {
-			if pathB == "" && pathA == "" {
-				pathA = parsePathLine(line, "--- ")
+			if p := parsePathLine(line, "--- "); p == devNull {
+				if meta.Status == "" {
+					meta.Status = StatusAdded
+				}
+			} else if pathB == "" && pathA == "" {
+				pathA = p
 			}
 			continue
 		}
 		if strings.HasPrefix(line, "+++ ") {
-			if pathB == "" {
-				pathB = parsePathLine(line, "+++ ")
+			if p := parsePathLine(line, "+++ "); p == devNull {
+				if meta.Status == "" {
+					meta.Status = StatusDeleted
+				}
+			} else if pathB == "" {
+				pathB = p
 			}
 			continue
 		}
@@ -114,16 +139,67 @@ func parseFileSection(section string) (filePath string, hunkBlocks []string, err
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		return "", nil, err
+		return Hunk{}, nil, err
 	}
 	if inHunk && len(currentLines) > 0 {
 		hunkBlocks = append(hunkBlocks, strings.Join(currentLines, "\n"))
 	}
-	filePath = pathB
-	if filePath == "" {
-		filePath = pathA
+	// rename/copy "to" lines hold the exact new path (the diff --git line is
+	// ambiguous when paths contain spaces).
+	if meta.FilePath == "" {
+		meta.FilePath = pathB
+		if meta.FilePath == "" {
+			meta.FilePath = pathA
+		}
+	}
+	if meta.Status == "" {
+		meta.Status = StatusModified
 	}
-	return filePath, hunkBlocks, nil
+	return meta, hunkBlocks, nil
+}
+
+// parseExtendedHeader applies one git extended header line (rename/copy,
+// similarity, file modes) to meta. Reports whether line was such a header.
+func parseExtendedHeader(line string, meta *Hunk) bool {
+	switch {
+	case strings.HasPrefix(line, "new file mode "):
+		meta.Status = StatusAdded
+		meta.NewMode = strings.TrimPrefix(line, "new file mode ")
+	case strings.HasPrefix(line, "deleted file mode "):
+		meta.Status = StatusDeleted
+		meta.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+	case strings.HasPrefix(line, "old mode "):
+		meta.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		meta.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "similarity index "):
+		meta.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+	case strings.HasPrefix(line, "rename from "):
+		meta.Status = StatusRenamed
+		meta.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		meta.Status = StatusRenamed
+		meta.FilePath = unquotePath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		meta.Status = StatusCopied
+		meta.OldPath = unquotePath(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		meta.Status = StatusCopied
+		meta.FilePath = unquotePath(strings.TrimPrefix(line, "copy to "))
+	default:
+		return false
+	}
+	return true
+}
+
+// unquotePath undoes git's C-style quoting of paths with special characters.
+func unquotePath(s string) string {
+	if len(s) >= 2 && s[0] == '"' {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
 }
 
 func parseDiffGitLine(line string) (a, b string) {
@@ -248,3 +248,93 @@ func TestParseUnifiedDiff_newFile(t *testing.T) {
 		t.Errorf("FilePath = %q, want newfile.go", got[0].FilePath)
 	}
 }
+
+func TestParseUnifiedDiff_fileStatus(t *testing.T) {
+	t.Parallel()
+	diff := `diff --git a/old/util.go b/new/util.go
+similarity index 92%
+rename from old/util.go
+rename to new/util.go
+index abc..def 100644
+--- a/old/util.go
++++ b/new/util.go
+@@ -3,1 +3,1 @@
+-x
++y
+diff --git a/moved.go b/elsewhere.go
+similarity index 100%
+rename from moved.go
+rename to elsewhere.go
+diff --git a/base.go b/base_copy.go
+similarity index 80%
+copy from base.go
+copy to base_copy.go
+--- a/base.go
++++ b/base_copy.go
+@@ -1,1 +1,1 @@
+-a
++b
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+--- a/gone.go
++++ /dev/null
+@@ -1,1 +0,0 @@
+-bye
+diff --git a/new.sh b/new.sh
+new file mode 100755
+--- /dev/null
++++ b/new.sh
+@@ -0,0 +1,1 @@
++echo hi
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+--- a/run.sh
++++ b/run.sh
+@@ -1,1 +1,1 @@
+-echo a
++echo b
+diff --git a/modeonly.sh b/modeonly.sh
+old mode 100644
+new mode 100755
+diff --git "a/with space.go" "b/with space2.go"
+similarity index 95%
+rename from "with space.go"
+rename to "with space2.go"
+--- "a/with space.go"
++++ "b/with space2.go"
+@@ -1,1 +1,1 @@
+-p
++q
+`
+	got, err := ParseUnifiedDiff(diff)
+	if err != nil {
+		t.Fatalf("ParseUnifiedDiff: %v", err)
+	}
+	want := []struct {
+		path, oldPath    string
+		status           FileStatus
+		similarity       int
+		oldMode, newMode string
+		note             string
+	}{
+		{"new/util.go", "old/util.go", StatusRenamed, 92, "", "", "renamed from old/util.go, 92% similar"},
+		{"base_copy.go", "base.go", StatusCopied, 80, "", "", "copied from base.go, 80% similar"},
+		{"gone.go", "", StatusDeleted, 0, "100644", "", "deleted file"},
+		{"new.sh", "", StatusAdded, 0, "", "100755", "new file"},
+		{"run.sh", "", StatusModified, 0, "100644", "100755", "mode 100644 -> 100755"},
+		{"with space2.go", "with space.go", StatusRenamed, 95, "", "", "renamed from with space.go, 95% similar"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(hunks) = %d, want %d (pure rename and mode-only change produce no hunks): %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		h := got[i]
+		if h.FilePath != w.path || h.OldPath != w.oldPath || h.Status != w.status || h.Similarity != w.similarity || h.OldMode != w.oldMode || h.NewMode != w.newMode {
+			t.Errorf("hunk %d = %+v, want %+v", i, h, w)
+		}
+		if note := h.StatusNote(); note != w.note {
+			t.Errorf("hunk %d StatusNote = %q, want %q", i, note, w.note)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"strings"
+)
+
+// ParsePatch parses a patch file and returns its hunks. Besides git diff
+// output it accepts git format-patch mail (headers, commit message, diffstat
+// and "-- " signatures around the diff are ignored, also for a series of
+// several mails) and plain diff -u output without "diff --git" lines, where
+// each ---/+++ pair starts a file. Lines outside hunks are dropped using the
+// @@ header counts, so trailing mail text is never read as hunk content.
+func ParsePatch(patch string) ([]Hunk, error) {
+	if strings.TrimSpace(patch) == "" {
+		return nil, nil
+	}
+	lines := strings.Split(strings.ReplaceAll(patch, "\r\n", "\n"), "\n")
+	var (
+		kept             []string
+		sectionStarts    []int // indexes into kept of plain ---/+++ file headers
+		gitHeaders       bool
+		oldLeft, newLeft int
+	)
+	for i, line := range lines {
+		if oldLeft > 0 || newLeft > 0 {
+			if consumeHunkLine(line, &oldLeft, &newLeft) {
+				kept = append(kept, line)
+				continue
+			}
+			// Truncated hunk; the rest is outside it.
+			oldLeft, newLeft = 0, 0
+		}
+		if ph, ok := parsePlanHunk(line); ok {
+			oldLeft, newLeft = ph.oldCount, ph.newCount
+			kept = append(kept, line)
+			continue
+		}
+		if strings.HasPrefix(line, "diff --git ") {
+			gitHeaders = true
+		}
+		if strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ") {
+			sectionStarts = append(sectionStarts, len(kept))
+			kept = append(kept, line)
+			continue
+		}
+		// Anything else that parses as hunk content (the "-- " signature,
+		// blank lines between mails) is not part of the diff.
+		if (line == "" || line[0] == ' ' || line[0] == '-' || line[0] == '+') && !strings.HasPrefix(line, "+++ ") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if gitHeaders {
+		return ParseUnifiedDiff(strings.Join(kept, "\n"))
+	}
+	sections := make([]string, 0, len(sectionStarts))
+	for i, start := range sectionStarts {
+		end := len(kept)
+		if i+1 < len(sectionStarts) {
+			end = sectionStarts[i+1]
+		}
+		sections = append(sections, strings.Join(kept[start:end], "\n"))
+	}
+	return parseSections(sections)
+}
+
+// consumeHunkLine counts line against the lines left in the current hunk and
+// reports whether it is a hunk body line.
+func consumeHunkLine(line string, oldLeft, newLeft *int) bool {
+	switch {
+	case line == "" || line[0] == ' ':
+		*oldLeft--
+		*newLeft--
+	case line[0] == '-':
+		*oldLeft--
+	case line[0] == '+':
+		*newLeft--
+	case line[0] == '\\':
+		// "\ No newline at end of file"
+	default:
+		return false
+	}
+	return true
+}
+
+// PatchSubject returns the Subject header of a format-patch mail without its
+// "[PATCH ...]" tag, or "" when patch is not a mail. Folded header lines are
+// joined.
+func PatchSubject(patch string) string {
+	lines := strings.Split(strings.ReplaceAll(patch, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		// The mail headers end at the first blank line.
+		if line == "" || strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "--- ") {
+			return ""
+		}
+		if !strings.HasPrefix(line, "Subject: ") {
+			continue
+		}
+		subject := strings.TrimPrefix(line, "Subject: ")
+		for _, next := range lines[i+1:] {
+			if next == "" || (next[0] != ' ' && next[0] != '\t') {
+				break
+			}
+			subject += " " + strings.TrimSpace(next)
+		}
+		subject = strings.TrimSpace(subject)
+		if strings.HasPrefix(subject, "[") {
+			if end := strings.Index(subject, "]"); end >= 0 {
+				subject = strings.TrimSpace(subject[end+1:])
+			}
+		}
+		return subject
+	}
+	return ""
+}
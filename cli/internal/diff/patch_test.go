@@ -0,0 +1,129 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+const formatPatchSeries = `From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001
+From: Dev <dev@example.com>
+Date: Sat, 1 Jun 2024 10:00:00 +0200
+Subject: [PATCH 1/2] Handle empty input in
+ parser
+
+Returns early instead of indexing into an empty slice.
+---
+ foo.go | 3 +++
+ 1 file changed, 3 insertions(+)
+
+diff --git a/foo.go b/foo.go
+index abc123..def456 100644
+--- a/foo.go
++++ b/foo.go
+@@ -1,2 +1,5 @@
+ func parse(s []string) {
++	if len(s) == 0 {
++		return
++	}
+ 	use(s[0])
+` + "-- " + `
+2.43.0
+
+
+From 2222222222222222222222222222222222222222 Mon Sep 17 00:00:00 2001
+From: Dev <dev@example.com>
+Subject: [PATCH 2/2] Drop bullet
+
+---
+diff --git a/notes.md b/notes.md
+index 111111..222222 100644
+--- a/notes.md
++++ b/notes.md
+@@ -1,2 +1 @@
+ # Notes
+` + "-- \n-- " + `
+2.43.0
+`
+
+func TestParsePatch_formatPatchSeries(t *testing.T) {
+	t.Parallel()
+	got, err := ParsePatch(formatPatchSeries)
+	if err != nil {
+		t.Fatalf("ParsePatch: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(hunks) = %d, want 2", len(got))
+	}
+	if got[0].FilePath != "foo.go" || got[1].FilePath != "notes.md" {
+		t.Errorf("paths = %q, %q; want foo.go, notes.md", got[0].FilePath, got[1].FilePath)
+	}
+	if strings.Contains(got[0].RawContent, "2.43.0") || strings.HasSuffix(got[0].RawContent, "-- ") {
+		t.Errorf("signature leaked into hunk: %q", got[0].RawContent)
+	}
+	// The removed "- " line looks like a signature but is counted by the @@ header.
+	wantNotes := "@@ -1,2 +1 @@\n # Notes\n-- "
+	if got[1].RawContent != wantNotes {
+		t.Errorf("notes hunk = %q, want %q", got[1].RawContent, wantNotes)
+	}
+}
+
+func TestParsePatch_plainDiffU(t *testing.T) {
+	t.Parallel()
+	patch := `Only in old: stale.txt
+diff -u old/a.c new/a.c
+--- old/a.c	2024-06-01 10:00:00.000000000 +0200
++++ new/a.c	2024-06-01 10:05:00.000000000 +0200
+@@ -1 +1 @@
+-int a;
++long a;
+--- /dev/null	1970-01-01 00:00:00.000000000 +0000
++++ new/b.c	2024-06-01 10:05:00.000000000 +0200
+@@ -0,0 +1 @@
++int b;
+--- old/c.c	2024-06-01 10:00:00.000000000 +0200
++++ /dev/null	1970-01-01 00:00:00.000000000 +0000
+@@ -1 +0,0 @@
+-int c;
+`
+	got, err := ParsePatch(patch)
+	if err != nil {
+		t.Fatalf("ParsePatch: %v", err)
+	}
+	want := []struct {
+		path   string
+		status FileStatus
+	}{
+		{"new/a.c", StatusModified},
+		{"new/b.c", StatusAdded},
+		{"old/c.c", StatusDeleted},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(hunks) = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].FilePath != w.path || got[i].Status != w.status {
+			t.Errorf("hunk %d = %q %s, want %q %s", i, got[i].FilePath, got[i].Status, w.path, w.status)
+		}
+	}
+}
+
+func TestPatchSubject(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"format-patch with folded subject", formatPatchSeries, "Handle empty input in parser"},
+		{"git diff", "diff --git a/x b/x\n--- a/x\n+++ b/x\n", ""},
+		{"plain diff", "--- a\n+++ b\n@@ -1 +1 @@\n-x\n+y\n", ""},
+		{"no tag", "From: Dev <dev@example.com>\nSubject: Fix it\n\nbody\n", "Fix it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PatchSubject(tt.patch); got != tt.want {
+				t.Errorf("PatchSubject = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
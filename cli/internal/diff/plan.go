@@ -0,0 +1,303 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PlanOptions configures Plan. Zero values disable the respective step.
+type PlanOptions struct {
+	// MaxLines splits hunks with more body lines (context, added and removed)
+	// than this, preferring declaration boundaries. It also caps the size of a
+	// merged hunk. 0 disables splitting; merged hunks are then capped at
+	// defaultMergeMaxLines.
+	MaxLines int
+	// MergeGap coalesces hunks of the same file separated by at most this many
+	// unchanged lines into one hunk; the gap is filled from the HEAD-side file.
+	MergeGap int
+}
+
+const (
+	// defaultMergeMaxLines caps a merged hunk when splitting is disabled.
+	defaultMergeMaxLines = 200
+	// splitOverlapLines is the number of trailing context lines of a piece
+	// repeated at the start of the next piece so the model sees the seam.
+	splitOverlapLines = 3
+)
+
+// planHeaderRegex captures old start/count, new start/count and the trailing
+// section heading of a hunk header.
+var planHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@(.*)$`)
+
+// declPrefixes mark the start of a top-level declaration in common languages;
+// Plan prefers to split a hunk just before such a line.
+var declPrefixes = []string{
+	"func ", "type ", "var ", "const ", "import ",
+	"def ", "async def ", "class ", "@",
+	"function ", "async function ", "export ", "interface ", "enum ",
+	"fn ", "pub ", "impl ", "struct ", "trait ", "mod ",
+	"public ", "private ", "protected ", "static ", "module ",
+}
+
+// planHunk is a parsed hunk: header numbers plus body lines (with prefixes).
+type planHunk struct {
+	oldStart, oldCount int
+	newStart, newCount int
+	section            string
+	body               []string
+}
+
+// Plan shapes hunks into review units before they are sent to the model:
+// nearby small hunks of the same file are merged into one hunk (gap lines are
+// read from headRef and added as context), then hunks longer than
+// opts.MaxLines are split into pieces. Every resulting hunk has a recomputed,
+// valid @@ header, so line ranges derived from it (finding filtering,
+// auto-dismiss, cursor URIs) stay correct. Hunks that cannot be parsed, or
+// whose file cannot be read for a merge, are passed through unchanged.
+//
+// Plan is meant to run after scope partitioning: hunk IDs are computed on the
+// raw git hunks, and the planned hunks only change how they are prompted.
+func Plan(ctx context.Context, repoRoot, headRef string, hunks []Hunk, opts PlanOptions) []Hunk {
+	readFile := func(path string) ([]string, bool) {
+		cmd := exec.CommandContext(ctx, "git", "show", headRef+":"+path)
+		cmd.Dir = repoRoot
+		cmd.Env = minimalEnvForRepo(repoRoot)
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, false
+		}
+		return strings.Split(strings.TrimSuffix(string(out), "\n"), "\n"), true
+	}
+	return plan(hunks, opts, readFile)
+}
+
+// plan implements Plan with an injectable HEAD-side file reader.
+func plan(hunks []Hunk, opts PlanOptions, readFile func(path string) ([]string, bool)) []Hunk {
+	if len(hunks) == 0 || (opts.MaxLines <= 0 && opts.MergeGap <= 0) {
+		return hunks
+	}
+	merged := hunks
+	if opts.MergeGap > 0 {
+		maxMerged := opts.MaxLines
+		if maxMerged <= 0 {
+			maxMerged = defaultMergeMaxLines
+		}
+		merged = mergeHunks(hunks, opts.MergeGap, maxMerged, readFile)
+	}
+	if opts.MaxLines <= 0 {
+		return merged
+	}
+	out := make([]Hunk, 0, len(merged))
+	for _, h := range merged {
+		out = append(out, splitHunk(h, opts.MaxLines)...)
+	}
+	return out
+}
+
+// mergeHunks coalesces consecutive hunks of the same file whose HEAD-side gap
+// is at most maxGap lines and whose combined body fits in maxLines.
+func mergeHunks(hunks []Hunk, maxGap, maxLines int, readFile func(path string) ([]string, bool)) []Hunk {
+	files := make(map[string][]string)
+	unreadable := make(map[string]bool)
+	out := make([]Hunk, 0, len(hunks))
+	var acc *planHunk
+	for _, h := range hunks {
+		ph, ok := parsePlanHunk(h.RawContent)
+		if acc != nil && ok && out[len(out)-1].FilePath == h.FilePath {
+			gap := ph.newStart - (acc.newStart + acc.newCount)
+			oldGap := ph.oldStart - (acc.oldStart + acc.oldCount)
+			fits := len(acc.body)+gap+len(ph.body) <= maxLines
+			nonEmpty := acc.oldCount > 0 && acc.newCount > 0 && ph.oldCount > 0 && ph.newCount > 0
+			if gap >= 0 && gap <= maxGap && gap == oldGap && fits && nonEmpty && !unreadable[h.FilePath] {
+				lines, cached := files[h.FilePath]
+				if !cached && gap > 0 {
+					var read bool
+					if lines, read = readFile(h.FilePath); read {
+						files[h.FilePath] = lines
+					} else {
+						unreadable[h.FilePath] = true
+					}
+				}
+				if gapStart := acc.newStart + acc.newCount; gap == 0 || (!unreadable[h.FilePath] && gapStart-1+gap <= len(lines)) {
+					for i := 0; i < gap; i++ {
+						acc.body = append(acc.body, " "+lines[gapStart-1+i])
+					}
+					acc.body = append(acc.body, ph.body...)
+					acc.oldCount += gap + ph.oldCount
+					acc.newCount += gap + ph.newCount
+					out[len(out)-1] = withBody(out[len(out)-1], acc)
+					continue
+				}
+			}
+		}
+		out = append(out, h)
+		acc = nil
+		if ok {
+			p := ph
+			acc = &p
+		}
+	}
+	return out
+}
+
+// splitHunk splits h into pieces of at most maxLines body lines (plus overlap
+// context). Cuts prefer the last declaration start in the second half of a
+// window; pieces without changes are folded into their neighbour.
+func splitHunk(h Hunk, maxLines int) []Hunk {
+	ph, ok := parsePlanHunk(h.RawContent)
+	if !ok || len(ph.body) <= maxLines {
+		return []Hunk{h}
+	}
+	// old/new line number at each body index, before consuming that line.
+	oldAt := make([]int, len(ph.body)+1)
+	newAt := make([]int, len(ph.body)+1)
+	oldAt[0], newAt[0] = ph.oldStart, ph.newStart
+	for i, l := range ph.body {
+		oldAt[i+1], newAt[i+1] = oldAt[i], newAt[i]
+		if !strings.HasPrefix(l, "+") {
+			oldAt[i+1]++
+		}
+		if !strings.HasPrefix(l, "-") {
+			newAt[i+1]++
+		}
+	}
+	var cuts []int
+	last := 0
+	for start := 0; len(ph.body)-start > maxLines; {
+		cut := start + maxLines
+		for i := start + maxLines; i > start+maxLines/2; i-- {
+			if isDeclStart(ph.body, i) {
+				cut = i
+				break
+			}
+		}
+		if hasChange(ph.body[last:cut]) && hasChange(ph.body[cut:]) {
+			cuts = append(cuts, cut)
+			last = cut
+		}
+		start = cut
+	}
+	if len(cuts) == 0 {
+		return []Hunk{h}
+	}
+	bounds := append(append([]int{0}, cuts...), len(ph.body))
+	out := make([]Hunk, 0, len(bounds)-1)
+	for k := 0; k+1 < len(bounds); k++ {
+		from, to := bounds[k], bounds[k+1]
+		if k > 0 {
+			// Repeat trailing context of the previous piece; only context lines,
+			// so no changed line is reviewed twice.
+			for n := 0; n < splitOverlapLines && from > bounds[k-1] && isContext(ph.body[from-1]); n++ {
+				from--
+			}
+		}
+		piece := planHunk{
+			oldStart: oldAt[from],
+			newStart: newAt[from],
+			oldCount: oldAt[to] - oldAt[from],
+			newCount: newAt[to] - newAt[from],
+			section:  ph.section,
+			body:     ph.body[from:to],
+		}
+		if k > 0 {
+			piece.section = sectionAt(ph, from)
+		}
+		out = append(out, withBody(h, &piece))
+	}
+	return out
+}
+
+// sectionAt returns the hunk header heading for a piece starting at body
+// index i: the last declaration line before i, or the original heading.
+func sectionAt(ph planHunk, i int) string {
+	for j := i - 1; j >= 0; j-- {
+		if isDeclStart(ph.body, j) && !strings.HasPrefix(ph.body[j], "-") {
+			return " " + strings.TrimSpace(ph.body[j][1:])
+		}
+	}
+	return ph.section
+}
+
+// isDeclStart reports whether body[i] (not removed) starts an unindented
+// declaration: a known keyword prefix, or any unindented line after a blank one.
+func isDeclStart(body []string, i int) bool {
+	if i <= 0 || i >= len(body) || strings.HasPrefix(body[i], "-") || len(body[i]) < 2 {
+		return false
+	}
+	text := body[i][1:]
+	switch text[0] {
+	case ' ', '\t', '}', ')', ']', '/', '#', '*':
+		return false
+	}
+	for _, p := range declPrefixes {
+		if strings.HasPrefix(text, p) {
+			return true
+		}
+	}
+	prev := body[i-1]
+	return len(prev) > 0 && strings.TrimSpace(prev[1:]) == ""
+}
+
+func isContext(line string) bool {
+	return line == "" || line[0] == ' '
+}
+
+func hasChange(body []string) bool {
+	for _, l := range body {
+		if !isContext(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePlanHunk parses a hunk's header and body. ok is false when the first
+// line is not a valid @@ header.
+func parsePlanHunk(raw string) (planHunk, bool) {
+	lines := strings.Split(raw, "\n")
+	m := planHeaderRegex.FindStringSubmatch(lines[0])
+	if m == nil {
+		return planHunk{}, false
+	}
+	ph := planHunk{section: m[5], body: lines[1:]}
+	ph.oldStart, _ = strconv.Atoi(m[1])
+	ph.oldCount = 1
+	if m[2] != "" {
+		ph.oldCount, _ = strconv.Atoi(m[2])
+	}
+	ph.newStart, _ = strconv.Atoi(m[3])
+	ph.newCount = 1
+	if m[4] != "" {
+		ph.newCount, _ = strconv.Atoi(m[4])
+	}
+	// An empty side's start is the line before the hunk; use the next line so
+	// starts always mean "first line of the hunk" (withBody undoes this).
+	if ph.oldCount == 0 {
+		ph.oldStart++
+	}
+	if ph.newCount == 0 {
+		ph.newStart++
+	}
+	return ph, true
+}
+
+// withBody returns h with RawContent and Context rebuilt from ph; file
+// metadata is kept.
+func withBody(h Hunk, ph *planHunk) Hunk {
+	oldStart, newStart := ph.oldStart, ph.newStart
+	// git reports the line before the hunk as start when a side is empty.
+	if ph.oldCount == 0 && oldStart > 0 {
+		oldStart--
+	}
+	if ph.newCount == 0 && newStart > 0 {
+		newStart--
+	}
+	header := fmt.Sprintf("@@ -%d,%d +%d,%d @@%s", oldStart, ph.oldCount, newStart, ph.newCount, ph.section)
+	h.RawContent = header + "\n" + strings.Join(ph.body, "\n")
+	h.Context = h.RawContent
+	return h
+}
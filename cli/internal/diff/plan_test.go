@@ -0,0 +1,139 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// checkHeaderCounts fails when h's @@ counts do not match its body lines.
+func checkHeaderCounts(t *testing.T, h Hunk) {
+	t.Helper()
+	ph, ok := parsePlanHunk(h.RawContent)
+	if !ok {
+		t.Fatalf("invalid header: %q", strings.SplitN(h.RawContent, "\n", 2)[0])
+	}
+	var oldN, newN int
+	for _, l := range ph.body {
+		if !strings.HasPrefix(l, "+") {
+			oldN++
+		}
+		if !strings.HasPrefix(l, "-") {
+			newN++
+		}
+	}
+	if oldN != ph.oldCount || newN != ph.newCount {
+		t.Errorf("header counts -%d +%d, body has -%d +%d", ph.oldCount, ph.newCount, oldN, newN)
+	}
+	if h.Context != h.RawContent {
+		t.Error("Context should equal RawContent")
+	}
+}
+
+func TestPlan_mergesNearbyHunks(t *testing.T) {
+	t.Parallel()
+	file := make([]string, 20)
+	for i := range file {
+		file[i] = fmt.Sprintf("line%d", i+1)
+	}
+	read := func(path string) ([]string, bool) { return file, path == "a.go" }
+	h1 := Hunk{FilePath: "a.go", Status: StatusRenamed, OldPath: "old.go", RawContent: "@@ -2,3 +2,3 @@ func A()\n line2\n-x\n+line3\n line4"}
+	h2 := Hunk{FilePath: "a.go", RawContent: "@@ -7,2 +7,3 @@\n line7\n+line8\n line9"}
+	h3 := Hunk{FilePath: "b.go", RawContent: "@@ -10,1 +10,2 @@\n line10\n+y"}
+
+	got := plan([]Hunk{h1, h2, h3}, PlanOptions{MergeGap: 2}, read)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2 (a.go merged, b.go kept)", len(got))
+	}
+	want := "@@ -2,7 +2,8 @@ func A()\n line2\n-x\n+line3\n line4\n line5\n line6\n line7\n+line8\n line9"
+	if got[0].RawContent != want {
+		t.Errorf("merged RawContent:\n%s\nwant:\n%s", got[0].RawContent, want)
+	}
+	if got[0].Status != StatusRenamed || got[0].OldPath != "old.go" {
+		t.Errorf("file metadata lost: %+v", got[0])
+	}
+	checkHeaderCounts(t, got[0])
+	if got[1].RawContent != h3.RawContent {
+		t.Errorf("other file changed: %q", got[1].RawContent)
+	}
+
+	// Gap of 2 exceeds MergeGap 1; unreadable file blocks merging too.
+	if got := plan([]Hunk{h1, h2}, PlanOptions{MergeGap: 1}, read); len(got) != 2 {
+		t.Errorf("MergeGap 1: len = %d, want 2", len(got))
+	}
+	noRead := func(string) ([]string, bool) { return nil, false }
+	if got := plan([]Hunk{h1, h2}, PlanOptions{MergeGap: 5}, noRead); len(got) != 2 {
+		t.Errorf("unreadable file: len = %d, want 2", len(got))
+	}
+	// Merged size would exceed MaxLines.
+	if got := plan([]Hunk{h1, h2}, PlanOptions{MergeGap: 5, MaxLines: 6}, read); len(got) != 2 {
+		t.Errorf("MaxLines 6: len = %d, want 2", len(got))
+	}
+}
+
+func TestPlan_splitsLargeHunkAtDeclaration(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	b.WriteString("@@ -10,12 +10,16 @@ package x")
+	body := []string{
+		" func A() {",
+		"+	a1()",
+		"+	a2()",
+		" }",
+		" ",
+		"+func B() {",
+		"+	b1()",
+		"+}",
+		" ",
+		" func C() {",
+		"-	old()",
+		"+	c1()",
+		" }",
+		" ",
+	}
+	for _, l := range body {
+		b.WriteString("\n" + l)
+	}
+	h := Hunk{FilePath: "x.go", RawContent: b.String()}
+
+	got := plan([]Hunk{h}, PlanOptions{MaxLines: 9}, nil)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2: %+v", len(got), got)
+	}
+	for _, p := range got {
+		checkHeaderCounts(t, p)
+	}
+	if first := strings.SplitN(got[0].RawContent, "\n", 2)[0]; first != "@@ -10,4 +10,9 @@ package x" {
+		t.Errorf("first header = %q", first)
+	}
+	// Second piece starts at "func C()" (new line 19) after one overlapping blank context line.
+	wantSecond := "@@ -13,5 +18,5 @@ func B() {\n \n func C() {\n-	old()\n+	c1()\n }\n "
+	if got[1].RawContent != wantSecond {
+		t.Errorf("second piece:\n%q\nwant:\n%q", got[1].RawContent, wantSecond)
+	}
+}
+
+func TestPlan_splitsWithoutBoundaryAndKeepsSmallHunks(t *testing.T) {
+	t.Parallel()
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("+	x%d()", i))
+	}
+	h := Hunk{FilePath: "y.go", RawContent: "@@ -0,0 +1,30 @@\n" + strings.Join(lines, "\n")}
+	got := plan([]Hunk{h}, PlanOptions{MaxLines: 10}, nil)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	for i, p := range got {
+		checkHeaderCounts(t, p)
+		want := fmt.Sprintf("@@ -0,0 +%d,10 @@", 1+10*i)
+		if !strings.HasPrefix(p.RawContent, want) {
+			t.Errorf("piece %d header = %q, want %q", i, strings.SplitN(p.RawContent, "\n", 2)[0], want)
+		}
+	}
+
+	small := Hunk{FilePath: "z.go", RawContent: "@@ -1,2 +1,2 @@\n-a\n+b\n c"}
+	if got := plan([]Hunk{small}, PlanOptions{MaxLines: 10, MergeGap: 3}, nil); len(got) != 1 || got[0].RawContent != small.RawContent {
+		t.Errorf("small hunk changed: %+v", got)
+	}
+}
@@ -24,10 +24,27 @@ var hunkHeaderRegex = regexp.MustCompile(`@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@
 
 // HunkLineRange parses the hunk header from RawContent and returns the 1-based
 // line range in the new file: start and end (inclusive). ok is false if the
-// header cannot be parsed.
+// header cannot be parsed. For a batched hunk (several @@ blocks, see
+// diff.BatchByFile) the range spans from the first block's start to the last
+// block's end.
 func HunkLineRange(hunk diff.Hunk) (start, end int, ok bool) {
 	firstLine := strings.SplitN(hunk.RawContent, "\n", 2)[0]
-	matches := hunkHeaderRegex.FindStringSubmatch(firstLine)
+	start, end, ok = headerLineRange(firstLine)
+	if !ok {
+		return 0, 0, false
+	}
+	if i := strings.LastIndex(hunk.RawContent, "\n@@"); i >= 0 {
+		lastLine := strings.SplitN(hunk.RawContent[i+1:], "\n", 2)[0]
+		if _, lastEnd, lastOK := headerLineRange(lastLine); lastOK && lastEnd > end {
+			end = lastEnd
+		}
+	}
+	return start, end, true
+}
+
+// headerLineRange returns the new-file line range of one @@ header line.
+func headerLineRange(line string) (start, end int, ok bool) {
+	matches := hunkHeaderRegex.FindStringSubmatch(line)
 	if matches == nil {
 		return 0, 0, false
 	}
@@ -25,6 +25,7 @@ func TestHunkLineRange(t *testing.T) {
 		{"empty", "", 0, 0, false},
 		{"no_header", "just some code", 0, 0, false},
 		{"malformed", "@@ -1 +x @@", 0, 0, false},
+		{"batched", "@@ -1,3 +5,4 @@\n context\n+added\n@@ -20,2 +30,3 @@\n x\n+y", 5, 32, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,16 +9,23 @@ package findings
 // is not modified. Order of kept findings is preserved.
 // Callers typically use findings.ResolveStrictness(cfg.Strictness) to obtain
 // minKeep and minMaint; default thresholds are 0.8 and 0.9.
+// The thresholds are set for the model's own confidence, so a calibrated
+// finding (RawConfidence set) is judged by RawConfidence: calibration changes
+// the reported confidence and rank, not which findings are kept.
 func FilterAbstention(list []Finding, minKeep, minMaint float64) []Finding {
 	if len(list) == 0 {
 		return nil
 	}
 	out := make([]Finding, 0, len(list))
 	for _, f := range list {
-		if f.Confidence < minKeep {
+		conf := f.Confidence
+		if f.RawConfidence > 0 {
+			conf = f.RawConfidence
+		}
+		if conf < minKeep {
 			continue
 		}
-		if f.Category == CategoryMaintainability && f.Confidence < minMaint {
+		if f.Category == CategoryMaintainability && conf < minMaint {
 			continue
 		}
 		out = append(out, f)
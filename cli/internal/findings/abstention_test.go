@@ -75,6 +75,18 @@ func TestFilterAbstention(t *testing.T) {
 				{File: "x.go", Line: 4, Severity: SeverityInfo, Category: CategoryMaintainability, Confidence: 0.95, Message: "keep maint"},
 			},
 		},
+		{
+			name: "calibrated_judged_by_raw_confidence",
+			input: []Finding{
+				{File: "c.go", Line: 1, Severity: SeverityWarning, Category: CategoryBug, Confidence: 0.4, RawConfidence: 0.9, Message: "keep calibrated"},
+				{File: "c.go", Line: 2, Severity: SeverityWarning, Category: CategoryBug, Confidence: 0.95, RawConfidence: 0.7, Message: "drop calibrated"},
+				{File: "c.go", Line: 3, Severity: SeverityInfo, Category: CategoryMaintainability, Confidence: 0.5, RawConfidence: 0.95, Message: "keep calibrated maint"},
+			},
+			output: []Finding{
+				{File: "c.go", Line: 1, Severity: SeverityWarning, Category: CategoryBug, Confidence: 0.4, RawConfidence: 0.9, Message: "keep calibrated"},
+				{File: "c.go", Line: 3, Severity: SeverityInfo, Category: CategoryMaintainability, Confidence: 0.5, RawConfidence: 0.95, Message: "keep calibrated maint"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
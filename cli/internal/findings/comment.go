@@ -0,0 +1,25 @@
+package findings
+
+import "strings"
+
+// Comment is a free-text note attached to a finding with stet comment. Comments
+// form a thread in the order they were added.
+type Comment struct {
+	Author string `json:"author,omitempty"` // git user.name of the commenter
+	Email  string `json:"email,omitempty"`  // git user.email of the commenter
+	At     string `json:"at"`               // RFC 3339 time the comment was added
+	Text   string `json:"text"`
+}
+
+// CommentText joins the texts of comments into one line ("; "-separated, inner
+// whitespace collapsed) for prompt shadows and suppression examples. Returns ""
+// when there are no comments.
+func CommentText(comments []Comment) string {
+	parts := make([]string, 0, len(comments))
+	for _, c := range comments {
+		if t := strings.Join(strings.Fields(c.Text), " "); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
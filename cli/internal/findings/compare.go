@@ -0,0 +1,176 @@
+// This file compares two finding sets (e.g. before and after stet rerun --replace)
+// for stet diff-findings.
+
+package findings
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match kinds of a Change, from most to least certain.
+const (
+	MatchID          = "id"
+	MatchFingerprint = "fingerprint"
+	MatchFuzzy       = "fuzzy"
+)
+
+// FuzzyMaxLineDistance and FuzzyMinSimilarity bound fuzzy matching: same file,
+// start lines at most this far apart, and message word similarity (Jaccard) at
+// least this high.
+const (
+	FuzzyMaxLineDistance = 5
+	FuzzyMinSimilarity   = 0.5
+)
+
+// Change is a finding present in both sets whose content differs. Fields lists
+// what changed: file, line, severity, category, message.
+type Change struct {
+	Old    Finding  `json:"old"`
+	New    Finding  `json:"new"`
+	Match  string   `json:"match"`
+	Fields []string `json:"fields"`
+}
+
+// Comparison is the result of Compare. New and Gone keep input order.
+type Comparison struct {
+	New       []Finding `json:"new"`
+	Gone      []Finding `json:"gone"`
+	Changed   []Change  `json:"changed"`
+	Unchanged int       `json:"unchanged"`
+}
+
+// Compare matches the findings of before and after: first by ID, then by
+// baseline fingerprint, then fuzzily by file, nearby line and similar message
+// (closest pairs first). Unmatched findings of after are new, of before gone.
+func Compare(before, after []Finding) Comparison {
+	matchedOld := make([]bool, len(before))
+	matchedNew := make([]bool, len(after))
+	var cmp Comparison
+	pair := func(i, j int, kind string) {
+		matchedOld[i], matchedNew[j] = true, true
+		if fields := changedFields(before[i], after[j]); len(fields) > 0 {
+			cmp.Changed = append(cmp.Changed, Change{Old: before[i], New: after[j], Match: kind, Fields: fields})
+		} else {
+			cmp.Unchanged++
+		}
+	}
+	for _, key := range []struct {
+		kind string
+		of   func(Finding) string
+	}{
+		{MatchID, func(f Finding) string { return f.ID }},
+		{MatchFingerprint, func(f Finding) string { return f.Fingerprint }},
+	} {
+		index := make(map[string]int)
+		for j, f := range after {
+			if k := key.of(f); k != "" && !matchedNew[j] {
+				if _, dup := index[k]; !dup {
+					index[k] = j
+				}
+			}
+		}
+		for i, f := range before {
+			if matchedOld[i] {
+				continue
+			}
+			if j, ok := index[key.of(f)]; ok && key.of(f) != "" && !matchedNew[j] {
+				pair(i, j, key.kind)
+			}
+		}
+	}
+	type candidate struct {
+		i, j  int
+		score float64
+	}
+	var cands []candidate
+	for i, a := range before {
+		if matchedOld[i] {
+			continue
+		}
+		for j, b := range after {
+			if matchedNew[j] || a.File != b.File {
+				continue
+			}
+			dist := math.Abs(float64(startLineOf(a) - startLineOf(b)))
+			sim := MessageSimilarity(a.Message, b.Message)
+			if dist > FuzzyMaxLineDistance || sim < FuzzyMinSimilarity {
+				continue
+			}
+			cands = append(cands, candidate{i, j, sim - dist/(10*FuzzyMaxLineDistance)})
+		}
+	}
+	sort.SliceStable(cands, func(x, y int) bool { return cands[x].score > cands[y].score })
+	for _, c := range cands {
+		if !matchedOld[c.i] && !matchedNew[c.j] {
+			pair(c.i, c.j, MatchFuzzy)
+		}
+	}
+	for j, f := range after {
+		if !matchedNew[j] {
+			cmp.New = append(cmp.New, f)
+		}
+	}
+	for i, f := range before {
+		if !matchedOld[i] {
+			cmp.Gone = append(cmp.Gone, f)
+		}
+	}
+	return cmp
+}
+
+// changedFields returns the names of the fields that differ between a and b.
+func changedFields(a, b Finding) []string {
+	var fields []string
+	if a.File != b.File {
+		fields = append(fields, "file")
+	}
+	if startLineOf(a) != startLineOf(b) {
+		fields = append(fields, "line")
+	}
+	if !strings.EqualFold(string(a.Severity), string(b.Severity)) {
+		fields = append(fields, "severity")
+	}
+	if !strings.EqualFold(string(a.Category), string(b.Category)) {
+		fields = append(fields, "category")
+	}
+	if strings.TrimSpace(a.Message) != strings.TrimSpace(b.Message) {
+		fields = append(fields, "message")
+	}
+	return fields
+}
+
+func startLineOf(f Finding) int {
+	if f.Range != nil {
+		return f.Range.Start
+	}
+	return f.Line
+}
+
+// MessageSimilarity is the Jaccard similarity of the lowercased word sets of a
+// and b (1 for two empty messages).
+func MessageSimilarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa) == 0 && len(wb) == 0 {
+		return 1
+	}
+	inter := 0
+	for w := range wa {
+		if wb[w] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(wa)+len(wb)-inter)
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,68 @@
+package findings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	before := []Finding{
+		{ID: "a", File: "x.go", Line: 10, Severity: SeverityWarning, Category: CategoryBug, Message: "Nil pointer dereference when cfg is nil."},
+		{ID: "b", File: "x.go", Line: 20, Severity: SeverityInfo, Category: CategoryStyle, Message: "Long function."},
+		{ID: "c", File: "y.go", Line: 5, Severity: SeverityWarning, Category: CategoryBug, Message: "Error is ignored.", Fingerprint: "fp-c"},
+		{ID: "d", File: "y.go", Line: 40, Severity: SeverityWarning, Category: CategorySecurity, Message: "SQL built from user input."},
+		{ID: "e", File: "z.go", Line: 1, Severity: SeverityWarning, Category: CategoryBug, Message: "Unchanged."},
+	}
+	after := []Finding{
+		{ID: "e", File: "z.go", Line: 1, Severity: SeverityWarning, Category: CategoryBug, Message: "Unchanged."},
+		{ID: "b", File: "x.go", Line: 20, Severity: SeverityNitpick, Category: CategoryStyle, Message: "Long function."},
+		{ID: "a2", File: "x.go", Line: 12, Severity: SeverityError, Category: CategoryBug, Message: "Possible nil pointer dereference when cfg is nil."},
+		{ID: "c2", File: "y.go", Line: 6, Severity: SeverityWarning, Category: CategoryBug, Message: "The returned error is dropped.", Fingerprint: "fp-c"},
+		{ID: "f", File: "y.go", Line: 41, Severity: SeverityWarning, Category: CategoryPerformance, Message: "Query runs inside a loop."},
+	}
+	cmp := Compare(before, after)
+	if cmp.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", cmp.Unchanged)
+	}
+	if len(cmp.New) != 1 || cmp.New[0].ID != "f" {
+		t.Errorf("New = %+v, want f", cmp.New)
+	}
+	if len(cmp.Gone) != 1 || cmp.Gone[0].ID != "d" {
+		t.Errorf("Gone = %+v, want d", cmp.Gone)
+	}
+	type change struct {
+		old, new, match string
+		fields          []string
+	}
+	var got []change
+	for _, c := range cmp.Changed {
+		got = append(got, change{c.Old.ID, c.New.ID, c.Match, c.Fields})
+	}
+	want := []change{
+		{"b", "b", MatchID, []string{"severity"}},
+		{"c", "c2", MatchFingerprint, []string{"line", "message"}},
+		{"a", "a2", MatchFuzzy, []string{"line", "severity", "message"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed = %+v, want %+v", got, want)
+	}
+}
+
+func TestMessageSimilarity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"Nil pointer.", "nil POINTER", 1},
+		{"a b c d", "a b", 0.5},
+		{"a", "b", 0},
+	}
+	for _, tt := range tests {
+		if got := MessageSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("MessageSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
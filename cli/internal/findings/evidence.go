@@ -18,10 +18,24 @@ package findings
 // (overlap: finding.Range.Start <= hunkEnd && finding.Range.End >= hunkStart).
 // Ranges are inclusive; touching at a single line (e.g. finding.End == hunkStart) counts as overlap.
 func FilterByHunkLines(list []Finding, filePath string, hunkStart, hunkEnd int) []Finding {
+	return FilterByHunkRanges(list, filePath, [][2]int{{hunkStart, hunkEnd}})
+}
+
+// FilterByHunkRanges is FilterByHunkLines for a prompt that covers several
+// hunks of filePath (file-level batching): a located finding is kept iff it
+// falls within at least one of ranges ([start, end], 1-based inclusive).
+// Invalid ranges are ignored; if none is valid, the list is returned unchanged.
+func FilterByHunkRanges(list []Finding, filePath string, ranges [][2]int) []Finding {
 	if len(list) == 0 {
 		return nil
 	}
-	if hunkStart <= 0 || hunkEnd < hunkStart {
+	valid := make([][2]int, 0, len(ranges))
+	for _, r := range ranges {
+		if r[0] > 0 && r[1] >= r[0] {
+			valid = append(valid, r)
+		}
+	}
+	if len(valid) == 0 {
 		return list
 	}
 	out := make([]Finding, 0, len(list))
@@ -34,17 +48,18 @@ func FilterByHunkLines(list []Finding, filePath string, hunkStart, hunkEnd int)
 			out = append(out, f)
 			continue
 		}
+		start, end := f.Line, f.Line
 		if f.Range != nil {
 			if f.Range.Start > f.Range.End {
 				continue
 			}
-			if f.Range.Start <= hunkEnd && f.Range.End >= hunkStart {
+			start, end = f.Range.Start, f.Range.End
+		}
+		for _, r := range valid {
+			if start <= r[1] && end >= r[0] {
 				out = append(out, f)
+				break
 			}
-			continue
-		}
-		if f.Line >= hunkStart && f.Line <= hunkEnd {
-			out = append(out, f)
 		}
 	}
 	return out
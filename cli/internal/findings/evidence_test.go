@@ -211,3 +211,32 @@ func TestFilterByHunkLines(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterByHunkRanges(t *testing.T) {
+	t.Parallel()
+	list := []Finding{
+		{File: evidenceTestPath, Line: 3, Message: "in first"},
+		{File: evidenceTestPath, Line: 12, Message: "in gap"},
+		{File: evidenceTestPath, Line: 21, Message: "in second"},
+		{File: evidenceTestPath, Range: &LineRange{Start: 8, End: 20}, Message: "range touches second"},
+		{File: evidenceTestPath, Message: "file-level"},
+		{File: "other.go", Line: 12, Message: "other file"},
+	}
+	got := FilterByHunkRanges(list, evidenceTestPath, [][2]int{{1, 5}, {20, 25}})
+	var msgs []string
+	for _, f := range got {
+		msgs = append(msgs, f.Message)
+	}
+	want := []string{"in first", "in second", "range touches second", "file-level", "other file"}
+	if len(msgs) != len(want) {
+		t.Fatalf("kept %v, want %v", msgs, want)
+	}
+	for i := range want {
+		if msgs[i] != want[i] {
+			t.Errorf("kept[%d] = %q, want %q", i, msgs[i], want[i])
+		}
+	}
+	if got := FilterByHunkRanges(list, evidenceTestPath, [][2]int{{0, 0}}); len(got) != len(list) {
+		t.Errorf("no valid range: kept %d, want all %d", len(got), len(list))
+	}
+}
@@ -0,0 +1,33 @@
+package findings
+
+// SetFileStatus records the diff status of file on each finding in list whose
+// File is file: status (e.g. "renamed") and, for renames and copies, the
+// baseline path oldFile. Status "" or "modified" clears both fields, so plain
+// modifications keep the JSON output unchanged. Findings for other files are
+// left untouched.
+func SetFileStatus(list []Finding, file, status, oldFile string) {
+	if status == "modified" {
+		status = ""
+	}
+	if status == "" {
+		oldFile = ""
+	}
+	for i := range list {
+		if list[i].File != file {
+			continue
+		}
+		list[i].FileStatus = status
+		list[i].OldFile = oldFile
+	}
+}
+
+// SetCommit records sha on each finding in list as the commit it was reviewed
+// in (per-commit review). sha "" leaves the findings untouched.
+func SetCommit(list []Finding, sha string) {
+	if sha == "" {
+		return
+	}
+	for i := range list {
+		list[i].Commit = sha
+	}
+}
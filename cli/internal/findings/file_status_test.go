@@ -0,0 +1,37 @@
+package findings
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSetFileStatus(t *testing.T) {
+	list := []Finding{
+		{File: "new.go", Line: 1, FileStatus: "added"},
+		{File: "other.go", Line: 2},
+	}
+	SetFileStatus(list, "new.go", "renamed", "old.go")
+	if list[0].FileStatus != "renamed" || list[0].OldFile != "old.go" {
+		t.Errorf("finding 0 = %+v, want renamed from old.go", list[0])
+	}
+	if list[1].FileStatus != "" || list[1].OldFile != "" {
+		t.Errorf("finding for other file should be untouched: %+v", list[1])
+	}
+	data, err := json.Marshal(list[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"file_status":"renamed","old_file":"old.go"`) {
+		t.Errorf("JSON = %s, want file_status and old_file", data)
+	}
+
+	SetFileStatus(list, "new.go", "modified", "ignored.go")
+	if list[0].FileStatus != "" || list[0].OldFile != "" {
+		t.Errorf("modified should clear status: %+v", list[0])
+	}
+	data, _ = json.Marshal(list[0])
+	if strings.Contains(string(data), "file_status") || strings.Contains(string(data), "old_file") {
+		t.Errorf("modified file: JSON should omit status fields; got %s", data)
+	}
+}
@@ -0,0 +1,225 @@
+// This file implements team-configured finding filters (config [filters]):
+// extra banned phrases and message regexes in the spirit of the FP kill list,
+// drop rules by category, path and message, and severity remapping.
+
+package findings
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"stet/cli/internal/erruser"
+)
+
+// FilterRules is the [filters] section of the configuration.
+type FilterRules struct {
+	// BannedPhrases are dropped like the built-in FP kill list: case-insensitive substring of the message.
+	BannedPhrases []string `toml:"banned_phrases"`
+	// BannedPatterns are Go regular expressions matched against the message.
+	BannedPatterns []string `toml:"banned_patterns"`
+	// Drop rules drop the findings that match every field they set.
+	Drop []DropRule `toml:"drop"`
+	// Remap rules set the severity of the findings that match; the first matching rule wins.
+	Remap []RemapRule `toml:"remap"`
+}
+
+// DropRule matches findings by category, file path glob and message regex.
+// Empty fields match everything; at least one must be set.
+type DropRule struct {
+	Category string `toml:"category"`
+	Path     string `toml:"path"`    // slash-separated glob; "**" matches any number of directories; no "/" matches the base name
+	Message  string `toml:"message"` // Go regular expression
+}
+
+// RemapRule sets Severity on findings matching its category, path and message
+// (same matching as DropRule; all may be empty to remap everything).
+type RemapRule struct {
+	DropRule
+	Severity Severity `toml:"severity"`
+}
+
+// RuleHit is how many findings one rule dropped or remapped.
+type RuleHit struct {
+	Rule  string
+	Count int
+}
+
+type compiledRule struct {
+	name     string
+	category Category
+	path     string
+	message  *regexp.Regexp
+	severity Severity // remap rules only
+}
+
+func (r compiledRule) matches(f Finding) bool {
+	if r.category != "" && strings.ToLower(string(f.Category)) != string(r.category) {
+		return false
+	}
+	if r.path != "" && !MatchPath(r.path, f.File) {
+		return false
+	}
+	return r.message == nil || r.message.MatchString(f.Message)
+}
+
+// Filter applies compiled FilterRules. A nil *Filter keeps every finding.
+type Filter struct {
+	drops  []compiledRule
+	remaps []compiledRule
+}
+
+// NewFilter compiles r. It returns nil when r has no rules, and a user-facing
+// error for invalid regexes, globs, categories or severities.
+func NewFilter(r FilterRules) (*Filter, error) {
+	f := &Filter{}
+	for _, p := range r.BannedPhrases {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		f.drops = append(f.drops, compiledRule{name: fmt.Sprintf("phrase %q", p), message: regexp.MustCompile("(?i)" + regexp.QuoteMeta(p))})
+	}
+	for _, p := range r.BannedPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, erruser.New(fmt.Sprintf("Invalid banned pattern %q in [filters].", p), err)
+		}
+		f.drops = append(f.drops, compiledRule{name: fmt.Sprintf("pattern /%s/", p), message: re})
+	}
+	for i, d := range r.Drop {
+		c, err := compileRule(d, fmt.Sprintf("drop[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		if c.category == "" && c.path == "" && c.message == nil {
+			return nil, erruser.New(fmt.Sprintf("Drop rule %d in [filters] needs a category, path or message.", i+1), nil)
+		}
+		f.drops = append(f.drops, c)
+	}
+	for i, m := range r.Remap {
+		sev := Severity(strings.ToLower(strings.TrimSpace(string(m.Severity))))
+		if _, ok := validSeverities[sev]; !ok {
+			return nil, erruser.New(fmt.Sprintf("Remap rule %d in [filters] has invalid severity %q; use error, warning, info or nitpick.", i+1, m.Severity), nil)
+		}
+		c, err := compileRule(m.DropRule, fmt.Sprintf("remap[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		c.severity = sev
+		c.name += " -> " + string(sev)
+		f.remaps = append(f.remaps, c)
+	}
+	if len(f.drops) == 0 && len(f.remaps) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// compileRule validates d and names it "<prefix> category=.. path=.. message=/../".
+func compileRule(d DropRule, prefix string) (compiledRule, error) {
+	c := compiledRule{name: prefix}
+	if d.Category != "" {
+		c.category = Category(strings.ToLower(strings.TrimSpace(d.Category)))
+		if _, ok := validCategories[c.category]; !ok {
+			return compiledRule{}, erruser.New(fmt.Sprintf("Invalid category %q in [filters] %s.", d.Category, prefix), nil)
+		}
+		c.name += " category=" + string(c.category)
+	}
+	if d.Path != "" {
+		c.path = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(d.Path, "\\", "/")), "/")
+		if _, err := path.Match(strings.ReplaceAll(c.path, "**", "*"), ""); err != nil {
+			return compiledRule{}, erruser.New(fmt.Sprintf("Invalid path glob %q in [filters] %s.", d.Path, prefix), err)
+		}
+		c.name += " path=" + d.Path
+	}
+	if d.Message != "" {
+		re, err := regexp.Compile(d.Message)
+		if err != nil {
+			return compiledRule{}, erruser.New(fmt.Sprintf("Invalid message pattern %q in [filters] %s.", d.Message, prefix), err)
+		}
+		c.message = re
+		c.name += " message=/" + d.Message + "/"
+	}
+	return c, nil
+}
+
+// Apply drops the findings matched by a banned phrase, banned pattern or drop
+// rule, then remaps the severity of the rest. It returns the kept findings
+// (input order preserved; list is not modified) and the rules that matched, in
+// configuration order.
+func (flt *Filter) Apply(list []Finding) ([]Finding, []RuleHit) {
+	if flt == nil || len(list) == 0 {
+		return list, nil
+	}
+	dropCounts := make([]int, len(flt.drops))
+	remapCounts := make([]int, len(flt.remaps))
+	out := make([]Finding, 0, len(list))
+	for _, f := range list {
+		dropped := false
+		for i, r := range flt.drops {
+			if r.matches(f) {
+				dropCounts[i]++
+				dropped = true
+				break
+			}
+		}
+		if dropped {
+			continue
+		}
+		for i, r := range flt.remaps {
+			if r.matches(f) {
+				remapCounts[i]++
+				f.Severity = r.severity
+				break
+			}
+		}
+		out = append(out, f)
+	}
+	var hits []RuleHit
+	for i, n := range dropCounts {
+		if n > 0 {
+			hits = append(hits, RuleHit{Rule: flt.drops[i].name, Count: n})
+		}
+	}
+	for i, n := range remapCounts {
+		if n > 0 {
+			hits = append(hits, RuleHit{Rule: flt.remaps[i].name, Count: n})
+		}
+	}
+	return out, hits
+}
+
+// MatchPath reports whether the slash-separated file path matches pattern.
+// Segments match as in path.Match, and a "**" segment matches zero or more
+// directories. A pattern without "/" is matched against the base name, as
+// for diff exclude patterns and Cursor rule globs.
+func MatchPath(pattern, file string) bool {
+	file = strings.TrimPrefix(strings.ReplaceAll(file, "\\", "/"), "./")
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			for k := 0; k <= len(segs); k++ {
+				if matchSegments(pat[1:], segs[k:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
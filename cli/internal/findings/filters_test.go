@@ -0,0 +1,99 @@
+package findings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"tests/**", "tests/a_test.go", true},
+		{"tests/**", "tests/unit/deep/a.py", true},
+		{"tests/**", "src/tests/a.py", false},
+		{"**/testdata/**", "pkg/x/testdata/in.json", true},
+		{"**/*_test.go", "a_test.go", true},
+		{"cmd/*/main.go", "cmd/stet/main.go", true},
+		{"cmd/*/main.go", "cmd/stet/sub/main.go", false},
+		{"*.pb.go", "api/v1/x.pb.go", true},
+		{"vendor/**", "vendor", true},
+	}
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	t.Parallel()
+	flt, err := NewFilter(FilterRules{
+		BannedPhrases:  []string{"consider renaming"},
+		BannedPatterns: []string{`^Magic number \d+`},
+		Drop:           []DropRule{{Category: "style", Path: "legacy/**"}},
+		Remap: []RemapRule{
+			{DropRule: DropRule{Category: "performance", Path: "tests/**"}, Severity: "info"},
+			{DropRule: DropRule{Path: "tests/**"}, Severity: "nitpick"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFilter: %v", err)
+	}
+	in := []Finding{
+		{ID: "1", File: "a.go", Severity: SeverityWarning, Category: CategoryStyle, Message: "Consider renaming x."},
+		{ID: "2", File: "a.go", Severity: SeverityWarning, Category: CategoryBug, Message: "Magic number 42 used."},
+		{ID: "3", File: "legacy/old/a.go", Severity: SeverityWarning, Category: CategoryStyle, Message: "Long line."},
+		{ID: "4", File: "legacy/a.go", Severity: SeverityError, Category: CategoryBug, Message: "Nil dereference."},
+		{ID: "5", File: "tests/a_test.go", Severity: SeverityWarning, Category: CategoryPerformance, Message: "Allocation in loop."},
+		{ID: "6", File: "tests/a_test.go", Severity: SeverityWarning, Category: CategoryBug, Message: "Wrong assertion."},
+	}
+	got, hits := flt.Apply(in)
+	var ids []string
+	for _, f := range got {
+		ids = append(ids, f.ID+":"+string(f.Severity))
+	}
+	if want := []string{"4:error", "5:info", "6:nitpick"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("kept = %v, want %v", ids, want)
+	}
+	if in[4].Severity != SeverityWarning {
+		t.Error("Apply modified its input")
+	}
+	wantHits := []RuleHit{
+		{Rule: `phrase "consider renaming"`, Count: 1},
+		{Rule: `pattern /^Magic number \d+/`, Count: 1},
+		{Rule: "drop[0] category=style path=legacy/**", Count: 1},
+		{Rule: "remap[0] category=performance path=tests/** -> info", Count: 1},
+		{Rule: "remap[1] path=tests/** -> nitpick", Count: 1},
+	}
+	if !reflect.DeepEqual(hits, wantHits) {
+		t.Errorf("hits = %+v, want %+v", hits, wantHits)
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	t.Parallel()
+	if flt, err := NewFilter(FilterRules{}); flt != nil || err != nil {
+		t.Errorf("NewFilter(empty) = %v, %v; want nil, nil", flt, err)
+	}
+	var nilFilter *Filter
+	if got, hits := nilFilter.Apply([]Finding{{ID: "1"}}); len(got) != 1 || hits != nil {
+		t.Error("nil Filter should keep every finding")
+	}
+	for name, r := range map[string]FilterRules{
+		"bad pattern":   {BannedPatterns: []string{"("}},
+		"empty drop":    {Drop: []DropRule{{}}},
+		"bad category":  {Drop: []DropRule{{Category: "nonsense"}}},
+		"bad glob":      {Drop: []DropRule{{Path: "a/[b"}}},
+		"bad message":   {Drop: []DropRule{{Message: "[a"}}},
+		"bad severity":  {Remap: []RemapRule{{Severity: "critical"}}},
+		"no severity":   {Remap: []RemapRule{{DropRule: DropRule{Category: "style"}}}},
+		"bad remap cat": {Remap: []RemapRule{{DropRule: DropRule{Category: "x"}, Severity: "info"}}},
+	} {
+		if _, err := NewFilter(r); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
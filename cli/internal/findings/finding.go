@@ -99,5 +99,29 @@ type Finding struct {
 	Suggestion    string     `json:"suggestion,omitempty"`
 	CursorURI     string     `json:"cursor_uri,omitempty"`
 	EvidenceLines EvidenceLines `json:"evidence_lines,omitempty"`
+	// FileStatus is the git status of File in the reviewed diff: added, deleted, renamed or copied
+	// (omitted for plain modifications). OldFile is the baseline path of a renamed or copied File.
+	FileStatus string `json:"file_status,omitempty"`
+	OldFile    string `json:"old_file,omitempty"`
+	// Commit is the SHA of the commit whose diff produced the finding in per-commit review
+	// (stet start --per-commit); empty when the whole range was reviewed at once.
+	Commit string `json:"commit,omitempty"`
+	// Status is the finding's lifecycle state in the session (see Status); empty means open.
+	// DeferredUntil (YYYY-MM-DD) is set while deferred; DismissReason is the reason given to
+	// stet dismiss.
+	Status        Status `json:"status,omitempty"`
+	DeferredUntil string `json:"deferred_until,omitempty"`
+	DismissReason string `json:"dismiss_reason,omitempty"`
+	// Comments is the thread of notes added with stet comment, oldest first.
+	Comments []Comment `json:"comments,omitempty"`
+	// Fingerprint identifies the finding in the committed baseline (.stet/baseline.json):
+	// file, token-level semantic hunk ID and message stem. Set by the review pipeline.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// RawConfidence is the model's own confidence when Confidence was replaced by the
+	// calibrated value (see package calibration); omitted when not calibrated.
+	RawConfidence float64 `json:"raw_confidence,omitempty"`
+	// Rank is the importance score from package rank (0-1, higher first); set only
+	// in stet list --json output, never stored in the session.
+	Rank float64 `json:"rank,omitempty"`
 }
 
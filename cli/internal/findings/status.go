@@ -0,0 +1,61 @@
+package findings
+
+import (
+	"fmt"
+	"time"
+
+	"stet/cli/internal/erruser"
+)
+
+// Status is the lifecycle state of a finding in a review session.
+type Status string
+
+const (
+	// StatusOpen is a finding nobody has acted on yet.
+	StatusOpen Status = "open"
+	// StatusAccepted is a valid finding the author intends to fix (stet accept).
+	StatusAccepted Status = "accepted"
+	// StatusFixed is a finding a later review no longer reported after its code changed.
+	StatusFixed Status = "fixed"
+	// StatusDeferred is a valid finding postponed until DeferredUntil (stet defer).
+	StatusDeferred Status = "deferred"
+	// StatusDismissed is a finding rejected with stet dismiss, optionally with a reason.
+	StatusDismissed Status = "dismissed"
+)
+
+// DeferDateLayout is the date layout of Finding.DeferredUntil and stet defer --until.
+const DeferDateLayout = "2006-01-02"
+
+// ParseDeferUntil parses a stet defer --until date (YYYY-MM-DD) and checks that it
+// is after today (the local date of now).
+func ParseDeferUntil(s string, now time.Time) (string, error) {
+	d, err := time.ParseInLocation(DeferDateLayout, s, now.Location())
+	if err != nil {
+		return "", erruser.New(fmt.Sprintf("Invalid date %q; use YYYY-MM-DD.", s), err)
+	}
+	if !d.After(now) {
+		return "", erruser.New(fmt.Sprintf("Defer date %s is not in the future.", s), nil)
+	}
+	return d.Format(DeferDateLayout), nil
+}
+
+// CurrentStatus returns the status of f at now. An empty Status is open, and a
+// deferral whose date has been reached is open again.
+func CurrentStatus(f Finding, now time.Time) Status {
+	switch f.Status {
+	case "":
+		return StatusOpen
+	case StatusDeferred:
+		if f.DeferredUntil == "" || now.Format(DeferDateLayout) >= f.DeferredUntil {
+			return StatusOpen
+		}
+	}
+	return f.Status
+}
+
+// NeedsAttention reports whether f is shown as an active finding at now: open
+// (including expired deferrals) or accepted.
+func NeedsAttention(f Finding, now time.Time) bool {
+	st := CurrentStatus(f, now)
+	return st == StatusOpen || st == StatusAccepted
+}
@@ -0,0 +1,48 @@
+package findings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCurrentStatus(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		f         Finding
+		want      Status
+		attention bool
+	}{
+		{"empty is open", Finding{}, StatusOpen, true},
+		{"accepted", Finding{Status: StatusAccepted}, StatusAccepted, true},
+		{"deferred to later", Finding{Status: StatusDeferred, DeferredUntil: "2026-03-11"}, StatusDeferred, false},
+		{"deferral reached", Finding{Status: StatusDeferred, DeferredUntil: "2026-03-10"}, StatusOpen, true},
+		{"deferred without date", Finding{Status: StatusDeferred}, StatusOpen, true},
+		{"fixed", Finding{Status: StatusFixed}, StatusFixed, false},
+		{"dismissed", Finding{Status: StatusDismissed}, StatusDismissed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CurrentStatus(tt.f, now); got != tt.want {
+				t.Errorf("CurrentStatus = %q, want %q", got, tt.want)
+			}
+			if got := NeedsAttention(tt.f, now); got != tt.attention {
+				t.Errorf("NeedsAttention = %v, want %v", got, tt.attention)
+			}
+		})
+	}
+}
+
+func TestParseDeferUntil(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	if got, err := ParseDeferUntil("2026-04-01", now); err != nil || got != "2026-04-01" {
+		t.Errorf("ParseDeferUntil(2026-04-01) = %q, %v", got, err)
+	}
+	for _, in := range []string{"2026-03-10", "2026-01-01", "next week", "2026-13-01"} {
+		if _, err := ParseDeferUntil(in, now); err == nil {
+			t.Errorf("ParseDeferUntil(%q): want error", in)
+		}
+	}
+}
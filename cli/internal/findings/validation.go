@@ -17,6 +17,12 @@ var (
 	}
 )
 
+// ValidCategory reports whether c is one of the Category constants.
+func ValidCategory(c Category) bool {
+	_, ok := validCategories[c]
+	return ok
+}
+
 // Normalize mutates the finding in place: invalid severity is set to SeverityWarning,
 // invalid category is set to CategoryBug. Call Validate after Normalize to check
 // other constraints (message, file, confidence, range). After Normalize, Validate
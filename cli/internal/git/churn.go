@@ -0,0 +1,84 @@
+// Package git (churn.go) summarizes recent commit activity per file.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"stet/cli/internal/erruser"
+)
+
+// FileActivity is the recent commit history of one file: how many commits
+// touched it, by how many distinct authors, and how many of those commits are
+// by its most frequent author.
+type FileActivity struct {
+	Commits          int
+	Authors          int
+	TopAuthorCommits int
+}
+
+// FileChurn returns the activity of each path in paths over the commits
+// reachable from ref since the given git date (e.g. "90 days ago"). Paths
+// without commits in that window are absent from the map. Empty paths returns
+// nil, nil.
+func FileChurn(repoRoot, ref, since string, paths []string) (map[string]FileActivity, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if repoRoot == "" || ref == "" {
+		return nil, erruser.New("file churn: repo root and ref required", nil)
+	}
+	args := []string{"log", "--no-color", "--no-renames", "--format=%x00%ae", "--name-only"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	args = append(args, ref, "--")
+	args = append(args, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, erruser.New("Could not read file history.", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return parseFileChurn(stdout.String()), nil
+}
+
+// parseFileChurn parses `git log --format=%x00%ae --name-only` output: each
+// commit is a NUL, the author email, then the touched paths one per line.
+func parseFileChurn(out string) map[string]FileActivity {
+	byAuthor := make(map[string]map[string]int)
+	for _, commit := range strings.Split(out, "\x00") {
+		lines := strings.Split(strings.TrimSpace(commit), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		author := strings.ToLower(strings.TrimSpace(lines[0]))
+		for _, p := range lines[1:] {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+			if byAuthor[p] == nil {
+				byAuthor[p] = make(map[string]int)
+			}
+			byAuthor[p][author]++
+		}
+	}
+	activity := make(map[string]FileActivity, len(byAuthor))
+	for p, authors := range byAuthor {
+		var a FileActivity
+		for _, n := range authors {
+			a.Commits += n
+			a.Authors++
+			if n > a.TopAuthorCommits {
+				a.TopAuthorCommits = n
+			}
+		}
+		activity[p] = a
+	}
+	return activity
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestFileChurn(t *testing.T) {
+	t.Parallel()
+	repo := initRepo(t)
+	writeFile(t, repo, "f1.txt", "a\nb\n")
+	run(t, repo, "git", "add", "f1.txt")
+	run(t, repo, "git", "-c", "user.email=other@stet.local", "commit", "-m", "c3")
+	writeFile(t, repo, "f1.txt", "a\nb\nc\n")
+	run(t, repo, "git", "add", "f1.txt")
+	run(t, repo, "git", "commit", "-m", "c4")
+
+	got, err := FileChurn(repo, "HEAD", "", []string{"f1.txt", "f2.txt", "missing.txt"})
+	if err != nil {
+		t.Fatalf("FileChurn: %v", err)
+	}
+	if want := (FileActivity{Commits: 3, Authors: 2, TopAuthorCommits: 2}); got["f1.txt"] != want {
+		t.Errorf("f1.txt = %+v, want %+v", got["f1.txt"], want)
+	}
+	if want := (FileActivity{Commits: 1, Authors: 1, TopAuthorCommits: 1}); got["f2.txt"] != want {
+		t.Errorf("f2.txt = %+v, want %+v", got["f2.txt"], want)
+	}
+	if _, ok := got["missing.txt"]; ok {
+		t.Error("missing.txt should be absent")
+	}
+	if got, err := FileChurn(repo, "HEAD", "", nil); got != nil || err != nil {
+		t.Errorf("FileChurn(no paths) = %v, %v; want nil, nil", got, err)
+	}
+	if _, err := FileChurn(repo, "nonexistent-ref", "", []string{"f1.txt"}); err == nil {
+		t.Error("FileChurn with invalid ref: want error")
+	}
+}
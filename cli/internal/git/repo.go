@@ -4,6 +4,7 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -88,6 +89,26 @@ func RevParse(repoRoot, ref string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// MergeBase returns the best common ancestor of refs a and b (git merge-base a b).
+// When b has merged a in, this is the last merged commit of a, so a diff from the
+// merge base to b contains only b's own changes (the "a...b" diff). Returns an
+// error when a or b is invalid or the histories are unrelated.
+func MergeBase(repoRoot, a, b string) (string, error) {
+	if repoRoot == "" || a == "" || b == "" {
+		return "", erruser.New("MergeBase: repo root and both refs required", nil)
+	}
+	cmd := exec.Command("git", "merge-base", a, b)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", erruser.New(fmt.Sprintf("Could not find a merge base of %q and %q.", a, b), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 // UserIntent returns the current branch name and the last commit message at HEAD.
 // Branch is from "git rev-parse --abbrev-ref HEAD" (returns "HEAD" when detached).
 // CommitMsg is from "git log -1 --format=%B HEAD". Both are trimmed.
@@ -121,6 +142,38 @@ func UserIntent(repoRoot string) (branch, commitMsg string, err error) {
 	return branch, commitMsg, nil
 }
 
+// UserIdentity returns the git user.name and user.email configured for repoRoot
+// (trimmed). Unset values are returned as "" without error.
+func UserIdentity(repoRoot string) (name, email string, err error) {
+	if repoRoot == "" {
+		return "", "", erruser.New("UserIdentity: repo root required", nil)
+	}
+	get := func(key string) (string, error) {
+		cmd := exec.Command("git", "config", "--get", key)
+		cmd.Dir = repoRoot
+		cmd.Env = minimalEnv()
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			// Exit status 1: the key is not set.
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+				return "", nil
+			}
+			return "", erruser.New("Could not read git user identity.", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+		}
+		return strings.TrimSpace(stdout.String()), nil
+	}
+	if name, err = get("user.name"); err != nil {
+		return "", "", err
+	}
+	if email, err = get("user.email"); err != nil {
+		return "", "", err
+	}
+	return name, email, nil
+}
+
 // UncommittedDiff returns the unified diff of uncommitted changes at repoRoot.
 // If stagedOnly is true, returns only staged changes (git diff --cached).
 // Otherwise returns staged plus unstaged (git diff HEAD). Uses --no-color.
@@ -145,6 +145,37 @@ func TestRevParse_invalidRef(t *testing.T) {
 	}
 }
 
+func TestMergeBase(t *testing.T) {
+	t.Parallel()
+	repo := initRepo(t)
+	fork, err := RevParse(repo, "HEAD")
+	if err != nil {
+		t.Fatalf("RevParse: %v", err)
+	}
+	run(t, repo, "git", "branch", "target")
+	writeFile(t, repo, "f3.txt", "c\n")
+	run(t, repo, "git", "add", "f3.txt")
+	run(t, repo, "git", "commit", "-m", "feature")
+	run(t, repo, "git", "checkout", "-q", "target")
+	writeFile(t, repo, "f4.txt", "d\n")
+	run(t, repo, "git", "add", "f4.txt")
+	run(t, repo, "git", "commit", "-m", "target moves on")
+	run(t, repo, "git", "checkout", "-q", "-")
+	got, err := MergeBase(repo, "target", "HEAD")
+	if err != nil {
+		t.Fatalf("MergeBase: %v", err)
+	}
+	if got != fork {
+		t.Errorf("MergeBase = %s, want fork point %s", got, fork)
+	}
+	if _, err := MergeBase(repo, "no-such-branch", "HEAD"); err == nil {
+		t.Error("MergeBase(invalid ref): expected error")
+	}
+	if _, err := MergeBase("", "target", "HEAD"); err == nil {
+		t.Error("MergeBase(empty repoRoot): expected error")
+	}
+}
+
 func TestUserIntent_returnsBranchAndCommit(t *testing.T) {
 	t.Parallel()
 	repo := initRepo(t)
@@ -162,6 +193,21 @@ func TestUserIntent_returnsBranchAndCommit(t *testing.T) {
 	}
 }
 
+func TestUserIdentity(t *testing.T) {
+	t.Parallel()
+	repo := initRepo(t)
+	name, email, err := UserIdentity(repo)
+	if err != nil {
+		t.Fatalf("UserIdentity: %v", err)
+	}
+	if name != "Test" || email != "test@stet.local" {
+		t.Errorf("UserIdentity = %q, %q; want Test, test@stet.local", name, email)
+	}
+	if _, _, err := UserIdentity(""); err == nil {
+		t.Error("UserIdentity(\"\"): want error")
+	}
+}
+
 func TestUserIntent_customCommitMessage(t *testing.T) {
 	t.Parallel()
 	repo := initRepo(t)
@@ -37,3 +37,36 @@ func RevList(repoRoot, sinceRef, untilRef string) ([]string, error) {
 	}
 	return strings.Split(trimmed, "\n"), nil
 }
+
+// CommitMessages returns the full message of each commit in shas, in the same
+// order, trimmed. Empty shas returns nil, nil.
+func CommitMessages(repoRoot string, shas []string) ([]string, error) {
+	if len(shas) == 0 {
+		return nil, nil
+	}
+	if repoRoot == "" {
+		return nil, erruser.New("commit messages: repo root required", nil)
+	}
+	args := append([]string{"show", "-s", "--no-color", "--format=%B%x00"}, shas...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, erruser.New("Could not read commit messages.", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	parts := strings.Split(stdout.String(), "\x00")
+	msgs := make([]string, 0, len(shas))
+	for _, p := range parts {
+		if len(msgs) == len(shas) {
+			break
+		}
+		msgs = append(msgs, strings.TrimSpace(p))
+	}
+	if len(msgs) != len(shas) {
+		return nil, erruser.New("Could not read commit messages.", fmt.Errorf("got %d messages for %d commits", len(msgs), len(shas)))
+	}
+	return msgs, nil
+}
@@ -105,3 +105,34 @@ func TestRevList_emptyRefs(t *testing.T) {
 		t.Fatal("RevList(until empty): expected error")
 	}
 }
+
+func TestCommitMessages_preservesOrderAndBody(t *testing.T) {
+	t.Parallel()
+	repo := initRepo(t)
+	writeFile(t, repo, "f3.txt", "c\n")
+	run(t, repo, "git", "add", "f3.txt")
+	run(t, repo, "git", "commit", "-m", "Add f3", "-m", "Longer body.")
+	shas, err := RevList(repo, "HEAD~2", "HEAD")
+	if err != nil {
+		t.Fatalf("RevList: %v", err)
+	}
+	msgs, err := CommitMessages(repo, shas)
+	if err != nil {
+		t.Fatalf("CommitMessages: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("CommitMessages: got %d messages, want 2", len(msgs))
+	}
+	if msgs[0] != "Add f3\n\nLonger body." {
+		t.Errorf("msgs[0] = %q, want subject and body of newest commit", msgs[0])
+	}
+	if msgs[1] == "" || msgs[1] == msgs[0] {
+		t.Errorf("msgs[1] = %q, want the older commit's message", msgs[1])
+	}
+	if msgs, err := CommitMessages(repo, nil); err != nil || msgs != nil {
+		t.Errorf("CommitMessages(nil) = %v, %v; want nil, nil", msgs, err)
+	}
+	if _, err := CommitMessages(repo, []string{"deadbeef"}); err == nil {
+		t.Error("CommitMessages(unknown sha): want error")
+	}
+}
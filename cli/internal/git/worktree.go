@@ -32,6 +32,13 @@ type WorktreeInfo struct {
 // If worktreeRoot is empty, uses repoRoot/.review/worktrees/stet-<short-sha>.
 // Otherwise uses worktreeRoot/stet-<short-sha>. Returns absolute path.
 func PathForRef(repoRoot, worktreeRoot, ref string) (string, error) {
+	return PathForSession(repoRoot, worktreeRoot, "", ref)
+}
+
+// PathForSession is PathForRef for a named session: the directory is
+// stet-<session>-<short-sha>, so sessions at the same baseline do not share a
+// worktree. An empty session is the default session (same as PathForRef).
+func PathForSession(repoRoot, worktreeRoot, session, ref string) (string, error) {
 	sha, err := revParseShort(repoRoot, ref, 12)
 	if err != nil {
 		return "", err
@@ -42,7 +49,11 @@ func PathForRef(repoRoot, worktreeRoot, ref string) (string, error) {
 	} else {
 		base = filepath.Join(repoRoot, ".review", "worktrees")
 	}
-	p := filepath.Join(base, "stet-"+sha)
+	name := "stet-" + sha
+	if session != "" {
+		name = "stet-" + session + "-" + sha
+	}
+	p := filepath.Join(base, name)
 	return filepath.Abs(p)
 }
 
@@ -85,13 +96,19 @@ func IsAncestor(repoRoot, ancestor, descendant string) (bool, error) {
 // Returns ErrWorktreeExists if a worktree already exists at the target path.
 // Returns ErrBaselineNotAncestor if ref is not an ancestor of HEAD.
 func Create(repoRoot, worktreeRoot, ref string) (path string, err error) {
+	return CreateForSession(repoRoot, worktreeRoot, "", ref)
+}
+
+// CreateForSession is Create for a named session; the path is derived via
+// PathForSession.
+func CreateForSession(repoRoot, worktreeRoot, session, ref string) (path string, err error) {
 	if ok, err := IsAncestor(repoRoot, ref, "HEAD"); err != nil {
 		return "", err
 	} else if !ok {
 		return "", ErrBaselineNotAncestor
 	}
 
-	path, err = PathForRef(repoRoot, worktreeRoot, ref)
+	path, err = PathForSession(repoRoot, worktreeRoot, session, ref)
 	if err != nil {
 		return "", err
 	}
@@ -198,3 +215,16 @@ func minimalEnv() []string {
 func MinimalEnv() []string {
 	return minimalEnv()
 }
+
+// AddDetached adds a worktree at path with ref checked out as a detached HEAD.
+// Unlike Create it does not derive the path or require ref to be an ancestor of
+// HEAD; callers own path and remove it with Remove.
+func AddDetached(repoRoot, path, ref string) error {
+	cmd := exec.Command("git", "worktree", "add", "--detach", path, ref)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return erruser.New("Could not add worktree.", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out))))
+	}
+	return nil
+}
@@ -85,6 +85,28 @@ func TestPathForRef_customWorktreeRoot(t *testing.T) {
 	}
 }
 
+func TestCreateForSession_separateWorktreesAtSameRef(t *testing.T) {
+	repo := initRepo(t)
+	sha := runOut(t, repo, "git", "rev-parse", "--short=12", "HEAD~1")
+	def, err := Create(repo, "", "HEAD~1")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	named, err := CreateForSession(repo, "", "feature-x", "HEAD~1")
+	if err != nil {
+		t.Fatalf("CreateForSession: %v", err)
+	}
+	if filepath.Base(named) != "stet-feature-x-"+sha || filepath.Dir(named) != filepath.Dir(def) {
+		t.Errorf("named worktree %q, want stet-feature-x-%s next to %q", named, sha, def)
+	}
+	if p, err := PathForSession(repo, "", "feature-x", "HEAD~1"); err != nil || p != named {
+		t.Errorf("PathForSession = %q, %v; want %q", p, err, named)
+	}
+	if _, err := os.Stat(filepath.Join(named, "f1.txt")); err != nil {
+		t.Errorf("f1.txt should exist in named worktree: %v", err)
+	}
+}
+
 func TestIsAncestor(t *testing.T) {
 	repo := initRepo(t)
 	ancestor, err := IsAncestor(repo, "HEAD~1", "HEAD")
@@ -222,6 +244,23 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestAddDetached(t *testing.T) {
+	repo := initRepo(t)
+	path := filepath.Join(t.TempDir(), "wt")
+	if err := AddDetached(repo, path, "HEAD~1"); err != nil {
+		t.Fatalf("AddDetached: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "f2.txt")); err == nil {
+		t.Error("f2.txt should not exist in worktree at HEAD~1")
+	}
+	if err := AddDetached(repo, path, "HEAD~1"); err == nil {
+		t.Error("AddDetached on an existing path: want error")
+	}
+	if err := Remove(repo, path); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+}
+
 func TestParseWorktreeList(t *testing.T) {
 	input := "worktree /a/main\nHEAD abc123\nbranch refs/heads/main\n\nworktree /a/stet-abc123\nHEAD abc123\nbare\n\n"
 	list, err := parseWorktreeList(input)
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"stet/cli/internal/erruser"
 )
@@ -141,6 +142,9 @@ func Append(stateDir string, record Record, maxRecords int) error {
 		return erruser.New("Could not create state directory for history.", err)
 	}
 	path := filepath.Join(stateDir, historyFilename)
+	if record.RecordedAt == "" {
+		record.RecordedAt = time.Now().UTC().Format(time.RFC3339)
+	}
 	line, err := json.Marshal(record)
 	if err != nil {
 		return erruser.New("Could not record review history.", err)
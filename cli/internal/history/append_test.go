@@ -39,6 +39,9 @@ func TestAppend_createsFileAndAppendsValidJSONL(t *testing.T) {
 	if decoded.DiffRef != rec.DiffRef || len(decoded.ReviewOutput) != 1 || decoded.ReviewOutput[0].ID != "f1" {
 		t.Errorf("decoded: %+v", decoded)
 	}
+	if _, ok := Time(decoded); !ok {
+		t.Errorf("recorded_at: got %q, want RFC 3339 time set by Append", decoded.RecordedAt)
+	}
 }
 
 func TestAppend_secondAppendAddsSecondLine(t *testing.T) {
@@ -0,0 +1,174 @@
+// Record selection for stet history (list, show, grep).
+
+package history
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/findings"
+)
+
+// Record kinds returned by Kind.
+const (
+	KindFinish  = "finish"
+	KindReplace = "replace"
+	KindDismiss = "dismiss"
+	KindStatus  = "status"
+)
+
+// Filter selects history records and their findings. Zero fields match
+// everything. Since and Until bound the record time (see Time); records
+// without a time never match a date bound.
+type Filter struct {
+	Since      time.Time
+	Until      time.Time
+	Model      string
+	Strictness string
+	Reason     string // dismissal reason of the finding
+	Category   string
+	FileGlob   string // slash-separated glob; "**" matches any number of directories
+}
+
+// ParseDate parses a --since/--until value: RFC 3339, or YYYY-MM-DD in local
+// time. With endOfDay, a date-only value means the end of that day.
+func ParseDate(s string, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, erruser.New(fmt.Sprintf("Invalid date %q; use YYYY-MM-DD or RFC 3339.", s), err)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// Time returns when r was recorded: RecordedAt, else UserAction.FinishedAt.
+// ok is false when neither is set or parses.
+func Time(r Record) (t time.Time, ok bool) {
+	for _, s := range []string{r.RecordedAt, r.UserAction.FinishedAt} {
+		if s == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Model returns the review model of r from its run config, else its usage.
+func Model(r Record) string {
+	if r.RunConfig != nil && r.RunConfig.Model != "" {
+		return r.RunConfig.Model
+	}
+	if r.UsageData != nil {
+		return r.UsageData.Model
+	}
+	return ""
+}
+
+// Kind classifies r by the action that appended it: finish, replace (rerun
+// --replace), dismiss (including auto-dismissal of addressed findings) or
+// status (accept, defer, comment).
+func Kind(r Record) string {
+	switch {
+	case r.UserAction.ReplaceFindings:
+		return KindReplace
+	case r.UserAction.FinishedAt != "":
+		return KindFinish
+	case len(r.UserAction.DismissedIDs) > 0:
+		return KindDismiss
+	default:
+		return KindStatus
+	}
+}
+
+// DismissReason returns the reason r records for finding id: from its
+// dismissals, else the finding's own DismissReason.
+func DismissReason(r Record, f findings.Finding) string {
+	for _, d := range r.UserAction.Dismissals {
+		if d.FindingID == f.ID && d.Reason != "" {
+			return d.Reason
+		}
+	}
+	return f.DismissReason
+}
+
+// Validate returns a user-facing error for an invalid reason, category or glob.
+func (q Filter) Validate() error {
+	if q.Reason != "" && !ValidReason(q.Reason) {
+		return erruser.New("Invalid reason; use one of: false_positive, already_correct, wrong_suggestion, out_of_scope.", nil)
+	}
+	if q.Category != "" && !findings.ValidCategory(findings.Category(strings.ToLower(q.Category))) {
+		return erruser.New(fmt.Sprintf("Invalid category %q.", q.Category), nil)
+	}
+	if q.FileGlob != "" {
+		if _, err := path.Match(strings.ReplaceAll(q.FileGlob, "**", "*"), ""); err != nil {
+			return erruser.New(fmt.Sprintf("Invalid file glob %q.", q.FileGlob), err)
+		}
+	}
+	return nil
+}
+
+// MatchRecord reports whether r passes the record-level filters: date range,
+// model and strictness.
+func (q Filter) MatchRecord(r Record) bool {
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		t, ok := Time(r)
+		if !ok || (!q.Since.IsZero() && t.Before(q.Since)) || (!q.Until.IsZero() && t.After(q.Until)) {
+			return false
+		}
+	}
+	if q.Model != "" && !strings.EqualFold(Model(r), q.Model) {
+		return false
+	}
+	if q.Strictness != "" {
+		st := ""
+		if r.RunConfig != nil {
+			st = r.RunConfig.Strictness
+		}
+		if !strings.EqualFold(st, q.Strictness) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasFindingFilter reports whether q filters findings (reason, category or file).
+func (q Filter) HasFindingFilter() bool {
+	return q.Reason != "" || q.Category != "" || q.FileGlob != ""
+}
+
+// MatchFinding reports whether f of record r passes the finding-level
+// filters: dismissal reason, category and file glob.
+func (q Filter) MatchFinding(r Record, f findings.Finding) bool {
+	if q.Category != "" && !strings.EqualFold(string(f.Category), q.Category) {
+		return false
+	}
+	if q.FileGlob != "" && !findings.MatchPath(q.FileGlob, f.File) {
+		return false
+	}
+	return q.Reason == "" || DismissReason(r, f) == q.Reason
+}
+
+// Findings returns the findings of r that pass the finding-level filters.
+func (q Filter) Findings(r Record) []findings.Finding {
+	var out []findings.Finding
+	for _, f := range r.ReviewOutput {
+		if q.MatchFinding(r, f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"stet/cli/internal/findings"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	rec := Record{
+		DiffRef: "abc",
+		ReviewOutput: []findings.Finding{
+			{ID: "1", File: "pkg/a/x.go", Category: findings.CategoryStyle},
+			{ID: "2", File: "cmd/main.go", Category: findings.CategoryBug},
+		},
+		UserAction: UserAction{
+			DismissedIDs: []string{"1"},
+			Dismissals:   []Dismissal{{FindingID: "1", Reason: ReasonFalsePositive}},
+		},
+		RunConfig:  NewRunConfigSnapshot("model-a", "strict", 0, 0, false),
+		RecordedAt: "2026-03-10T12:00:00Z",
+	}
+	day := func(s string, end bool) time.Time {
+		d, err := ParseDate(s, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		name    string
+		q       Filter
+		record  bool
+		matched []string
+	}{
+		{"empty", Filter{}, true, []string{"1", "2"}},
+		{"in range", Filter{Since: day("2026-03-01", false), Until: day("2026-03-10T23:00:00Z", false)}, true, []string{"1", "2"}},
+		{"before since", Filter{Since: day("2026-03-11T00:00:00Z", false)}, false, nil},
+		{"model case-insensitive", Filter{Model: "MODEL-A"}, true, []string{"1", "2"}},
+		{"other strictness", Filter{Strictness: "lenient"}, false, nil},
+		{"reason", Filter{Reason: ReasonFalsePositive}, true, []string{"1"}},
+		{"category", Filter{Category: "bug"}, true, []string{"2"}},
+		{"file glob", Filter{FileGlob: "pkg/**"}, true, []string{"1"}},
+	}
+	for _, tt := range tests {
+		if got := tt.q.MatchRecord(rec); got != tt.record {
+			t.Errorf("%s: MatchRecord = %v, want %v", tt.name, got, tt.record)
+		}
+		if !tt.record {
+			continue
+		}
+		var ids []string
+		for _, f := range tt.q.Findings(rec) {
+			ids = append(ids, f.ID)
+		}
+		if len(ids) != len(tt.matched) || (len(ids) > 0 && ids[0] != tt.matched[0]) {
+			t.Errorf("%s: Findings = %v, want %v", tt.name, ids, tt.matched)
+		}
+	}
+	if _, ok := Time(Record{}); ok {
+		t.Error("Time of a record without dates: want ok=false")
+	}
+	if (Filter{Since: day("2026-01-01", false)}).MatchRecord(Record{}) {
+		t.Error("record without a date should not match a date bound")
+	}
+	for _, q := range []Filter{{Reason: "bogus"}, {Category: "bogus"}, {FileGlob: "a/[b"}} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("Validate(%+v): want error", q)
+		}
+	}
+	if _, err := ParseDate("March 1", false); err == nil {
+		t.Error("ParseDate(March 1): want error")
+	}
+}
+
+func TestKind(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		rec  Record
+		want string
+	}{
+		{Record{UserAction: UserAction{ReplaceFindings: true}}, KindReplace},
+		{Record{UserAction: UserAction{FinishedAt: "2026-03-10T12:00:00Z", DismissedIDs: []string{"1"}}}, KindFinish},
+		{Record{UserAction: UserAction{DismissedIDs: []string{"1"}}}, KindDismiss},
+		{Record{}, KindStatus},
+	}
+	for _, tt := range tests {
+		if got := Kind(tt.rec); got != tt.want {
+			t.Errorf("Kind(%+v) = %q, want %q", tt.rec.UserAction, got, tt.want)
+		}
+	}
+}
@@ -38,6 +38,9 @@ type Dismissal struct {
 	FindingID     string `json:"finding_id"`
 	Reason        string `json:"reason,omitempty"`        // One of Reason* constants, or empty.
 	PromptContext string `json:"prompt_context,omitempty"` // Hunk content that produced this finding; for optimizer.
+	// Comments is the finding's comment thread (stet comment) at dismissal; free-text detail
+	// beyond Reason for the optimizer and suppression examples.
+	Comments []findings.Comment `json:"comments,omitempty"`
 }
 
 // RunConfigSnapshot holds run config for tuning correlation. Callers populate from config or RunOptions.
@@ -91,4 +94,10 @@ type Record struct {
 	CompletionTokens  *int64             `json:"completion_tokens,omitempty"`
 	EvalDurationNs    *int64             `json:"eval_duration_ns,omitempty"`
 	UsageData         *Usage             `json:"usage,omitempty"`
+	// ReplacedOutput is the session's findings before a rerun --replace (set only when
+	// UserAction.ReplaceFindings is true), so stet diff-findings can compare the two runs.
+	ReplacedOutput []findings.Finding `json:"replaced_output,omitempty"`
+	// RecordedAt is when the record was appended (RFC 3339, UTC); set by Append.
+	// Records written by older versions have none (finish records still have FinishedAt).
+	RecordedAt string `json:"recorded_at,omitempty"`
 }
@@ -11,10 +11,12 @@ import (
 )
 
 // SuppressionExamples reads history from stateDir, takes the last maxRecords
-// records, and extracts one short example string per dismissal that can be
-// resolved to a finding in the same record's ReviewOutput. Examples are
-// formatted as "file:line: message", deduplicated (normalized: trim, collapse
-// whitespace), and capped at maxExamples (oldest dropped). Returns nil, nil
+// records, and extracts one short example string per dismissed finding ID that
+// can be resolved to a finding in the same record's ReviewOutput. Examples are
+// formatted as "file:line: message", followed by " (reviewer: comments)" when
+// the finding has comments (stet comment; the latest thread recorded for its ID,
+// else the dismissal's), deduplicated by finding ID and by text (normalized: trim,
+// collapse whitespace), and capped at maxExamples (oldest dropped). Returns nil, nil
 // on missing/empty history or no resolvable dismissals (fail open: no section).
 // On read error (e.g. directory unreadable), returns nil, err.
 func SuppressionExamples(stateDir string, maxRecords, maxExamples int) ([]string, error) {
@@ -34,8 +36,18 @@ func SuppressionExamples(stateDir string, maxRecords, maxExamples int) ([]string
 		start = len(records) - maxRecords
 	}
 	slice := records[start:]
+	// Comment records carry the finding with its latest thread; newest wins.
+	latestComments := make(map[string][]findings.Comment)
+	for _, rec := range slice {
+		for _, f := range rec.ReviewOutput {
+			if f.ID != "" && len(f.Comments) > 0 {
+				latestComments[f.ID] = f.Comments
+			}
+		}
+	}
 	var raw []string
 	seen := make(map[string]struct{})
+	seenIDs := make(map[string]struct{})
 	for _, rec := range slice {
 		if len(rec.ReviewOutput) == 0 || len(rec.UserAction.Dismissals) == 0 {
 			continue
@@ -50,11 +62,22 @@ func SuppressionExamples(stateDir string, maxRecords, maxExamples int) ([]string
 			if d.FindingID == "" {
 				continue
 			}
+			if _, ok := seenIDs[d.FindingID]; ok {
+				continue
+			}
 			f, ok := byID[d.FindingID]
 			if !ok {
 				continue
 			}
+			seenIDs[d.FindingID] = struct{}{}
 			ex := formatExample(f)
+			comments := latestComments[d.FindingID]
+			if len(comments) == 0 {
+				comments = d.Comments
+			}
+			if note := findings.CommentText(comments); ex != "" && note != "" {
+				ex += " (reviewer: " + note + ")"
+			}
 			norm := normalizeExample(ex)
 			if norm == "" {
 				continue
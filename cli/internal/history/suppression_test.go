@@ -36,6 +36,65 @@ func TestSuppressionExamples_fixtureReturnsExpectedFormat(t *testing.T) {
 	}
 }
 
+func TestSuppressionExamples_includesComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, historyFilename)
+	rec := Record{
+		DiffRef: "HEAD",
+		ReviewOutput: []findings.Finding{
+			{ID: "f1", File: "a.go", Line: 3, Message: "Possible nil dereference"},
+			{ID: "f2", File: "b.go", Line: 7, Message: "Unused result", Comments: []findings.Comment{{At: "2025-01-02T10:00:00Z", Text: "Result is\n intentionally ignored"}}},
+		},
+		UserAction: UserAction{Dismissals: []Dismissal{
+			{FindingID: "f1", Reason: ReasonWrongSuggestion, Comments: []findings.Comment{
+				{Author: "Dev", At: "2025-01-02T10:00:00Z", Text: "Caller checks nil"},
+				{Author: "Lead", At: "2025-01-02T11:00:00Z", Text: "agreed"},
+			}},
+			{FindingID: "f2"},
+		}},
+	}
+	writeRecord(t, path, rec)
+	examples, err := SuppressionExamples(dir, 50, 30)
+	if err != nil {
+		t.Fatalf("SuppressionExamples: %v", err)
+	}
+	want := []string{
+		"a.go:3: Possible nil dereference (reviewer: Caller checks nil; agreed)",
+		"b.go:7: Unused result (reviewer: Result is intentionally ignored)",
+	}
+	if len(examples) != len(want) {
+		t.Fatalf("examples = %q, want %q", examples, want)
+	}
+	for i := range want {
+		if examples[i] != want[i] {
+			t.Errorf("examples[%d] = %q, want %q", i, examples[i], want[i])
+		}
+	}
+}
+
+func TestSuppressionExamples_oneExamplePerFindingWithLatestComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, historyFilename)
+	first := []findings.Comment{{Author: "Dev", Text: "Caller checks nil"}}
+	thread := append(append([]findings.Comment(nil), first...), findings.Comment{Author: "Lead", Text: "agreed"})
+	f := findings.Finding{ID: "f1", File: "a.go", Line: 3, Message: "Possible nil dereference", Status: findings.StatusDismissed}
+	withFirst, withThread := f, f
+	withFirst.Comments, withThread.Comments = first, thread
+	// stet dismiss, then stet comment (only the finding), then an older-style
+	// comment record that re-lists the dismissal.
+	writeRecord(t, path, Record{DiffRef: "HEAD", ReviewOutput: []findings.Finding{withFirst}, UserAction: UserAction{DismissedIDs: []string{"f1"}, Dismissals: []Dismissal{{FindingID: "f1", Reason: ReasonFalsePositive, Comments: first}}}})
+	appendRecord(t, path, Record{DiffRef: "HEAD", ReviewOutput: []findings.Finding{withThread}})
+	appendRecord(t, path, Record{DiffRef: "HEAD", ReviewOutput: []findings.Finding{withThread}, UserAction: UserAction{Dismissals: []Dismissal{{FindingID: "f1", Comments: thread}}}})
+	examples, err := SuppressionExamples(dir, 50, 30)
+	if err != nil {
+		t.Fatalf("SuppressionExamples: %v", err)
+	}
+	want := "a.go:3: Possible nil dereference (reviewer: Caller checks nil; agreed)"
+	if len(examples) != 1 || examples[0] != want {
+		t.Errorf("examples = %q, want [%q]", examples, want)
+	}
+}
+
 func TestSuppressionExamples_deduplication(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, historyFilename)
@@ -72,6 +72,12 @@ func hashString(s string) string {
 	return hex.EncodeToString(h[:])
 }
 
+// LangFromPath returns the language key of path used for comment stripping
+// (go, python, js, sh, rust), or "" when the extension is not recognized.
+func LangFromPath(path string) string {
+	return langFromPath(path)
+}
+
 // langFromPath returns a language key from the file path for comment stripping.
 // Supported: go, python, js, sh, rust. Unknown extensions return "" (no comment strip).
 func langFromPath(path string) string {
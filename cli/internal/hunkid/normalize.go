@@ -0,0 +1,263 @@
+package hunkid
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"stet/cli/internal/erruser"
+)
+
+// Level is how far SemanticHunkIDLevel normalizes a hunk before hashing.
+type Level string
+
+const (
+	// LevelText strips comments and collapses whitespace with regexes; the
+	// @@ header and +/- markers are part of the hash (SemanticHunkID).
+	LevelText Level = "text"
+	// LevelTokens hashes the language tokens of the old and new side of the
+	// hunk, so layout (gofmt, prettier, line wrapping) and comments are
+	// ignored, and so is the @@ header (line numbers shift when code above
+	// moves). Python keeps each line's indentation since it is significant.
+	LevelTokens Level = "tokens"
+	// LevelAST additionally parses Go sides with go/parser, sorts imports and
+	// renames function-local identifiers to positional names before printing
+	// with go/printer. Sides that do not parse, and other languages, fall
+	// back to LevelTokens.
+	LevelAST Level = "ast"
+)
+
+// ParseLevel validates a normalization level from config ("" = LevelText).
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(strings.ToLower(strings.TrimSpace(s))); l {
+	case "":
+		return LevelText, nil
+	case LevelText, LevelTokens, LevelAST:
+		return l, nil
+	default:
+		return "", erruser.New("Hunk ID normalization must be text, tokens or ast.", nil)
+	}
+}
+
+// SemanticHunkIDLevel returns the semantic ID of a hunk at the given
+// normalization level. LevelText (and "") equals SemanticHunkID. At the other
+// levels the old side (context and removed lines) and the new side (context
+// and added lines) are normalized separately, so a hunk whose change is the
+// same up to formatting, import order or local names gets the same ID.
+func SemanticHunkIDLevel(path, rawContent string, level Level) string {
+	if level == "" || level == LevelText {
+		return SemanticHunkID(path, rawContent)
+	}
+	oldSide, newSide := hunkSides(normalizeCRLF(rawContent))
+	lang := langFromPath(path)
+	return hashString(path + ":" + string(level) + ":" + normalizeSide(oldSide, lang, level) + "\x00" + normalizeSide(newSide, lang, level))
+}
+
+// hunkSides splits unified hunk content into old-side and new-side code,
+// dropping @@ headers, "\ No newline" markers and the +/-/space prefixes.
+// Content without an @@ header is used as-is for both sides.
+func hunkSides(content string) (oldSide, newSide string) {
+	if !strings.HasPrefix(content, "@@") {
+		return content, content
+	}
+	var oldLines, newLines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" {
+			oldLines = append(oldLines, "")
+			newLines = append(newLines, "")
+			continue
+		}
+		switch line[0] {
+		case ' ':
+			oldLines = append(oldLines, line[1:])
+			newLines = append(newLines, line[1:])
+		case '-':
+			oldLines = append(oldLines, line[1:])
+		case '+':
+			newLines = append(newLines, line[1:])
+		}
+	}
+	return strings.Join(oldLines, "\n"), strings.Join(newLines, "\n")
+}
+
+func normalizeSide(code, lang string, level Level) string {
+	if lang == "go" {
+		if level == LevelAST {
+			if printed, ok := goASTNormalize(code); ok {
+				code = printed
+			}
+		}
+		return goTokens(code)
+	}
+	return genericTokens(code, lang)
+}
+
+// goTokens returns the Go tokens of code separated by single spaces, without
+// comments and automatically inserted semicolons. Fragments that do not scan
+// cleanly (e.g. a hunk cut inside a raw string) still yield a deterministic
+// token stream.
+func goTokens(code string) string {
+	fset := token.NewFileSet()
+	src := []byte(code)
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, 0)
+	var out []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		if lit != "" {
+			out = append(out, lit)
+		} else {
+			out = append(out, tok.String())
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// genericTokenRegex matches identifiers, numbers, quoted strings and single
+// punctuation characters for languages without a dedicated scanner.
+var genericTokenRegex = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*|\d[\w.]*|"(?:\\.|[^"\\\n])*"|'(?:\\.|[^'\\\n])*'|\S`)
+
+// genericTokens strips comments for lang and returns the tokens of code
+// separated by single spaces. For Python each non-blank line keeps its
+// indentation width, since indentation is syntax there.
+func genericTokens(code, lang string) string {
+	switch lang {
+	case "js", "rust":
+		code = stripGoStyleComments(code)
+	case "python":
+		code = stripPythonComments(code)
+	case "sh":
+		code = stripShellComments(code)
+	}
+	if lang != "python" {
+		return strings.Join(genericTokenRegex.FindAllString(code, -1), " ")
+	}
+	var lines []string
+	for _, line := range strings.Split(code, "\n") {
+		toks := genericTokenRegex.FindAllString(line, -1)
+		if len(toks) == 0 {
+			continue
+		}
+		indent := len(strings.ReplaceAll(line[:len(line)-len(strings.TrimLeft(line, " \t"))], "\t", "    "))
+		lines = append(lines, strconv.Itoa(indent)+" "+strings.Join(toks, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// goASTNormalize parses a Go fragment as a file body, or else as statements
+// of a function body, sorts imports, renames locals and prints it. ok is
+// false when neither form parses.
+func goASTNormalize(code string) (string, bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", "package p\n"+code, parser.SkipObjectResolution)
+	if err != nil {
+		f, err = parser.ParseFile(fset, "", "package p\nfunc _() {\n"+code+"\n}", parser.SkipObjectResolution)
+		if err != nil {
+			return "", false
+		}
+	}
+	ast.SortImports(fset, f)
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok {
+			renameLocals(fd)
+		}
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, f); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// renameLocals renames the parameters, results and variables declared in fd
+// (including nested function literals) to _v0, _v1, ... in order of
+// declaration, so renaming a local does not change the printed code. Scoping
+// is by name within fd, which is enough for hashing. Selector fields and
+// composite literal keys are left alone.
+func renameLocals(fd *ast.FuncDecl) {
+	names := make(map[string]string)
+	declare := func(id *ast.Ident) {
+		if id == nil || id.Name == "_" {
+			return
+		}
+		if _, ok := names[id.Name]; !ok {
+			names[id.Name] = "_v" + strconv.Itoa(len(names))
+		}
+	}
+	declareFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, id := range field.Names {
+				declare(id)
+			}
+		}
+	}
+	declareFields(fd.Recv)
+	ast.Inspect(fd, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncType:
+			declareFields(n.Params)
+			declareFields(n.Results)
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						declare(id)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE {
+				if id, ok := n.Key.(*ast.Ident); ok {
+					declare(id)
+				}
+				if id, ok := n.Value.(*ast.Ident); ok {
+					declare(id)
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range n.Names {
+				declare(id)
+			}
+		}
+		return true
+	})
+	if len(names) == 0 {
+		return
+	}
+	skip := make(map[*ast.Ident]bool)
+	ast.Inspect(fd, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			skip[n.Sel] = true
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if id, ok := kv.Key.(*ast.Ident); ok {
+						skip[id] = true
+					}
+				}
+			}
+		case *ast.Ident:
+			if to, ok := names[n.Name]; ok && !skip[n] && n != fd.Name {
+				n.Name = to
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,124 @@
+package hunkid
+
+import "testing"
+
+func TestSemanticHunkIDLevel(t *testing.T) {
+	t.Parallel()
+	base := "@@ -1,3 +1,4 @@\n import (\n-\t\"os\"\n+\t\"fmt\"\n+\t\"os\"\n )"
+	body := "@@ -10,3 +10,4 @@ func F() {\n func F(a int) int {\n-\tx := a + 1\n+\tx := a + 2\n \treturn x\n }"
+	tests := []struct {
+		name     string
+		path     string
+		a, b     string
+		level    Level
+		wantSame bool
+	}{
+		{
+			name:     "gofmt-only layout change at tokens",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -10,3 +10,4 @@ func F() {\n func F(a int) int {\n-\tx := a+1\n+\tx   :=   a+2\n \treturn x\n }",
+			level:    LevelTokens,
+			wantSame: true,
+		},
+		{
+			name:     "layout change differs at text",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -10,3 +10,4 @@ func F() {\n func F(a int) int {\n-\tx := a+1\n+\tx   :=   a+2\n \treturn x\n }",
+			level:    LevelText,
+			wantSame: false,
+		},
+		{
+			name:     "line shift ignored at tokens",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -42,3 +47,4 @@ func F() {\n func F(a int) int {\n-\tx := a + 1\n+\tx := a + 2\n \treturn x\n }",
+			level:    LevelTokens,
+			wantSame: true,
+		},
+		{
+			name:     "local rename at ast",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -10,3 +10,4 @@ func F() {\n func F(n int) int {\n-\ty := n + 1\n+\ty := n + 2\n \treturn y\n }",
+			level:    LevelAST,
+			wantSame: true,
+		},
+		{
+			name:     "local rename differs at tokens",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -10,3 +10,4 @@ func F() {\n func F(n int) int {\n-\ty := n + 1\n+\ty := n + 2\n \treturn y\n }",
+			level:    LevelTokens,
+			wantSame: false,
+		},
+		{
+			name:     "import order at ast",
+			path:     "p.go",
+			a:        base,
+			b:        "@@ -1,3 +1,4 @@\n import (\n+\t\"os\"\n+\t\"fmt\"\n-\t\"os\"\n )",
+			level:    LevelAST,
+			wantSame: true,
+		},
+		{
+			name:     "real change differs at ast",
+			path:     "p.go",
+			a:        body,
+			b:        "@@ -10,3 +10,4 @@ func F() {\n func F(a int) int {\n-\tx := a + 1\n+\tx := a * 2\n \treturn x\n }",
+			level:    LevelAST,
+			wantSame: false,
+		},
+		{
+			name:     "python reindent differs at tokens",
+			path:     "m.py",
+			a:        "@@ -1,2 +1,2 @@\n if x:\n-    y()\n+    z()",
+			b:        "@@ -1,2 +1,2 @@\n if x:\n-    y()\n+z()",
+			level:    LevelTokens,
+			wantSame: false,
+		},
+		{
+			name:     "js comment and spacing at tokens",
+			path:     "a.ts",
+			a:        "@@ -1,1 +1,1 @@\n-f(a,b)\n+g(a,b)",
+			b:        "@@ -1,1 +1,1 @@\n-f(a, b) // old\n+g( a, b )",
+			level:    LevelTokens,
+			wantSame: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := SemanticHunkIDLevel(tt.path, tt.a, tt.level)
+			b := SemanticHunkIDLevel(tt.path, tt.b, tt.level)
+			if (a == b) != tt.wantSame {
+				t.Errorf("same ID = %v, want %v", a == b, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestSemanticHunkIDLevel_textMatchesSemanticHunkID(t *testing.T) {
+	t.Parallel()
+	content := "@@ -1,1 +1,1 @@\n-a\n+b"
+	if got, want := SemanticHunkIDLevel("x.go", content, LevelText), SemanticHunkID("x.go", content); got != want {
+		t.Errorf("LevelText = %q, want SemanticHunkID %q", got, want)
+	}
+	if got, want := SemanticHunkIDLevel("x.go", content, ""), SemanticHunkID("x.go", content); got != want {
+		t.Errorf("empty level = %q, want SemanticHunkID %q", got, want)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]Level{"": LevelText, "text": LevelText, " AST ": LevelAST, "tokens": LevelTokens} {
+		got, err := ParseLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("bytes"); err == nil {
+		t.Error("ParseLevel(bytes): expected error")
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"strconv"
 	"strings"
 
+	"stet/cli/internal/coverage"
 	"stet/cli/internal/diff"
 	"stet/cli/internal/erruser"
 	"stet/cli/internal/rag"
 	"stet/cli/internal/rules"
+	"stet/cli/internal/testctx"
 	"stet/cli/internal/tokens"
 )
 
@@ -132,6 +134,46 @@ func InjectUserIntent(systemPrompt, branch, commitMsg string) string {
 	return systemPrompt[:sectionStart] + replacement + systemPrompt[sectionEnd:]
 }
 
+const changeSummaryHeader = "## Change Summary\n"
+
+// InjectChangeSummary places summary (from the whole-change summary pass) in a
+// "## Change Summary" section directly after the "## User Intent" section, so
+// every hunk is reviewed with the big picture. An existing Change Summary
+// section is replaced. When there is no User Intent section the summary is
+// appended. Empty summary returns systemPrompt unchanged.
+func InjectChangeSummary(systemPrompt, summary string) string {
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return systemPrompt
+	}
+	section := changeSummaryHeader + summary
+	if idx := strings.Index(systemPrompt, changeSummaryHeader); idx != -1 {
+		return systemPrompt[:idx] + section + systemPrompt[sectionEnd(systemPrompt, idx, changeSummaryHeader):]
+	}
+	idx := strings.Index(systemPrompt, userIntentHeader)
+	if idx == -1 {
+		return strings.TrimRight(systemPrompt, "\n") + "\n\n" + section + "\n"
+	}
+	end := sectionEnd(systemPrompt, idx, userIntentHeader)
+	return systemPrompt[:end] + "\n\n" + section + systemPrompt[end:]
+}
+
+// sectionEnd returns the index where the body of the section starting at idx
+// with header ends: before the blank lines preceding the next "## " heading,
+// or len(s) when it is the last section.
+func sectionEnd(s string, idx int, header string) int {
+	start := idx + len(header)
+	end := strings.Index(s[start:], "\n## ")
+	if end == -1 {
+		return len(s)
+	}
+	end += start
+	for end > start && s[end-1] == '\n' {
+		end--
+	}
+	return end
+}
+
 const projectReviewCriteriaHeader = "## Project review criteria\n"
 
 // AppendCursorRules appends a "## Project review criteria" section to
@@ -301,6 +343,70 @@ func FormatCallGraph(callers, callees []rag.Definition, maxTokens int) string {
 	return text
 }
 
+const relatedTestsHeader = "## Related tests\n\n"
+
+const coverageHeader = "## Test coverage\n\n"
+
+// FormatRelatedTests returns the related-tests section for the user prompt:
+// each test that references the hunk's enclosing function as (File: path,
+// Line: N) and a code block. When a function was identified but no test
+// references it, the section says so, which is itself a signal for "testing"
+// findings. Returns "" when there is nothing to report. If maxTokens > 0, the
+// body is truncated to fit the token budget.
+func FormatRelatedTests(res testctx.Result, maxTokens int) string {
+	if res.Subject == "" && len(res.Snippets) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if len(res.Snippets) == 0 {
+		b.WriteString("No test references `")
+		b.WriteString(res.Subject)
+		b.WriteString("`")
+		if len(res.Searched) > 0 {
+			b.WriteString(" (searched: ")
+			b.WriteString(strings.Join(res.Searched, ", "))
+			b.WriteString(")")
+		} else {
+			b.WriteString(" (no test file is named after this file)")
+		}
+		b.WriteString(".")
+		return relatedTestsHeader + b.String()
+	}
+	for i, s := range res.Snippets {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString("(File: ")
+		b.WriteString(s.File)
+		b.WriteString(", Line: ")
+		b.WriteString(strconv.Itoa(s.Line))
+		b.WriteString(")\n\n```\n")
+		b.WriteString(s.Content)
+		b.WriteString("\n```")
+	}
+	text := b.String()
+	if maxTokens > 0 {
+		text = truncateToTokenBudget(text, maxTokens)
+	}
+	return relatedTestsHeader + text
+}
+
+// FormatCoverage returns the test-coverage section for the user prompt from
+// the hunk's uncovered added lines (sorted) and the number of added lines the
+// profile instruments. profileName identifies the coverage file. Returns ""
+// when no added line is instrumented (no data to report).
+func FormatCoverage(profileName string, uncovered []int, instrumented int) string {
+	if instrumented == 0 {
+		return ""
+	}
+	if len(uncovered) == 0 {
+		return coverageHeader + "All " + strconv.Itoa(instrumented) + " instrumented added lines in this hunk are executed by tests (coverage profile: " + profileName + "). Do not report missing tests for them."
+	}
+	return coverageHeader + "Coverage profile " + profileName + " shows " + strconv.Itoa(len(uncovered)) + " of " + strconv.Itoa(instrumented) +
+		" instrumented added lines are never executed by any test: lines " + coverage.FormatRanges(uncovered) + ".\n" +
+		"This is measured, not inferred. When an uncovered line carries logic whose failure would go unnoticed (error handling, branches, edge cases), report a \"testing\" finding at that line with high confidence. Do not report missing tests for covered lines."
+}
+
 // AppendSymbolDefinitions appends a section with symbol definitions (signature +
 // optional docstring) to the user prompt. Used by RAG-lite (Sub-phase 6.8).
 // If defs is nil or empty, returns userPrompt unchanged. If maxTokens > 0,
@@ -327,10 +433,12 @@ func UserPromptWithRAGPlacement(hunkBlock, symbolDefsBlock string) string {
 }
 
 // Shadow holds a dismissed finding's ID and the prompt context (hunk content)
-// for optional negative few-shot injection (Sub-phase 6.9).
+// for optional negative few-shot injection (Sub-phase 6.9). Note is the
+// reviewer's comment on the finding, if any (stet comment).
 type Shadow struct {
 	FindingID     string
 	PromptContext string
+	Note          string
 }
 
 const (
@@ -340,7 +448,8 @@ const (
 
 // AppendPromptShadows appends a "## Negative examples (do not report)" section
 // with up to maxPromptShadowsInject most recent shadows, each truncated to
-// maxShadowContextChars when injecting. Returns system unchanged if shadows is nil/empty.
+// maxShadowContextChars when injecting, followed by the reviewer's note when set.
+// Returns system unchanged if shadows is nil/empty.
 func AppendPromptShadows(system string, shadows []Shadow) string {
 	if len(shadows) == 0 {
 		return system
@@ -359,7 +468,16 @@ func AppendPromptShadows(system string, shadows []Shadow) string {
 		}
 		b.WriteString("Context (dismissed):\n```\n")
 		b.WriteString(ctx)
-		b.WriteString("\n```\n\n")
+		b.WriteString("\n```\n")
+		if note := shadows[i].Note; note != "" {
+			if len(note) > maxShadowContextChars {
+				note = note[:maxShadowContextChars] + " [truncated]"
+			}
+			b.WriteString("Reviewer note: ")
+			b.WriteString(note)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
 	}
 	return system + b.String()
 }
@@ -416,7 +534,24 @@ func UserPrompt(hunk diff.Hunk) string {
 	if hunk.FilePath == "" {
 		return content
 	}
-	return "File: " + hunk.FilePath + "\n\n" + content
+	return fileHeader(hunk) + "\n\n" + content
+}
+
+// fileHeader returns the "File: path" line of the user prompt, with the file
+// status (rename source, new/deleted file, mode change) and, for a batched
+// hunk, the number of hunks in parentheses when set.
+func fileHeader(hunk diff.Hunk) string {
+	var notes []string
+	if note := hunk.StatusNote(); note != "" {
+		notes = append(notes, note)
+	}
+	if len(hunk.Parts) > 1 {
+		notes = append(notes, strconv.Itoa(len(hunk.Parts))+" hunks")
+	}
+	if len(notes) > 0 {
+		return "File: " + hunk.FilePath + " (" + strings.Join(notes, "; ") + ")"
+	}
+	return "File: " + hunk.FilePath
 }
 
 const searchReplaceFormatNote = "\n\n## Input format\nThe user content is in search-replace format: SEARCH = old code, REPLACE = new code. Review the change and the resulting code (REPLACE) for defects.\n"
@@ -461,5 +596,5 @@ func UserPromptSearchReplace(hunk diff.Hunk) string {
 	if hunk.FilePath == "" {
 		return "<<<<<<< SEARCH\n" + oldStr + "\n=======\n" + newStr + "\n>>>>>>> REPLACE"
 	}
-	return "File: " + hunk.FilePath + "\n\n<<<<<<< SEARCH\n" + oldStr + "\n=======\n" + newStr + "\n>>>>>>> REPLACE"
+	return fileHeader(hunk) + "\n\n<<<<<<< SEARCH\n" + oldStr + "\n=======\n" + newStr + "\n>>>>>>> REPLACE"
 }
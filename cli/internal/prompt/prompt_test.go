@@ -9,6 +9,7 @@ import (
 	"stet/cli/internal/diff"
 	"stet/cli/internal/rag"
 	"stet/cli/internal/rules"
+	"stet/cli/internal/testctx"
 )
 
 func TestDefaultSystemPrompt_containsActionabilityAndHighConfidenceGuidance(t *testing.T) {
@@ -220,6 +221,35 @@ func TestUserPrompt_emptyContext_usesRawContent(t *testing.T) {
 	}
 }
 
+func TestUserPrompt_fileStatusInHeader(t *testing.T) {
+	hunk := diff.Hunk{
+		FilePath:   "new/util.go",
+		RawContent: "@@ -1 +1 @@\n-a\n+b",
+		Status:     diff.StatusRenamed,
+		OldPath:    "old/util.go",
+		Similarity: 92,
+	}
+	want := "File: new/util.go (renamed from old/util.go, 92% similar)\n\n"
+	if got := UserPrompt(hunk); !strings.HasPrefix(got, want) {
+		t.Errorf("UserPrompt = %q, want prefix %q", got, want)
+	}
+	if got := UserPromptSearchReplace(hunk); !strings.HasPrefix(got, want) {
+		t.Errorf("UserPromptSearchReplace = %q, want prefix %q", got, want)
+	}
+}
+
+func TestUserPrompt_batchedHunkCountInHeader(t *testing.T) {
+	parts := []diff.Hunk{
+		{FilePath: "a.go", RawContent: "@@ -1 +1 @@\n-a\n+b"},
+		{FilePath: "a.go", RawContent: "@@ -9 +9 @@\n-c\n+d"},
+	}
+	hunk := diff.Hunk{FilePath: "a.go", Status: diff.StatusAdded, RawContent: parts[0].RawContent + "\n" + parts[1].RawContent, Parts: parts}
+	want := "File: a.go (new file; 2 hunks)\n\n@@ -1 +1 @@"
+	if got := UserPrompt(hunk); !strings.HasPrefix(got, want) {
+		t.Errorf("UserPrompt = %q, want prefix %q", got, want)
+	}
+}
+
 func TestUserPromptSearchReplace(t *testing.T) {
 	hunk := diff.Hunk{
 		FilePath: "a.go",
@@ -303,6 +333,28 @@ func TestInjectUserIntent_missingSection_unchanged(t *testing.T) {
 	}
 }
 
+func TestInjectChangeSummary(t *testing.T) {
+	base := "Review code.\n\n## User Intent\nBranch: main\n\n## Review steps\nFollow steps."
+	got := InjectChangeSummary(base, "  - Renames Foo to Bar\n")
+	want := "Review code.\n\n## User Intent\nBranch: main\n\n## Change Summary\n- Renames Foo to Bar\n\n## Review steps\nFollow steps."
+	if got != want {
+		t.Errorf("InjectChangeSummary:\ngot  %q\nwant %q", got, want)
+	}
+	again := InjectChangeSummary(got, "- Second pass")
+	if strings.Count(again, "## Change Summary") != 1 || !strings.Contains(again, "- Second pass\n\n## Review steps") {
+		t.Errorf("InjectChangeSummary should replace existing section; got:\n%s", again)
+	}
+	if got := InjectChangeSummary(base, "  "); got != base {
+		t.Errorf("InjectChangeSummary(empty): want unchanged; got %q", got)
+	}
+	if got := InjectChangeSummary("No sections.", "- S"); got != "No sections.\n\n## Change Summary\n- S\n" {
+		t.Errorf("InjectChangeSummary(no User Intent): got %q", got)
+	}
+	if got := InjectChangeSummary(DefaultSystemPrompt, "- S"); !strings.Contains(got, "## Change Summary\n- S") {
+		t.Errorf("InjectChangeSummary(default prompt): section missing")
+	}
+}
+
 func TestAppendCursorRules_nilRules_unchanged(t *testing.T) {
 	base := "System prompt."
 	got := AppendCursorRules(base, nil, "app.ts", 1000)
@@ -397,6 +449,19 @@ func TestAppendPromptShadows_nonEmpty_appendsSection(t *testing.T) {
 	}
 }
 
+func TestAppendPromptShadows_note(t *testing.T) {
+	got := AppendPromptShadows("System prompt.", []Shadow{
+		{FindingID: "f1", PromptContext: "ctx one", Note: "Nil is checked by the caller"},
+		{FindingID: "f2", PromptContext: "ctx two"},
+	})
+	if !strings.Contains(got, "ctx one\n```\nReviewer note: Nil is checked by the caller\n") {
+		t.Errorf("AppendPromptShadows: want note after its context; got:\n%s", got)
+	}
+	if strings.Count(got, "Reviewer note:") != 1 {
+		t.Errorf("AppendPromptShadows: want one note line; got:\n%s", got)
+	}
+}
+
 func TestAppendPromptShadows_contextExceedsLimit_truncated(t *testing.T) {
 	base := "System prompt."
 	longCtx := strings.Repeat("x", 1000)
@@ -628,3 +693,45 @@ func TestUserPromptWithRAGPlacement_nonEmpty_structure(t *testing.T) {
 		t.Errorf("UserPromptWithRAGPlacement: should end with repeat header + hunkBlock; got last %d chars: %q", len(codeUnderReviewRepeatHeader)+len(hunkBlock)+20, got[max(0, len(got)-80):])
 	}
 }
+
+func TestFormatRelatedTests(t *testing.T) {
+	if got := FormatRelatedTests(testctx.Result{}, 0); got != "" {
+		t.Errorf("FormatRelatedTests(empty): want %q; got %q", "", got)
+	}
+	res := testctx.Result{
+		Subject:  "Add",
+		Searched: []string{"*_test.go"},
+		Snippets: []testctx.Snippet{{File: "calc/calc_test.go", Line: 5, Name: "TestAdd", Content: "func TestAdd(t *testing.T) {\n}"}},
+	}
+	got := FormatRelatedTests(res, 0)
+	for _, want := range []string{relatedTestsHeader, "(File: calc/calc_test.go, Line: 5)", "func TestAdd(t *testing.T) {"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatRelatedTests: want %q in %q", want, got)
+		}
+	}
+	res.Snippets = nil
+	got = FormatRelatedTests(res, 0)
+	if !strings.Contains(got, "No test references `Add` (searched: *_test.go).") {
+		t.Errorf("FormatRelatedTests(no snippets): got %q", got)
+	}
+	got = FormatRelatedTests(testctx.Result{Subject: "parse"}, 0)
+	if !strings.Contains(got, "no test file is named after this file") {
+		t.Errorf("FormatRelatedTests(no test files): got %q", got)
+	}
+}
+
+func TestFormatCoverage(t *testing.T) {
+	if got := FormatCoverage("cover.out", nil, 0); got != "" {
+		t.Errorf("FormatCoverage(no instrumented lines): want %q; got %q", "", got)
+	}
+	got := FormatCoverage("cover.out", nil, 4)
+	if !strings.Contains(got, "All 4 instrumented added lines") {
+		t.Errorf("FormatCoverage(all covered): got %q", got)
+	}
+	got = FormatCoverage("cover.out", []int{12, 13, 14, 20}, 6)
+	for _, want := range []string{coverageHeader, "4 of 6", "lines 12-14, 20.", `"testing" finding`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCoverage: want %q in %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Package cpp implements RAG symbol resolution for C and C++ files: extract
+// symbols from hunk content and look up definitions via git grep.
+package cpp
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/rag"
+)
+
+const (
+	grepTimeout              = 5 * time.Second
+	maxSymbolCandidates      = 30
+	maxPrecedingCommentLines = 5
+)
+
+var cppKeywords = map[string]bool{
+	"alignas": true, "alignof": true, "auto": true, "bool": true, "break": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"constexpr": true, "const_cast": true, "continue": true, "decltype": true,
+	"default": true, "delete": true, "do": true, "double": true, "dynamic_cast": true,
+	"else": true, "enum": true, "explicit": true, "extern": true, "false": true,
+	"float": true, "for": true, "friend": true, "goto": true, "if": true,
+	"inline": true, "int": true, "long": true, "mutable": true, "namespace": true,
+	"new": true, "noexcept": true, "nullptr": true, "operator": true, "private": true,
+	"protected": true, "public": true, "register": true, "reinterpret_cast": true,
+	"return": true, "short": true, "signed": true, "sizeof": true, "static": true,
+	"static_assert": true, "static_cast": true, "struct": true, "switch": true,
+	"template": true, "this": true, "throw": true, "true": true, "try": true,
+	"typedef": true, "typeid": true, "typename": true, "union": true, "unsigned": true,
+	"using": true, "virtual": true, "void": true, "volatile": true, "while": true,
+	"NULL": true, "include": true, "define": true, "ifdef": true, "ifndef": true,
+	"endif": true, "defined": true,
+}
+
+var (
+	reTypeDecl  = regexp.MustCompile(`\b(?:class|struct|union|enum(?:\s+class)?)\s+([A-Za-z_]\w*)`)
+	reScopeCall = regexp.MustCompile(`::\s*([A-Za-z_]\w*)\s*\(`)
+	reCall      = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*\(`)
+	reTypeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
+)
+
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`(?:class|struct|union|enum)[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[[:space:]]*[:{]|[[:space:]]*$)` +
+	`|typedef[[:space:]].*[^a-zA-Z0-9_](?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*;` +
+	`|#[[:space:]]*define[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[^a-zA-Z0-9_]|$)` +
+	`|using[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*=` +
+	`|::(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*\([^;]*$` +
+	`|^[A-Za-z_][A-Za-z0-9_:<>,*&[:space:]]*[[:space:]*&](?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*\([^;]*$`)
+
+// Resolver implements rag.Resolver for C and C++.
+type Resolver struct{}
+
+func init() {
+	for _, ext := range []string{".c", ".h", ".cc", ".cpp", ".cxx", ".hh", ".hpp", ".hxx"} {
+		rag.MustRegisterResolver(ext, New())
+	}
+}
+
+// New returns a new C/C++ symbol resolver.
+func New() *Resolver {
+	return &Resolver{}
+}
+
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "cpp"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
+// ResolveSymbols extracts symbols from hunkContent and looks up their
+// definitions in the repo. Returns up to opts.MaxDefinitions; total size
+// may be capped by opts.MaxTokens.
+func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts rag.ResolveOptions) ([]rag.Definition, error) {
+	symbols := extractSymbols(hunkContent)
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	maxDefs := opts.MaxDefinitions
+	if maxDefs <= 0 {
+		maxDefs = 10
+	}
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
+	if err != nil || len(defs) == 0 {
+		return nil, err
+	}
+	if opts.MaxTokens > 0 {
+		defs = capDefinitionsByTokens(defs, opts.MaxTokens)
+	}
+	return defs, nil
+}
+
+func extractSymbols(hunkContent string) []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, re := range []*regexp.Regexp{reTypeDecl, reScopeCall, reCall, reTypeIdent} {
+		for _, m := range re.FindAllStringSubmatch(hunkContent, -1) {
+			if len(m) < 2 {
+				continue
+			}
+			name := m[1]
+			if cppKeywords[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			list = append(list, name)
+			if len(list) >= maxSymbolCandidates {
+				return list
+			}
+		}
+	}
+	return list
+}
+
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
+	absRepo, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var defs []rag.Definition
+	for _, sym := range symbols {
+		if len(defs) >= maxDefs {
+			break
+		}
+		if seen[sym] {
+			continue
+		}
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
+		if err != nil || path == "" {
+			continue
+		}
+		seen[sym] = true
+		relPath, _ := filepath.Rel(absRepo, path)
+		relPath = filepath.ToSlash(relPath)
+		sig, doc := readSignatureAndDoc(path, line, content)
+		if sig == "" {
+			sig = strings.TrimSpace(content)
+		}
+		defs = append(defs, rag.Definition{
+			Symbol:    sym,
+			File:      relPath,
+			Line:      line,
+			Signature: sig,
+			Docstring: doc,
+		})
+	}
+	return defs, nil
+}
+
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("cpp", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
+func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
+	quoted := regexp.QuoteMeta(symbol)
+	// POSIX [[:space:]] for macOS/BSD git grep -E. Match: class/struct/union/enum Symbol
+	// (not forward declarations ending in ;), typedef ... Symbol;, #define Symbol,
+	// using Symbol =, Class::Symbol( out-of-line definitions, and free functions
+	// "ret Symbol(" where the line does not end in ; (skips prototypes and calls).
+	pattern := `((class|struct|union|enum)[[:space:]]+` + quoted + `([[:space:]]*[:{]|[[:space:]]*$)` +
+		`|typedef[[:space:]].*[^a-zA-Z0-9_]` + quoted + `[[:space:]]*;` +
+		`|#[[:space:]]*define[[:space:]]+` + quoted + `([^a-zA-Z0-9_]|$)` +
+		`|using[[:space:]]+` + quoted + `[[:space:]]*=` +
+		`|::` + quoted + `[[:space:]]*\([^;]*$` +
+		`|^[A-Za-z_][A-Za-z0-9_:<>,*&[:space:]]*[[:space:]*&]` + quoted + `[[:space:]]*\([^;]*$)`
+	ctx, cancel := context.WithTimeout(ctx, grepTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "grep", "-n", "-E", pattern)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv(repoRoot)
+	out, err := cmd.Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 {
+			return "", 0, "", nil
+		}
+		return "", 0, "", err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return "", 0, "", nil
+	}
+	first := strings.SplitN(trimmed, "\n", 2)[0]
+	idx := strings.Index(first, ":")
+	if idx == -1 {
+		return "", 0, "", nil
+	}
+	path := first[:idx]
+	if path == "" || strings.Contains(path, "..") {
+		return "", 0, "", nil
+	}
+	rest := first[idx+1:]
+	idx2 := strings.Index(rest, ":")
+	if idx2 == -1 {
+		return "", 0, "", nil
+	}
+	lineno, errParse := strconv.Atoi(rest[:idx2])
+	if errParse != nil || lineno < 1 {
+		return "", 0, "", nil
+	}
+	lineContent = rest[idx2+1:]
+	absPath = filepath.Join(repoRoot, path)
+	// Ensure resolved path is under repo root (defense in depth; matches Go resolver).
+	rel, errRel := filepath.Rel(repoRoot, absPath)
+	if errRel != nil || strings.HasPrefix(rel, "..") || rel == ".." {
+		return "", 0, "", nil
+	}
+	return absPath, lineno, lineContent, nil
+}
+
+func minimalEnv(repoRoot string) []string {
+	gitDir := filepath.Join(repoRoot, ".git")
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_DIR=" + gitDir,
+		"GIT_WORK_TREE=" + repoRoot,
+	}
+}
+
+// readSignatureAndDoc reads the file at path, line (1-based). Signature = declaration
+// line(s) up to { or ;. Docstring = preceding //, ///, or a /* ... */ block (Doxygen).
+func readSignatureAndDoc(absPath string, lineNum int, declarationLine string) (signature, docstring string) {
+	f, err := os.Open(absPath)
+	if err != nil {
+		return declarationLine, ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	var lines []string
+	line := 0
+	for sc.Scan() {
+		line++
+		lines = append(lines, sc.Text())
+		if line >= lineNum+15 {
+			break
+		}
+	}
+	if lineNum < 1 || lineNum > len(lines) {
+		return strings.TrimSpace(declarationLine), ""
+	}
+	sigLine := lines[lineNum-1]
+	var sigBuilder strings.Builder
+	sigBuilder.WriteString(strings.TrimSpace(sigLine))
+	if !strings.Contains(sigLine, "{") && !strings.Contains(sigLine, ";") {
+		for i := lineNum; i < len(lines) && i < lineNum+5; i++ {
+			sigBuilder.WriteString("\n")
+			sigBuilder.WriteString(lines[i])
+			if strings.Contains(lines[i], "{") || strings.Contains(lines[i], ";") {
+				break
+			}
+		}
+	}
+	signature = strings.TrimSpace(sigBuilder.String())
+	docstring = precedingComment(lines, lineNum)
+	return signature, docstring
+}
+
+// precedingComment collects up to maxPrecedingCommentLines of // or /* */ comment
+// lines directly above lineNum (1-based). Block comment markers are stripped.
+func precedingComment(lines []string, lineNum int) string {
+	var docLines []string
+	inBlock := false
+	for i := lineNum - 2; i >= 0 && i >= lineNum-1-maxPrecedingCommentLines; i-- {
+		s := strings.TrimSpace(lines[i])
+		if inBlock {
+			start := strings.HasPrefix(s, "/*")
+			s = strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!")
+			if s = strings.TrimSpace(s); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			if start {
+				break
+			}
+			continue
+		}
+		if s == "" {
+			break
+		}
+		if strings.HasPrefix(s, "//") {
+			docLines = append([]string{strings.TrimSpace(strings.TrimLeft(s, "/!"))}, docLines...)
+			continue
+		}
+		if strings.HasSuffix(s, "*/") {
+			s = strings.TrimSpace(strings.TrimSuffix(s, "*/"))
+			if strings.HasPrefix(s, "/*") {
+				s = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!"))
+				if s != "" {
+					docLines = append([]string{s}, docLines...)
+				}
+				break
+			}
+			inBlock = true
+			if s = strings.TrimSpace(strings.TrimLeft(s, "*")); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			continue
+		}
+		break
+	}
+	return strings.Join(docLines, "\n")
+}
+
+func capDefinitionsByTokens(defs []rag.Definition, maxTokens int) []rag.Definition {
+	estimate := func(s string) int {
+		return (len(s) + 3) / 4
+	}
+	used := 0
+	for i := range defs {
+		d := &defs[i]
+		n := estimate(d.Signature) + estimate(d.Docstring)
+		if used+n > maxTokens && i > 0 {
+			return defs[:i]
+		}
+		used += n
+	}
+	return defs
+}
@@ -0,0 +1,201 @@
+package cpp
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/rag"
+)
+
+func TestResolveSymbols_definitions(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	header := `#pragma once
+
+/**
+ * Widget renders things.
+ */
+class Widget {
+public:
+    void draw();
+};
+
+// Adds two numbers.
+int add(int a, int b);
+
+#define MAX_ITEMS 16
+`
+	source := `#include "widget.h"
+
+int add(int a, int b) {
+    return a + b;
+}
+
+void Widget::draw() {
+    add(1, 2);
+}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "widget.h"), []byte(header), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "widget.cpp"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "src")
+
+	tests := []struct {
+		name    string
+		hunk    string
+		symbol  string
+		file    string
+		wantSig string
+		wantDoc string
+	}{
+		{name: "class", hunk: "Widget w;", symbol: "Widget", file: "src/widget.h", wantSig: "class Widget", wantDoc: "Widget renders things."},
+		{name: "free function skips prototype", hunk: "int x = add(1, 2);", symbol: "add", file: "src/widget.cpp", wantSig: "int add(int a, int b) {"},
+		{name: "out-of-line method", hunk: "w.draw();", symbol: "draw", file: "src/widget.cpp", wantSig: "void Widget::draw()"},
+		{name: "macro", hunk: "int buf[MAX_ITEMS];", symbol: "MAX_ITEMS", file: "src/widget.h", wantSig: "#define MAX_ITEMS"},
+	}
+	r := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := r.ResolveSymbols(ctx, dir, "src/main.cpp", tt.hunk, rag.ResolveOptions{MaxDefinitions: 5})
+			if err != nil {
+				t.Fatalf("ResolveSymbols: %v", err)
+			}
+			var got *rag.Definition
+			for i := range defs {
+				if defs[i].Symbol == tt.symbol {
+					got = &defs[i]
+					break
+				}
+			}
+			if got == nil {
+				t.Fatalf("no definition for %q; got %+v", tt.symbol, defs)
+			}
+			if got.File != tt.file {
+				t.Errorf("File = %q, want %q", got.File, tt.file)
+			}
+			if !strings.Contains(got.Signature, tt.wantSig) {
+				t.Errorf("Signature = %q, want to contain %q", got.Signature, tt.wantSig)
+			}
+			if tt.wantDoc != "" && got.Docstring != tt.wantDoc {
+				t.Errorf("Docstring = %q, want %q", got.Docstring, tt.wantDoc)
+			}
+		})
+	}
+}
+
+func TestResolveSymbols_maxN_returnsAtMostN(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	content := `void a() {}
+void b() {}
+void c() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "x.c"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "x.c")
+
+	defs, err := New().ResolveSymbols(ctx, dir, "x.c", "a(); b(); c();", rag.ResolveOptions{MaxDefinitions: 2})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 2 {
+		t.Errorf("expected 2 definitions; got %d", len(defs))
+	}
+}
+
+func TestResolveSymbols_noMatch_returnsNil(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	defs, err := New().ResolveSymbols(ctx, dir, "x.cpp", "NonExistent();", rag.ResolveOptions{MaxDefinitions: 5})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 0 {
+		t.Errorf("expected 0 definitions; got %d", len(defs))
+	}
+}
+
+func TestExtractSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		hunk    string
+		want    []string
+		notWant []string
+	}{
+		{name: "calls and types", hunk: "Widget w; w.draw(); add(1, 2);", want: []string{"Widget", "draw", "add"}},
+		{name: "scoped call", hunk: "std::sort(v.begin(), v.end());", want: []string{"sort", "begin"}},
+		{name: "skips keywords", hunk: "if (x) { return sizeof(int); } while (y) {}", notWant: []string{"if", "return", "sizeof", "while"}},
+		{name: "type declaration", hunk: "enum class Color { Red };", want: []string{"Color", "Red"}, notWant: []string{"class", "enum"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSymbols(tt.hunk)
+			seen := make(map[string]bool)
+			for _, s := range got {
+				if seen[s] {
+					t.Errorf("duplicate symbol %q", s)
+				}
+				seen[s] = true
+			}
+			for _, w := range tt.want {
+				if !seen[w] {
+					t.Errorf("expected %q in %v", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if seen[w] {
+					t.Errorf("did not expect %q in %v", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCapDefinitionsByTokens(t *testing.T) {
+	defs := []rag.Definition{
+		{Symbol: "a", Signature: strings.Repeat("x", 40)},
+		{Symbol: "b", Signature: strings.Repeat("y", 40)},
+	}
+	got := capDefinitionsByTokens(defs, 15)
+	if len(got) != 1 {
+		t.Errorf("capDefinitionsByTokens: got %d definitions, want 1", len(got))
+	}
+}
+
+func initGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@test")
+	runGit(t, dir, "config", "user.name", "Test")
+}
+
+func gitAdd(t *testing.T, dir, path string) {
+	t.Helper()
+	runGit(t, dir, "add", path)
+	runGit(t, dir, "commit", "-m", "add")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
@@ -0,0 +1,319 @@
+// Package csharp implements RAG symbol resolution for C# files: extract
+// symbols from hunk content and look up definitions via git grep.
+package csharp
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/rag"
+)
+
+const (
+	grepTimeout              = 5 * time.Second
+	maxSymbolCandidates      = 30
+	maxPrecedingCommentLines = 5
+)
+
+var csharpKeywords = map[string]bool{
+	"abstract": true, "as": true, "base": true, "bool": true, "break": true,
+	"byte": true, "case": true, "catch": true, "char": true, "checked": true,
+	"class": true, "const": true, "continue": true, "decimal": true, "default": true,
+	"delegate": true, "do": true, "double": true, "else": true, "enum": true,
+	"event": true, "explicit": true, "extern": true, "false": true, "finally": true,
+	"fixed": true, "float": true, "for": true, "foreach": true, "goto": true,
+	"if": true, "implicit": true, "in": true, "int": true, "interface": true,
+	"internal": true, "is": true, "lock": true, "long": true, "namespace": true,
+	"new": true, "null": true, "object": true, "operator": true, "out": true,
+	"override": true, "params": true, "private": true, "protected": true, "public": true,
+	"readonly": true, "record": true, "ref": true, "return": true, "sbyte": true,
+	"sealed": true, "short": true, "sizeof": true, "stackalloc": true, "static": true,
+	"string": true, "struct": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "uint": true, "ulong": true,
+	"unchecked": true, "unsafe": true, "ushort": true, "using": true, "var": true,
+	"virtual": true, "void": true, "volatile": true, "while": true, "async": true,
+	"await": true, "nameof": true, "yield": true, "get": true, "set": true,
+}
+
+var typeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
+var callIdent = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\s*(?:<[^<>()]*>)?\s*\(`)
+
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`(?:class|interface|struct|enum|record)[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[^a-zA-Z0-9_]|$)` +
+	`|delegate[[:space:]].*[^a-zA-Z0-9_](?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*[<(]` +
+	`|^[[:space:]]*(?:public|private|protected|internal|static|override|virtual|abstract|async|sealed|extern|partial|new)[[:space:]][][A-Za-z0-9_<>,.?[:space:]]*[[:space:]](?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*(?:<[^>]*>)?[[:space:]]*\(`)
+
+// Resolver implements rag.Resolver for C#.
+type Resolver struct{}
+
+func init() {
+	rag.MustRegisterResolver(".cs", New())
+}
+
+// New returns a new C# symbol resolver.
+func New() *Resolver {
+	return &Resolver{}
+}
+
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "csharp"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
+// ResolveSymbols extracts symbols from hunkContent and looks up their
+// definitions in the repo. Returns up to opts.MaxDefinitions; total size
+// may be capped by opts.MaxTokens.
+func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts rag.ResolveOptions) ([]rag.Definition, error) {
+	symbols := extractSymbols(hunkContent)
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	maxDefs := opts.MaxDefinitions
+	if maxDefs <= 0 {
+		maxDefs = 10
+	}
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
+	if err != nil || len(defs) == 0 {
+		return nil, err
+	}
+	if opts.MaxTokens > 0 {
+		defs = capDefinitionsByTokens(defs, opts.MaxTokens)
+	}
+	return defs, nil
+}
+
+func extractSymbols(hunkContent string) []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, re := range []*regexp.Regexp{typeIdent, callIdent} {
+		for _, m := range re.FindAllStringSubmatch(hunkContent, -1) {
+			if len(m) < 2 {
+				continue
+			}
+			name := m[1]
+			if csharpKeywords[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			list = append(list, name)
+			if len(list) >= maxSymbolCandidates {
+				return list
+			}
+		}
+	}
+	return list
+}
+
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
+	absRepo, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var defs []rag.Definition
+	for _, sym := range symbols {
+		if len(defs) >= maxDefs {
+			break
+		}
+		if seen[sym] {
+			continue
+		}
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
+		if err != nil || path == "" {
+			continue
+		}
+		seen[sym] = true
+		relPath, _ := filepath.Rel(absRepo, path)
+		relPath = filepath.ToSlash(relPath)
+		sig, doc := readSignatureAndDoc(path, line, content)
+		if sig == "" {
+			sig = strings.TrimSpace(content)
+		}
+		defs = append(defs, rag.Definition{
+			Symbol:    sym,
+			File:      relPath,
+			Line:      line,
+			Signature: sig,
+			Docstring: doc,
+		})
+	}
+	return defs, nil
+}
+
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("csharp", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
+func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
+	quoted := regexp.QuoteMeta(symbol)
+	// POSIX [[:space:]] for macOS/BSD git grep -E. Match: class/interface/struct/enum/record/delegate
+	// Symbol, and member declarations "modifier Type Symbol(" or "modifier Type Symbol<T>(" where the
+	// line starts with a modifier (skips call sites like "x = Symbol(").
+	pattern := `((class|interface|struct|enum|record)[[:space:]]+` + quoted + `([^a-zA-Z0-9_]|$)` +
+		`|delegate[[:space:]].*[^a-zA-Z0-9_]` + quoted + `[[:space:]]*[<(]` +
+		`|^[[:space:]]*(public|private|protected|internal|static|override|virtual|abstract|async|sealed|extern|partial|new)[[:space:]][][A-Za-z0-9_<>,.?[:space:]]*[[:space:]]` + quoted + `[[:space:]]*(<[^>]*>)?[[:space:]]*\()`
+	ctx, cancel := context.WithTimeout(ctx, grepTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "grep", "-n", "-E", pattern)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv(repoRoot)
+	out, err := cmd.Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 {
+			return "", 0, "", nil
+		}
+		return "", 0, "", err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return "", 0, "", nil
+	}
+	first := strings.SplitN(trimmed, "\n", 2)[0]
+	idx := strings.Index(first, ":")
+	if idx == -1 {
+		return "", 0, "", nil
+	}
+	path := first[:idx]
+	if path == "" || strings.Contains(path, "..") {
+		return "", 0, "", nil
+	}
+	rest := first[idx+1:]
+	idx2 := strings.Index(rest, ":")
+	if idx2 == -1 {
+		return "", 0, "", nil
+	}
+	lineno, errParse := strconv.Atoi(rest[:idx2])
+	if errParse != nil || lineno < 1 {
+		return "", 0, "", nil
+	}
+	lineContent = rest[idx2+1:]
+	absPath = filepath.Join(repoRoot, path)
+	// Ensure resolved path is under repo root (defense in depth; matches Go resolver).
+	rel, errRel := filepath.Rel(repoRoot, absPath)
+	if errRel != nil || strings.HasPrefix(rel, "..") || rel == ".." {
+		return "", 0, "", nil
+	}
+	return absPath, lineno, lineContent, nil
+}
+
+func minimalEnv(repoRoot string) []string {
+	gitDir := filepath.Join(repoRoot, ".git")
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_DIR=" + gitDir,
+		"GIT_WORK_TREE=" + repoRoot,
+	}
+}
+
+// xmlDocTag matches XML doc tags such as <summary> or <param name="x"> in /// comments.
+var xmlDocTag = regexp.MustCompile(`</?[a-zA-Z]+[^>]*>`)
+
+// readSignatureAndDoc reads the file at path, line (1-based). Signature = declaration
+// line(s) up to {, ; or =>. Docstring = preceding /// XML doc comments (tags stripped)
+// or // comments.
+func readSignatureAndDoc(absPath string, lineNum int, declarationLine string) (signature, docstring string) {
+	f, err := os.Open(absPath)
+	if err != nil {
+		return declarationLine, ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	var lines []string
+	line := 0
+	for sc.Scan() {
+		line++
+		lines = append(lines, sc.Text())
+		if line >= lineNum+15 {
+			break
+		}
+	}
+	if lineNum < 1 || lineNum > len(lines) {
+		return strings.TrimSpace(declarationLine), ""
+	}
+	sigLine := lines[lineNum-1]
+	var sigBuilder strings.Builder
+	sigBuilder.WriteString(strings.TrimSpace(sigLine))
+	if !endsDeclaration(sigLine) {
+		for i := lineNum; i < len(lines) && i < lineNum+5; i++ {
+			sigBuilder.WriteString("\n")
+			sigBuilder.WriteString(lines[i])
+			if endsDeclaration(lines[i]) {
+				break
+			}
+		}
+	}
+	signature = strings.TrimSpace(sigBuilder.String())
+
+	var docLines []string
+	for i := lineNum - 2; i >= 0 && i >= lineNum-1-maxPrecedingCommentLines; i-- {
+		s := strings.TrimSpace(lines[i])
+		if s == "" {
+			break
+		}
+		if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+			// Attribute line such as [Obsolete]; keep scanning for doc comments above it.
+			continue
+		}
+		if strings.HasPrefix(s, "///") {
+			text := strings.TrimSpace(xmlDocTag.ReplaceAllString(s[3:], ""))
+			if text != "" {
+				docLines = append([]string{text}, docLines...)
+			}
+			continue
+		}
+		if strings.HasPrefix(s, "//") {
+			docLines = append([]string{strings.TrimSpace(s[2:])}, docLines...)
+			continue
+		}
+		break
+	}
+	if len(docLines) > 0 {
+		docstring = strings.Join(docLines, "\n")
+	}
+	return signature, docstring
+}
+
+func endsDeclaration(line string) bool {
+	return strings.Contains(line, "{") || strings.Contains(line, ";") || strings.Contains(line, "=>")
+}
+
+func capDefinitionsByTokens(defs []rag.Definition, maxTokens int) []rag.Definition {
+	estimate := func(s string) int {
+		return (len(s) + 3) / 4
+	}
+	used := 0
+	for i := range defs {
+		d := &defs[i]
+		n := estimate(d.Signature) + estimate(d.Docstring)
+		if used+n > maxTokens && i > 0 {
+			return defs[:i]
+		}
+		used += n
+	}
+	return defs
+}
@@ -0,0 +1,192 @@
+package csharp
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/rag"
+)
+
+func TestResolveSymbols_definitions(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `namespace App
+{
+    /// <summary>
+    /// Sends greetings.
+    /// </summary>
+    public class Greeter
+    {
+        // Builds the greeting text.
+        public static string Greet(string name)
+        {
+            return "hi " + name;
+        }
+
+        public async Task<int> CountAsync<T>(IEnumerable<T> items) => items.Count();
+    }
+
+    public interface IStore { }
+}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "Greeter.cs"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "src/Greeter.cs")
+
+	tests := []struct {
+		name    string
+		hunk    string
+		symbol  string
+		wantSig string
+		wantDoc string
+	}{
+		{name: "class with xml doc", hunk: "var g = new Greeter();", symbol: "Greeter", wantSig: "public class Greeter", wantDoc: "Sends greetings."},
+		{name: "static method", hunk: "var s = Greeter.Greet(\"x\");", symbol: "Greet", wantSig: "public static string Greet(string name)", wantDoc: "Builds the greeting text."},
+		{name: "generic expression-bodied method", hunk: "await CountAsync<int>(xs);", symbol: "CountAsync", wantSig: "=> items.Count();"},
+		{name: "interface", hunk: "IStore store;", symbol: "IStore", wantSig: "public interface IStore"},
+	}
+	r := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := r.ResolveSymbols(ctx, dir, "src/Program.cs", tt.hunk, rag.ResolveOptions{MaxDefinitions: 5})
+			if err != nil {
+				t.Fatalf("ResolveSymbols: %v", err)
+			}
+			var got *rag.Definition
+			for i := range defs {
+				if defs[i].Symbol == tt.symbol {
+					got = &defs[i]
+					break
+				}
+			}
+			if got == nil {
+				t.Fatalf("no definition for %q; got %+v", tt.symbol, defs)
+			}
+			if want := "src/Greeter.cs"; got.File != want {
+				t.Errorf("File = %q, want %q", got.File, want)
+			}
+			if !strings.Contains(got.Signature, tt.wantSig) {
+				t.Errorf("Signature = %q, want to contain %q", got.Signature, tt.wantSig)
+			}
+			if tt.wantDoc != "" && got.Docstring != tt.wantDoc {
+				t.Errorf("Docstring = %q, want %q", got.Docstring, tt.wantDoc)
+			}
+		})
+	}
+}
+
+func TestResolveSymbols_maxN_returnsAtMostN(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	content := `public class X {
+    public void A() {}
+    public void B() {}
+    public void C() {}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "X.cs"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "X.cs")
+
+	defs, err := New().ResolveSymbols(ctx, dir, "X.cs", "A(); B(); C();", rag.ResolveOptions{MaxDefinitions: 2})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 2 {
+		t.Errorf("expected 2 definitions; got %d", len(defs))
+	}
+}
+
+func TestResolveSymbols_noMatch_returnsNil(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	defs, err := New().ResolveSymbols(ctx, dir, "X.cs", "NonExistent();", rag.ResolveOptions{MaxDefinitions: 5})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 0 {
+		t.Errorf("expected 0 definitions; got %d", len(defs))
+	}
+}
+
+func TestExtractSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		hunk    string
+		want    []string
+		notWant []string
+	}{
+		{name: "calls and types", hunk: "var w = new Widget(); w.Draw(); Add(1, 2);", want: []string{"Widget", "Draw", "Add"}},
+		{name: "generic call", hunk: "var x = Parse<int>(s);", want: []string{"Parse"}},
+		{name: "skips keywords", hunk: "if (x) { return typeof(int); } foreach (var y in ys) {}", notWant: []string{"if", "return", "typeof", "foreach", "var"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSymbols(tt.hunk)
+			seen := make(map[string]bool)
+			for _, s := range got {
+				if seen[s] {
+					t.Errorf("duplicate symbol %q", s)
+				}
+				seen[s] = true
+			}
+			for _, w := range tt.want {
+				if !seen[w] {
+					t.Errorf("expected %q in %v", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if seen[w] {
+					t.Errorf("did not expect %q in %v", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCapDefinitionsByTokens(t *testing.T) {
+	defs := []rag.Definition{
+		{Symbol: "a", Signature: strings.Repeat("x", 40)},
+		{Symbol: "b", Signature: strings.Repeat("y", 40)},
+	}
+	got := capDefinitionsByTokens(defs, 15)
+	if len(got) != 1 {
+		t.Errorf("capDefinitionsByTokens: got %d definitions, want 1", len(got))
+	}
+}
+
+func initGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@test")
+	runGit(t, dir, "config", "user.name", "Test")
+}
+
+func gitAdd(t *testing.T, dir, path string) {
+	t.Helper()
+	runGit(t, dir, "add", path)
+	runGit(t, dir, "commit", "-m", "add")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
@@ -57,7 +57,7 @@ func (r *callGraphResolver) ResolveCallGraph(ctx context.Context, repoRoot, file
 	if err != nil {
 		return nil, nil // best-effort; don't fail the pipeline
 	}
-	callees, err := findCallees(ctx, repoRoot, filePath, start, end, calleesMax)
+	callees, err := findCallees(ctx, repoRoot, filePath, start, end, calleesMax, opts.Index)
 	if err != nil {
 		return nil, nil
 	}
@@ -151,7 +151,7 @@ func parseGrepLine(line, absRepo string) (absPath string, lineNum int, content s
 }
 
 // findCallees parses the file, finds the enclosing function, collects called names from the body, and looks up each definition.
-func findCallees(ctx context.Context, repoRoot, filePath string, startLine, endLine, max int) ([]rag.Definition, error) {
+func findCallees(ctx context.Context, repoRoot, filePath string, startLine, endLine, max int, idx rag.SymbolIndex) ([]rag.Definition, error) {
 	path := filepath.Join(repoRoot, filepath.FromSlash(filePath))
 	path = filepath.Clean(path)
 	absRepo, err := filepath.Abs(repoRoot)
@@ -200,7 +200,7 @@ func findCallees(ctx context.Context, repoRoot, filePath string, startLine, endL
 			continue
 		}
 		seen[name] = true
-		path, line, content, err := gitGrepSymbol(ctx, absRepo, name)
+		path, line, content, err := findDefinition(ctx, absRepo, name, idx)
 		if err != nil || path == "" {
 			continue
 		}
@@ -37,6 +37,10 @@ var typeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
 // callIdent matches lowercase identifier followed by ( (function call).
 var callIdent = regexp.MustCompile(`\b([a-z][A-Za-z0-9_]*)\s*\(`)
 
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`(?:func|type|var|const)[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[^a-zA-Z0-9_]|$)`)
+
 // Resolver implements rag.Resolver for Go.
 type Resolver struct{}
 
@@ -49,19 +53,42 @@ func New() *Resolver {
 	return &Resolver{}
 }
 
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "go"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
 // ResolveSymbols extracts symbols from hunkContent and looks up their
 // definitions in the repo. Returns up to opts.MaxDefinitions; total size
-// may be capped by opts.MaxTokens.
+// may be capped by opts.MaxTokens. When opts.TypeInfo is set, symbols are
+// resolved via go/packages type information first; on load failure (or when
+// the file is not part of a loaded package) it falls back to git grep.
 func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts rag.ResolveOptions) ([]rag.Definition, error) {
-	symbols := extractSymbols(hunkContent)
-	if len(symbols) == 0 {
-		return nil, nil
-	}
 	maxDefs := opts.MaxDefinitions
 	if maxDefs <= 0 {
 		maxDefs = 10
 	}
-	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs)
+	if opts.TypeInfo {
+		if absRepo, err := filepath.Abs(repoRoot); err == nil {
+			if defs, err := resolveWithTypes(ctx, absRepo, filePath, hunkContent, maxDefs); err == nil {
+				if len(defs) > 0 && opts.MaxTokens > 0 {
+					defs = capDefinitionsByTokens(defs, opts.MaxTokens)
+				}
+				return defs, nil
+			}
+		}
+	}
+	symbols := extractSymbols(hunkContent)
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
 	if err != nil || len(defs) == 0 {
 		return nil, err
 	}
@@ -94,7 +121,7 @@ func extractSymbols(hunkContent string) []string {
 }
 
 // lookupDefinitions runs git grep for each symbol and reads signature + docstring.
-func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int) ([]rag.Definition, error) {
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
 	absRepo, err := filepath.Abs(repoRoot)
 	if err != nil {
 		return nil, err
@@ -108,7 +135,7 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 		if seen[sym] {
 			continue
 		}
-		path, line, content, err := gitGrepSymbol(ctx, absRepo, sym)
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
 		if err != nil || path == "" {
 			continue
 		}
@@ -130,6 +157,20 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 	return defs, nil
 }
 
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("go", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
 // gitGrepSymbol runs git grep for Go definitions of symbol. Returns absPath, line, lineContent.
 func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
 	// Match: func Symbol, type Symbol, var Symbol, const Symbol. Use POSIX classes
@@ -52,6 +52,36 @@ func Bar() int {
 	}
 }
 
+// emptyIndex is a symbol index without definitions, like one that skipped the
+// defining file for being too large.
+type emptyIndex struct{}
+
+func (emptyIndex) Lookup(language, name string) (string, int, string, bool) {
+	return "", 0, "", false
+}
+
+func TestResolveSymbols_indexMiss_fallsBackToGrep(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	pkgDir := filepath.Join(dir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkgDir, "foo.go"), []byte("package pkg\n\nfunc Bar() int {\n\treturn 42\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "pkg/foo.go")
+
+	defs, err := New().ResolveSymbols(ctx, dir, "pkg/foo.go", "x := Bar()", rag.ResolveOptions{MaxDefinitions: 5, Index: emptyIndex{}})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 1 || defs[0].Symbol != "Bar" || defs[0].File != "pkg/foo.go" {
+		t.Errorf("expected grep fallback to find Bar in pkg/foo.go; got %+v", defs)
+	}
+}
+
 func TestResolveSymbols_maxN_returnsAtMostN(t *testing.T) {
 	ctx := context.Background()
 	dir := t.TempDir()
@@ -0,0 +1,362 @@
+// Package goresolver: type-checked symbol resolution for Go using go/packages.
+// Used when rag.ResolveOptions.TypeInfo is set; identifiers in the hunk are
+// resolved through types.Info, so methods, struct fields, and implementations
+// of referenced interfaces are found precisely. Callers fall back to git grep
+// when the packages cannot be loaded or the file is not part of a package.
+package goresolver
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/expand"
+	"stet/cli/internal/rag"
+)
+
+const (
+	typesLoadTimeout   = 2 * time.Minute
+	maxImplementations = 3
+	typesLoadMode      = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+	typesLoadPattern   = "./..."
+)
+
+// errNoTypeInfo is returned when the hunk's file has no type information
+// (not in a loaded package, or the hunk header cannot be parsed).
+var errNoTypeInfo = errors.New("goresolver: no type information for file")
+
+// typeIndex holds the type-checked packages of one repo root.
+type typeIndex struct {
+	fset  *token.FileSet
+	pkgs  []*packages.Package
+	files map[string]typedFile // absolute file path -> syntax and package
+}
+
+type typedFile struct {
+	pkg  *packages.Package
+	file *ast.File
+}
+
+// typeIndexEntry is one load of a repo's packages; concurrent callers wait on
+// done instead of loading again.
+type typeIndexEntry struct {
+	done chan struct{}
+	idx  *typeIndex
+	err  error
+}
+
+var (
+	typeIndexes   = make(map[string]*typeIndexEntry)
+	typeIndexesMu sync.Mutex
+)
+
+// loadTypeIndex returns the cached type index for absRepo, loading it on first
+// use. The worktree under review does not change during a run, so a loaded
+// index is kept for the lifetime of the process. The load is detached from
+// ctx so one cancelled caller does not fail it for the others, and failed
+// loads are not cached: the next caller tries again.
+func loadTypeIndex(ctx context.Context, absRepo string) (*typeIndex, error) {
+	typeIndexesMu.Lock()
+	entry, ok := typeIndexes[absRepo]
+	if !ok {
+		entry = &typeIndexEntry{done: make(chan struct{})}
+		typeIndexes[absRepo] = entry
+		go func() {
+			entry.idx, entry.err = buildTypeIndex(context.Background(), absRepo)
+			if entry.err != nil {
+				typeIndexesMu.Lock()
+				if typeIndexes[absRepo] == entry {
+					delete(typeIndexes, absRepo)
+				}
+				typeIndexesMu.Unlock()
+			}
+			close(entry.done)
+		}()
+	}
+	typeIndexesMu.Unlock()
+	select {
+	case <-entry.done:
+		return entry.idx, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func buildTypeIndex(ctx context.Context, absRepo string) (*typeIndex, error) {
+	ctx, cancel := context.WithTimeout(ctx, typesLoadTimeout)
+	defer cancel()
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    typesLoadMode,
+		Dir:     absRepo,
+		Fset:    fset,
+	}
+	pkgs, err := packages.Load(cfg, typesLoadPattern)
+	if err != nil {
+		return nil, err
+	}
+	idx := &typeIndex{fset: fset, files: make(map[string]typedFile)}
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.TypesInfo == nil {
+			continue
+		}
+		idx.pkgs = append(idx.pkgs, pkg)
+		for _, f := range pkg.Syntax {
+			name := fset.Position(f.Pos()).Filename
+			if name == "" {
+				continue
+			}
+			idx.files[filepath.Clean(name)] = typedFile{pkg: pkg, file: f}
+		}
+	}
+	if len(idx.files) == 0 {
+		return nil, errNoTypeInfo
+	}
+	sort.Slice(idx.pkgs, func(i, j int) bool { return idx.pkgs[i].PkgPath < idx.pkgs[j].PkgPath })
+	return idx, nil
+}
+
+// typedRef is one object referenced from the hunk, with the receiver type
+// name for methods and fields (empty otherwise).
+type typedRef struct {
+	obj  types.Object
+	recv string
+}
+
+// resolveWithTypes resolves identifiers used in the hunk's new-side line range
+// to their declarations via type information. Returns errNoTypeInfo (or the
+// load error) when the caller should fall back to grep.
+func resolveWithTypes(ctx context.Context, absRepo, filePath, hunkContent string, maxDefs int) ([]rag.Definition, error) {
+	start, end, ok := expand.HunkLineRange(diff.Hunk{FilePath: filePath, RawContent: hunkContent})
+	if !ok {
+		return nil, errNoTypeInfo
+	}
+	idx, err := loadTypeIndex(ctx, absRepo)
+	if err != nil {
+		return nil, err
+	}
+	tf, ok := idx.files[filepath.Join(absRepo, filepath.FromSlash(filePath))]
+	if !ok {
+		return nil, errNoTypeInfo
+	}
+	refs := collectTypedRefs(idx.fset, tf.pkg.TypesInfo, tf.file, start, end)
+	seen := make(map[types.Object]bool)
+	var defs []rag.Definition
+	add := func(obj types.Object, symbol string) {
+		if len(defs) >= maxDefs || seen[obj] {
+			return
+		}
+		seen[obj] = true
+		if d, ok := definitionForObject(idx.fset, absRepo, obj, symbol); ok {
+			defs = append(defs, d)
+		}
+	}
+	for _, ref := range refs {
+		if len(defs) >= maxDefs {
+			break
+		}
+		add(ref.obj, qualifiedName(ref.obj, ref.recv))
+		// Interface or interface method: also show the concrete implementations in the repo.
+		iface, method := interfaceOf(ref.obj)
+		if iface == nil {
+			continue
+		}
+		for _, impl := range findImplementations(idx, iface, maxImplementations) {
+			if method == "" {
+				add(impl, impl.Name())
+				continue
+			}
+			if m, _, _ := types.LookupFieldOrMethod(types.NewPointer(impl.Type()), true, impl.Pkg(), method); m != nil {
+				add(m, impl.Name()+"."+method)
+			}
+		}
+	}
+	return defs, nil
+}
+
+// collectTypedRefs walks file and returns, in source order, the objects used
+// by identifiers on lines start..end (1-based, inclusive). Selector expressions
+// keep their receiver so methods and fields are named Recv.Name. Local
+// variables, package names, and universe objects are skipped.
+func collectTypedRefs(fset *token.FileSet, info *types.Info, file *ast.File, start, end int) []typedRef {
+	var refs []typedRef
+	inRange := func(n ast.Node) bool {
+		line := fset.Position(n.Pos()).Line
+		return line >= start && line <= end
+	}
+	handled := make(map[*ast.Ident]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		if fset.Position(n.End()).Line < start || fset.Position(n.Pos()).Line > end {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			if !inRange(x.Sel) {
+				return true
+			}
+			if sel, ok := info.Selections[x]; ok {
+				handled[x.Sel] = true
+				refs = append(refs, typedRef{obj: sel.Obj(), recv: namedTypeName(sel.Recv())})
+			}
+		case *ast.Ident:
+			if handled[x] || !inRange(x) {
+				return true
+			}
+			obj := info.Uses[x]
+			if obj == nil || !isResolvable(obj) {
+				return true
+			}
+			refs = append(refs, typedRef{obj: obj})
+		}
+		return true
+	})
+	return refs
+}
+
+// isResolvable reports whether obj is worth a definition: package-level
+// functions, types, vars and consts, plus methods and fields.
+func isResolvable(obj types.Object) bool {
+	if obj.Pkg() == nil {
+		return false
+	}
+	switch o := obj.(type) {
+	case *types.PkgName, *types.Label, *types.Builtin, *types.Nil:
+		return false
+	case *types.Func:
+		return true
+	case *types.Var:
+		if o.IsField() {
+			return true
+		}
+	}
+	return obj.Parent() == obj.Pkg().Scope()
+}
+
+// namedTypeName returns the name of t's named type, dereferencing pointers.
+func namedTypeName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj().Name()
+	}
+	return ""
+}
+
+// qualifiedName returns Recv.Name for methods and fields, otherwise Name.
+func qualifiedName(obj types.Object, recv string) string {
+	if recv == "" {
+		if fn, ok := obj.(*types.Func); ok {
+			if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+				recv = namedTypeName(sig.Recv().Type())
+			}
+		}
+	}
+	if recv == "" {
+		return obj.Name()
+	}
+	return recv + "." + obj.Name()
+}
+
+// interfaceOf returns the interface type for an interface type name, or for an
+// interface method together with the method name. Returns (nil, "") otherwise.
+func interfaceOf(obj types.Object) (*types.Interface, string) {
+	switch o := obj.(type) {
+	case *types.TypeName:
+		if iface, ok := o.Type().Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+			return iface, ""
+		}
+	case *types.Func:
+		sig, ok := o.Type().(*types.Signature)
+		if !ok || sig.Recv() == nil {
+			return nil, ""
+		}
+		if iface, ok := sig.Recv().Type().Underlying().(*types.Interface); ok {
+			return iface, o.Name()
+		}
+	}
+	return nil, ""
+}
+
+// findImplementations returns up to limit named, non-interface types in the
+// loaded packages whose value or pointer type implements iface.
+func findImplementations(idx *typeIndex, iface *types.Interface, limit int) []*types.TypeName {
+	var out []*types.TypeName
+	for _, pkg := range idx.pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			if _, isIface := tn.Type().Underlying().(*types.Interface); isIface {
+				continue
+			}
+			if types.Implements(tn.Type(), iface) || types.Implements(types.NewPointer(tn.Type()), iface) {
+				out = append(out, tn)
+				if len(out) >= limit {
+					return out
+				}
+			}
+		}
+	}
+	return out
+}
+
+// definitionForObject builds a Definition for obj when it is declared under
+// absRepo. Fields and interface methods use only their own line as signature.
+func definitionForObject(fset *token.FileSet, absRepo string, obj types.Object, symbol string) (rag.Definition, bool) {
+	if !obj.Pos().IsValid() {
+		return rag.Definition{}, false
+	}
+	pos := fset.Position(obj.Pos())
+	rel, err := filepath.Rel(absRepo, pos.Filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return rag.Definition{}, false
+	}
+	sig, doc := readSignatureAndDoc(pos.Filename, pos.Line, "")
+	if singleLineDeclaration(obj) {
+		sig = strings.TrimSpace(strings.SplitN(sig, "\n", 2)[0])
+	}
+	if sig == "" {
+		sig = types.ObjectString(obj, types.RelativeTo(obj.Pkg()))
+	}
+	return rag.Definition{
+		Symbol:    symbol,
+		File:      filepath.ToSlash(rel),
+		Line:      pos.Line,
+		Signature: sig,
+		Docstring: doc,
+	}, true
+}
+
+// singleLineDeclaration reports whether obj is declared on a single line inside
+// a larger declaration (struct fields, interface methods).
+func singleLineDeclaration(obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.IsField()
+	case *types.Func:
+		sig, ok := o.Type().(*types.Signature)
+		if !ok || sig.Recv() == nil {
+			return false
+		}
+		_, isIface := sig.Recv().Type().Underlying().(*types.Interface)
+		return isIface
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package goresolver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/rag"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResolveSymbols_typeInfo_methodsFieldsAndImplementations(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/shapes\n\ngo 1.22\n",
+		"shape/shape.go": `package shape
+
+// Shape has an area.
+type Shape interface {
+	Area() float64
+}
+
+// Square is a Shape.
+type Square struct {
+	// Side is the edge length.
+	Side float64
+}
+
+// Area returns the square's area.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+`,
+		"main.go": `package main
+
+import "example.com/shapes/shape"
+
+func total(shapes []shape.Shape, sq shape.Square) float64 {
+	sum := sq.Side
+	for _, s := range shapes {
+		sum += s.Area()
+	}
+	return sum
+}
+
+func main() {
+	_ = total(nil, shape.Square{})
+}
+`,
+	})
+	hunk := "@@ -5,6 +5,6 @@\n func total(shapes []shape.Shape, sq shape.Square) float64 {\n-\tsum := 0.0\n+\tsum := sq.Side\n \tfor _, s := range shapes {\n \t\tsum += s.Area()\n \t}\n"
+	defs, err := New().ResolveSymbols(ctx, dir, "main.go", hunk, rag.ResolveOptions{MaxDefinitions: 10, TypeInfo: true})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	bySymbol := make(map[string]rag.Definition)
+	for _, d := range defs {
+		bySymbol[d.Symbol] = d
+	}
+	tests := []struct {
+		symbol  string
+		wantSig string
+		wantDoc string
+	}{
+		{symbol: "Shape", wantSig: "type Shape interface", wantDoc: "Shape has an area."},
+		{symbol: "Square", wantSig: "type Square struct", wantDoc: "Square is a Shape."},
+		{symbol: "Square.Side", wantSig: "Side float64", wantDoc: "Side is the edge length."},
+		{symbol: "Shape.Area", wantSig: "Area() float64"},
+		{symbol: "Square.Area", wantSig: "func (s Square) Area() float64", wantDoc: "Area returns the square's area."},
+	}
+	for _, tt := range tests {
+		d, ok := bySymbol[tt.symbol]
+		if !ok {
+			t.Errorf("no definition for %q; got %+v", tt.symbol, defs)
+			continue
+		}
+		if d.File != "shape/shape.go" {
+			t.Errorf("%s: File = %q, want shape/shape.go", tt.symbol, d.File)
+		}
+		if !strings.Contains(d.Signature, tt.wantSig) {
+			t.Errorf("%s: Signature = %q, want to contain %q", tt.symbol, d.Signature, tt.wantSig)
+		}
+		if tt.wantDoc != "" && d.Docstring != tt.wantDoc {
+			t.Errorf("%s: Docstring = %q, want %q", tt.symbol, d.Docstring, tt.wantDoc)
+		}
+	}
+	if d := bySymbol["Square.Side"]; strings.Contains(d.Signature, "\n") {
+		t.Errorf("field signature should be a single line; got %q", d.Signature)
+	}
+	if _, ok := bySymbol["sum"]; ok {
+		t.Errorf("local variable should not be resolved; got %+v", defs)
+	}
+}
+
+func TestResolveSymbols_typeInfo_loadFailure_fallsBackToGrep(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	// No go.mod: go/packages cannot load ./..., so the grep path must answer.
+	writeFiles(t, dir, map[string]string{
+		"pkg/bar.go": "package pkg\n\n// Bar returns one.\nfunc Bar() int {\n\treturn 1\n}\n",
+	})
+	gitAdd(t, dir, "pkg")
+	hunk := "@@ -1,1 +1,1 @@\n+x := Bar()\n"
+	defs, err := New().ResolveSymbols(ctx, dir, "pkg/use.go", hunk, rag.ResolveOptions{MaxDefinitions: 5, TypeInfo: true})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 1 || defs[0].Symbol != "Bar" || defs[0].File != "pkg/bar.go" {
+		t.Errorf("expected grep fallback to find Bar in pkg/bar.go; got %+v", defs)
+	}
+}
+
+func TestLoadTypeIndex_errorsAndCancellationNotCached(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pkg/bar.go": "package pkg\n\n// Bar returns one.\nfunc Bar() int {\n\treturn 1\n}\n",
+	})
+	if _, err := loadTypeIndex(context.Background(), dir); err == nil {
+		t.Fatal("loadTypeIndex without go.mod: want error")
+	}
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/pkg\n\ngo 1.22\n"})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := loadTypeIndex(cancelled, dir); err == nil {
+		t.Fatal("loadTypeIndex with cancelled context: want error")
+	}
+	idx, err := loadTypeIndex(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("loadTypeIndex after earlier failures: %v", err)
+	}
+	if _, ok := idx.files[filepath.Join(dir, "pkg", "bar.go")]; !ok {
+		t.Errorf("index should contain pkg/bar.go; got %d files", len(idx.files))
+	}
+}
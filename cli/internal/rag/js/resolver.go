@@ -38,6 +38,11 @@ var funcIdent = regexp.MustCompile(`\bfunction\s+(\w+)\s*\(`)
 var typeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
 var callIdent = regexp.MustCompile(`\b([a-zA-Z_$][A-Za-z0-9_$]*)\s*\(`)
 
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`function[[:space:]]+(?P<name>[A-Za-z_$][A-Za-z0-9_$]*)[[:space:]]*\(|const[[:space:]]+(?P<name>[A-Za-z_$][A-Za-z0-9_$]*)[[:space:]]*=` +
+	`|class[[:space:]]+(?P<name>[A-Za-z_$][A-Za-z0-9_$]*)[^a-zA-Z0-9_]|interface[[:space:]]+(?P<name>[A-Za-z_$][A-Za-z0-9_$]*)[^a-zA-Z0-9_]`)
+
 // Resolver implements rag.Resolver for JavaScript and TypeScript.
 type Resolver struct{}
 
@@ -54,6 +59,17 @@ func New() *Resolver {
 	return &Resolver{}
 }
 
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "js"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
 // ResolveSymbols extracts symbols from hunkContent and looks up their
 // definitions in the repo. Returns up to opts.MaxDefinitions; total size
 // may be capped by opts.MaxTokens.
@@ -66,7 +82,7 @@ func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkC
 	if maxDefs <= 0 {
 		maxDefs = 10
 	}
-	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs)
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
 	if err != nil || len(defs) == 0 {
 		return nil, err
 	}
@@ -98,7 +114,7 @@ func extractSymbols(hunkContent string) []string {
 	return list
 }
 
-func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int) ([]rag.Definition, error) {
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
 	absRepo, err := filepath.Abs(repoRoot)
 	if err != nil {
 		return nil, err
@@ -112,7 +128,7 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 		if seen[sym] {
 			continue
 		}
-		path, line, content, err := gitGrepSymbol(ctx, absRepo, sym)
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
 		if err != nil || path == "" {
 			continue
 		}
@@ -134,6 +150,20 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 	return defs, nil
 }
 
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("js", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
 func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
 	quoted := regexp.QuoteMeta(symbol)
 	// Use POSIX [[:space:]] and [^a-zA-Z0-9_] so git grep -E works on macOS/BSD.
@@ -0,0 +1,343 @@
+// Package kotlin implements RAG symbol resolution for Kotlin files: extract
+// symbols from hunk content and look up definitions via git grep.
+package kotlin
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/rag"
+)
+
+const (
+	grepTimeout              = 5 * time.Second
+	maxSymbolCandidates      = 30
+	maxPrecedingCommentLines = 5
+)
+
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "false": true, "for": true, "fun": true, "if": true,
+	"in": true, "interface": true, "is": true, "null": true, "object": true,
+	"package": true, "return": true, "super": true, "this": true, "throw": true,
+	"true": true, "try": true, "typealias": true, "typeof": true, "val": true,
+	"var": true, "when": true, "while": true, "by": true, "catch": true,
+	"constructor": true, "finally": true, "get": true, "import": true, "init": true,
+	"set": true, "where": true, "abstract": true, "companion": true, "const": true,
+	"data": true, "enum": true, "inline": true, "internal": true, "lateinit": true,
+	"open": true, "override": true, "private": true, "protected": true, "public": true,
+	"sealed": true, "suspend": true, "vararg": true,
+}
+
+var (
+	reFun       = regexp.MustCompile(`\bfun\s+(?:<[^>]*>\s*)?(?:[\w.]+\.)?(\w+)\s*\(`)
+	reTypeDecl  = regexp.MustCompile(`\b(?:class|interface|object|typealias)\s+(\w+)`)
+	reCall      = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*(?:<[^<>()]*>)?\s*[({]`)
+	reTypeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
+)
+
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`(?:class|interface|object|typealias)[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[^a-zA-Z0-9_]|$)` +
+	`|fun[[:space:]]+(?:<[^>]*>[[:space:]]*)?(?:[A-Za-z0-9_.<>?]+\.)?(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*\(` +
+	`|const[[:space:]]+val[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)[^a-zA-Z0-9_]`)
+
+// Resolver implements rag.Resolver for Kotlin.
+type Resolver struct{}
+
+func init() {
+	rag.MustRegisterResolver(".kt", New())
+	rag.MustRegisterResolver(".kts", New())
+}
+
+// New returns a new Kotlin symbol resolver.
+func New() *Resolver {
+	return &Resolver{}
+}
+
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "kotlin"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
+// ResolveSymbols extracts symbols from hunkContent and looks up their
+// definitions in the repo. Returns up to opts.MaxDefinitions; total size
+// may be capped by opts.MaxTokens.
+func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts rag.ResolveOptions) ([]rag.Definition, error) {
+	symbols := extractSymbols(hunkContent)
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	maxDefs := opts.MaxDefinitions
+	if maxDefs <= 0 {
+		maxDefs = 10
+	}
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
+	if err != nil || len(defs) == 0 {
+		return nil, err
+	}
+	if opts.MaxTokens > 0 {
+		defs = capDefinitionsByTokens(defs, opts.MaxTokens)
+	}
+	return defs, nil
+}
+
+func extractSymbols(hunkContent string) []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, re := range []*regexp.Regexp{reFun, reTypeDecl, reCall, reTypeIdent} {
+		for _, m := range re.FindAllStringSubmatch(hunkContent, -1) {
+			if len(m) < 2 {
+				continue
+			}
+			name := m[1]
+			if kotlinKeywords[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			list = append(list, name)
+			if len(list) >= maxSymbolCandidates {
+				return list
+			}
+		}
+	}
+	return list
+}
+
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
+	absRepo, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var defs []rag.Definition
+	for _, sym := range symbols {
+		if len(defs) >= maxDefs {
+			break
+		}
+		if seen[sym] {
+			continue
+		}
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
+		if err != nil || path == "" {
+			continue
+		}
+		seen[sym] = true
+		relPath, _ := filepath.Rel(absRepo, path)
+		relPath = filepath.ToSlash(relPath)
+		sig, doc := readSignatureAndDoc(path, line, content)
+		if sig == "" {
+			sig = strings.TrimSpace(content)
+		}
+		defs = append(defs, rag.Definition{
+			Symbol:    sym,
+			File:      relPath,
+			Line:      line,
+			Signature: sig,
+			Docstring: doc,
+		})
+	}
+	return defs, nil
+}
+
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("kotlin", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
+func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
+	quoted := regexp.QuoteMeta(symbol)
+	// POSIX [[:space:]] for macOS/BSD git grep -E. Match: class/interface/object/typealias Symbol
+	// (covers data/enum/sealed class), fun Symbol(, generic fun <T> Symbol(, extension
+	// fun Type.Symbol(, and top-level const val Symbol.
+	pattern := `((class|interface|object|typealias)[[:space:]]+` + quoted + `([^a-zA-Z0-9_]|$)` +
+		`|fun[[:space:]]+(<[^>]*>[[:space:]]*)?([A-Za-z0-9_.<>?]+\.)?` + quoted + `[[:space:]]*\(` +
+		`|const[[:space:]]+val[[:space:]]+` + quoted + `[^a-zA-Z0-9_])`
+	ctx, cancel := context.WithTimeout(ctx, grepTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "grep", "-n", "-E", pattern)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv(repoRoot)
+	out, err := cmd.Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 {
+			return "", 0, "", nil
+		}
+		return "", 0, "", err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return "", 0, "", nil
+	}
+	first := strings.SplitN(trimmed, "\n", 2)[0]
+	idx := strings.Index(first, ":")
+	if idx == -1 {
+		return "", 0, "", nil
+	}
+	path := first[:idx]
+	if path == "" || strings.Contains(path, "..") {
+		return "", 0, "", nil
+	}
+	rest := first[idx+1:]
+	idx2 := strings.Index(rest, ":")
+	if idx2 == -1 {
+		return "", 0, "", nil
+	}
+	lineno, errParse := strconv.Atoi(rest[:idx2])
+	if errParse != nil || lineno < 1 {
+		return "", 0, "", nil
+	}
+	lineContent = rest[idx2+1:]
+	absPath = filepath.Join(repoRoot, path)
+	// Ensure resolved path is under repo root (defense in depth; matches Go resolver).
+	rel, errRel := filepath.Rel(repoRoot, absPath)
+	if errRel != nil || strings.HasPrefix(rel, "..") || rel == ".." {
+		return "", 0, "", nil
+	}
+	return absPath, lineno, lineContent, nil
+}
+
+func minimalEnv(repoRoot string) []string {
+	gitDir := filepath.Join(repoRoot, ".git")
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_DIR=" + gitDir,
+		"GIT_WORK_TREE=" + repoRoot,
+	}
+}
+
+// readSignatureAndDoc reads the file at path, line (1-based). Signature = declaration
+// line(s) up to { or an expression body (=). Docstring = preceding KDoc (/** */) or // lines.
+func readSignatureAndDoc(absPath string, lineNum int, declarationLine string) (signature, docstring string) {
+	f, err := os.Open(absPath)
+	if err != nil {
+		return declarationLine, ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	var lines []string
+	line := 0
+	for sc.Scan() {
+		line++
+		lines = append(lines, sc.Text())
+		if line >= lineNum+15 {
+			break
+		}
+	}
+	if lineNum < 1 || lineNum > len(lines) {
+		return strings.TrimSpace(declarationLine), ""
+	}
+	sigLine := lines[lineNum-1]
+	var sigBuilder strings.Builder
+	sigBuilder.WriteString(strings.TrimSpace(sigLine))
+	if !endsDeclaration(sigLine) {
+		for i := lineNum; i < len(lines) && i < lineNum+5; i++ {
+			sigBuilder.WriteString("\n")
+			sigBuilder.WriteString(lines[i])
+			if endsDeclaration(lines[i]) {
+				break
+			}
+		}
+	}
+	signature = strings.TrimSpace(sigBuilder.String())
+	docstring = precedingComment(lines, lineNum)
+	return signature, docstring
+}
+
+// endsDeclaration reports whether line closes a Kotlin declaration header: an opening
+// brace, an expression body after the parameter list, or a one-line declaration
+// without parameters or with a closed parameter list.
+func endsDeclaration(line string) bool {
+	s := strings.TrimSpace(line)
+	if strings.Contains(s, "{") {
+		return true
+	}
+	if i := strings.LastIndex(s, ")"); i >= 0 && strings.Contains(s[i:], "=") {
+		return true
+	}
+	return strings.Count(s, "(") == strings.Count(s, ")") && !strings.HasSuffix(s, ",")
+}
+
+// precedingComment collects up to maxPrecedingCommentLines of // or /* */ comment
+// lines directly above lineNum (1-based). Block comment markers are stripped.
+func precedingComment(lines []string, lineNum int) string {
+	var docLines []string
+	inBlock := false
+	for i := lineNum - 2; i >= 0 && i >= lineNum-1-maxPrecedingCommentLines; i-- {
+		s := strings.TrimSpace(lines[i])
+		if inBlock {
+			start := strings.HasPrefix(s, "/*")
+			s = strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!")
+			if s = strings.TrimSpace(s); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			if start {
+				break
+			}
+			continue
+		}
+		if s == "" {
+			break
+		}
+		if strings.HasPrefix(s, "//") {
+			docLines = append([]string{strings.TrimSpace(strings.TrimLeft(s, "/!"))}, docLines...)
+			continue
+		}
+		if strings.HasSuffix(s, "*/") {
+			s = strings.TrimSpace(strings.TrimSuffix(s, "*/"))
+			if strings.HasPrefix(s, "/*") {
+				s = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!"))
+				if s != "" {
+					docLines = append([]string{s}, docLines...)
+				}
+				break
+			}
+			inBlock = true
+			if s = strings.TrimSpace(strings.TrimLeft(s, "*")); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			continue
+		}
+		break
+	}
+	return strings.Join(docLines, "\n")
+}
+
+func capDefinitionsByTokens(defs []rag.Definition, maxTokens int) []rag.Definition {
+	estimate := func(s string) int {
+		return (len(s) + 3) / 4
+	}
+	used := 0
+	for i := range defs {
+		d := &defs[i]
+		n := estimate(d.Signature) + estimate(d.Docstring)
+		if used+n > maxTokens && i > 0 {
+			return defs[:i]
+		}
+		used += n
+	}
+	return defs
+}
@@ -0,0 +1,188 @@
+package kotlin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/rag"
+)
+
+func TestResolveSymbols_definitions(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `package app
+
+/**
+ * Sends greetings.
+ */
+class Greeter(private val prefix: String) {
+    // Builds the greeting text.
+    fun greet(name: String): String {
+        return prefix + name
+    }
+}
+
+fun String.shout(): String = uppercase()
+
+data class Point(val x: Int, val y: Int)
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "Greeter.kt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "src/Greeter.kt")
+
+	tests := []struct {
+		name    string
+		hunk    string
+		symbol  string
+		wantSig string
+		wantDoc string
+	}{
+		{name: "class with kdoc", hunk: "val g = Greeter(\"hi \")", symbol: "Greeter", wantSig: "class Greeter(private val prefix: String) {", wantDoc: "Sends greetings."},
+		{name: "member function", hunk: "g.greet(\"x\")", symbol: "greet", wantSig: "fun greet(name: String): String {", wantDoc: "Builds the greeting text."},
+		{name: "extension function", hunk: "name.shout()", symbol: "shout", wantSig: "fun String.shout(): String = uppercase()"},
+		{name: "data class", hunk: "val p = Point(1, 2)", symbol: "Point", wantSig: "data class Point(val x: Int, val y: Int)"},
+	}
+	r := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := r.ResolveSymbols(ctx, dir, "src/Main.kt", tt.hunk, rag.ResolveOptions{MaxDefinitions: 5})
+			if err != nil {
+				t.Fatalf("ResolveSymbols: %v", err)
+			}
+			var got *rag.Definition
+			for i := range defs {
+				if defs[i].Symbol == tt.symbol {
+					got = &defs[i]
+					break
+				}
+			}
+			if got == nil {
+				t.Fatalf("no definition for %q; got %+v", tt.symbol, defs)
+			}
+			if want := "src/Greeter.kt"; got.File != want {
+				t.Errorf("File = %q, want %q", got.File, want)
+			}
+			if !strings.Contains(got.Signature, tt.wantSig) {
+				t.Errorf("Signature = %q, want to contain %q", got.Signature, tt.wantSig)
+			}
+			if tt.wantDoc != "" && got.Docstring != tt.wantDoc {
+				t.Errorf("Docstring = %q, want %q", got.Docstring, tt.wantDoc)
+			}
+		})
+	}
+}
+
+func TestResolveSymbols_maxN_returnsAtMostN(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	content := `fun a() {}
+fun b() {}
+fun c() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "X.kt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitAdd(t, dir, "X.kt")
+
+	defs, err := New().ResolveSymbols(ctx, dir, "X.kt", "a(); b(); c()", rag.ResolveOptions{MaxDefinitions: 2})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 2 {
+		t.Errorf("expected 2 definitions; got %d", len(defs))
+	}
+}
+
+func TestResolveSymbols_noMatch_returnsNil(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	defs, err := New().ResolveSymbols(ctx, dir, "X.kt", "NonExistent()", rag.ResolveOptions{MaxDefinitions: 5})
+	if err != nil {
+		t.Fatalf("ResolveSymbols: %v", err)
+	}
+	if len(defs) != 0 {
+		t.Errorf("expected 0 definitions; got %d", len(defs))
+	}
+}
+
+func TestExtractSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		hunk    string
+		want    []string
+		notWant []string
+	}{
+		{name: "calls and types", hunk: "val w = Widget(); w.draw(); add(1, 2)", want: []string{"Widget", "draw", "add"}},
+		{name: "declarations", hunk: "fun <T> List<T>.second(): T = this[1]\nobject Registry", want: []string{"second", "Registry"}},
+		{name: "trailing lambda", hunk: "items.forEach { println(it) }", want: []string{"forEach", "println"}},
+		{name: "skips keywords", hunk: "if (x) { return when (y) { else -> null } }", notWant: []string{"if", "return", "when", "else"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSymbols(tt.hunk)
+			seen := make(map[string]bool)
+			for _, s := range got {
+				if seen[s] {
+					t.Errorf("duplicate symbol %q", s)
+				}
+				seen[s] = true
+			}
+			for _, w := range tt.want {
+				if !seen[w] {
+					t.Errorf("expected %q in %v", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if seen[w] {
+					t.Errorf("did not expect %q in %v", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCapDefinitionsByTokens(t *testing.T) {
+	defs := []rag.Definition{
+		{Symbol: "a", Signature: strings.Repeat("x", 40)},
+		{Symbol: "b", Signature: strings.Repeat("y", 40)},
+	}
+	got := capDefinitionsByTokens(defs, 15)
+	if len(got) != 1 {
+		t.Errorf("capDefinitionsByTokens: got %d definitions, want 1", len(got))
+	}
+}
+
+func initGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@test")
+	runGit(t, dir, "config", "user.name", "Test")
+}
+
+func gitAdd(t *testing.T, dir, path string) {
+	t.Helper()
+	runGit(t, dir, "add", path)
+	runGit(t, dir, "commit", "-m", "add")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
@@ -0,0 +1,343 @@
+// Package php implements RAG symbol resolution for PHP files: extract symbols
+// from hunk content and look up definitions via git grep.
+package php
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/rag"
+)
+
+const (
+	grepTimeout              = 5 * time.Second
+	maxSymbolCandidates      = 30
+	maxPrecedingCommentLines = 5
+)
+
+var phpKeywords = map[string]bool{
+	"abstract": true, "and": true, "array": true, "as": true, "break": true,
+	"callable": true, "case": true, "catch": true, "class": true, "clone": true,
+	"const": true, "continue": true, "declare": true, "default": true, "do": true,
+	"echo": true, "else": true, "elseif": true, "empty": true, "enddeclare": true,
+	"endfor": true, "endforeach": true, "endif": true, "endswitch": true, "endwhile": true,
+	"enum": true, "extends": true, "final": true, "finally": true, "fn": true,
+	"for": true, "foreach": true, "function": true, "global": true, "goto": true,
+	"if": true, "implements": true, "include": true, "include_once": true, "instanceof": true,
+	"insteadof": true, "interface": true, "isset": true, "list": true, "match": true,
+	"namespace": true, "new": true, "or": true, "print": true, "private": true,
+	"protected": true, "public": true, "readonly": true, "require": true, "require_once": true,
+	"return": true, "static": true, "switch": true, "throw": true, "trait": true,
+	"try": true, "unset": true, "use": true, "var": true, "while": true,
+	"xor": true, "yield": true, "self": true, "parent": true, "null": true,
+	"true": true, "false": true, "this": true,
+}
+
+var (
+	reNew       = regexp.MustCompile(`\bnew\s+\\?(?:\w+\\)*(\w+)`)
+	reStatic    = regexp.MustCompile(`\b(\w+)::`)
+	reMember    = regexp.MustCompile(`(?:->|::)(\w+)\s*\(`)
+	reCall      = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*\(`)
+	reTypeIdent = regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)\b`)
+)
+
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`(?i)function[[:space:]]+&?[[:space:]]*(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*\(` +
+	`|(?:class|interface|trait|enum)[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)(?:[^a-zA-Z0-9_]|$)` +
+	`|const[[:space:]]+(?:[A-Za-z_?]+[[:space:]]+)?(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*=`)
+
+// Resolver implements rag.Resolver for PHP.
+type Resolver struct{}
+
+func init() {
+	rag.MustRegisterResolver(".php", New())
+}
+
+// New returns a new PHP symbol resolver.
+func New() *Resolver {
+	return &Resolver{}
+}
+
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "php"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+// Names are lowercased because PHP identifiers are matched case-insensitively.
+func (r *Resolver) DefinedNames(line string) []string {
+	names := rag.MatchDefinedNames(defNamePattern, line)
+	for i, n := range names {
+		names[i] = strings.ToLower(n)
+	}
+	return names
+}
+
+// ResolveSymbols extracts symbols from hunkContent and looks up their
+// definitions in the repo. Returns up to opts.MaxDefinitions; total size
+// may be capped by opts.MaxTokens.
+func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts rag.ResolveOptions) ([]rag.Definition, error) {
+	symbols := extractSymbols(hunkContent)
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	maxDefs := opts.MaxDefinitions
+	if maxDefs <= 0 {
+		maxDefs = 10
+	}
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
+	if err != nil || len(defs) == 0 {
+		return nil, err
+	}
+	if opts.MaxTokens > 0 {
+		defs = capDefinitionsByTokens(defs, opts.MaxTokens)
+	}
+	return defs, nil
+}
+
+func extractSymbols(hunkContent string) []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, re := range []*regexp.Regexp{reNew, reStatic, reMember, reCall, reTypeIdent} {
+		for _, m := range re.FindAllStringSubmatch(hunkContent, -1) {
+			if len(m) < 2 {
+				continue
+			}
+			name := m[1]
+			// PHP keywords and built-in names are case-insensitive.
+			if phpKeywords[strings.ToLower(name)] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			list = append(list, name)
+			if len(list) >= maxSymbolCandidates {
+				return list
+			}
+		}
+	}
+	return list
+}
+
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
+	absRepo, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var defs []rag.Definition
+	for _, sym := range symbols {
+		if len(defs) >= maxDefs {
+			break
+		}
+		if seen[sym] {
+			continue
+		}
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
+		if err != nil || path == "" {
+			continue
+		}
+		seen[sym] = true
+		relPath, _ := filepath.Rel(absRepo, path)
+		relPath = filepath.ToSlash(relPath)
+		sig, doc := readSignatureAndDoc(path, line, content)
+		if sig == "" {
+			sig = strings.TrimSpace(content)
+		}
+		defs = append(defs, rag.Definition{
+			Symbol:    sym,
+			File:      relPath,
+			Line:      line,
+			Signature: sig,
+			Docstring: doc,
+		})
+	}
+	return defs, nil
+}
+
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("php", strings.ToLower(symbol))
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
+func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
+	quoted := regexp.QuoteMeta(symbol)
+	// POSIX [[:space:]] for macOS/BSD git grep -E. Match: function [&]Symbol( (functions and
+	// methods), class/interface/trait/enum Symbol, and const Symbol =. Case-insensitive
+	// because PHP function and class names are.
+	pattern := `(function[[:space:]]+&?[[:space:]]*` + quoted + `[[:space:]]*\(` +
+		`|(class|interface|trait|enum)[[:space:]]+` + quoted + `([^a-zA-Z0-9_]|$)` +
+		`|const[[:space:]]+([A-Za-z_?]+[[:space:]]+)?` + quoted + `[[:space:]]*=)`
+	ctx, cancel := context.WithTimeout(ctx, grepTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "grep", "-n", "-i", "-E", pattern)
+	cmd.Dir = repoRoot
+	cmd.Env = minimalEnv(repoRoot)
+	out, err := cmd.Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 {
+			return "", 0, "", nil
+		}
+		return "", 0, "", err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return "", 0, "", nil
+	}
+	first := strings.SplitN(trimmed, "\n", 2)[0]
+	idx := strings.Index(first, ":")
+	if idx == -1 {
+		return "", 0, "", nil
+	}
+	path := first[:idx]
+	if path == "" || strings.Contains(path, "..") {
+		return "", 0, "", nil
+	}
+	rest := first[idx+1:]
+	idx2 := strings.Index(rest, ":")
+	if idx2 == -1 {
+		return "", 0, "", nil
+	}
+	lineno, errParse := strconv.Atoi(rest[:idx2])
+	if errParse != nil || lineno < 1 {
+		return "", 0, "", nil
+	}
+	lineContent = rest[idx2+1:]
+	absPath = filepath.Join(repoRoot, path)
+	// Ensure resolved path is under repo root (defense in depth; matches Go resolver).
+	rel, errRel := filepath.Rel(repoRoot, absPath)
+	if errRel != nil || strings.HasPrefix(rel, "..") || rel == ".." {
+		return "", 0, "", nil
+	}
+	return absPath, lineno, lineContent, nil
+}
+
+func minimalEnv(repoRoot string) []string {
+	gitDir := filepath.Join(repoRoot, ".git")
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_DIR=" + gitDir,
+		"GIT_WORK_TREE=" + repoRoot,
+	}
+}
+
+// readSignatureAndDoc reads the file at path, line (1-based). Signature = declaration
+// line(s) up to { or ;. Docstring = preceding PHPDoc (/** */), // or # lines.
+func readSignatureAndDoc(absPath string, lineNum int, declarationLine string) (signature, docstring string) {
+	f, err := os.Open(absPath)
+	if err != nil {
+		return declarationLine, ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	var lines []string
+	line := 0
+	for sc.Scan() {
+		line++
+		lines = append(lines, sc.Text())
+		if line >= lineNum+15 {
+			break
+		}
+	}
+	if lineNum < 1 || lineNum > len(lines) {
+		return strings.TrimSpace(declarationLine), ""
+	}
+	sigLine := lines[lineNum-1]
+	var sigBuilder strings.Builder
+	sigBuilder.WriteString(strings.TrimSpace(sigLine))
+	if !strings.Contains(sigLine, "{") && !strings.Contains(sigLine, ";") {
+		for i := lineNum; i < len(lines) && i < lineNum+5; i++ {
+			sigBuilder.WriteString("\n")
+			sigBuilder.WriteString(lines[i])
+			if strings.Contains(lines[i], "{") || strings.Contains(lines[i], ";") {
+				break
+			}
+		}
+	}
+	signature = strings.TrimSpace(sigBuilder.String())
+	docstring = precedingComment(lines, lineNum)
+	return signature, docstring
+}
+
+// precedingComment collects up to maxPrecedingCommentLines of //, # or /* */ comment
+// lines directly above lineNum (1-based). Block comment markers are stripped.
+func precedingComment(lines []string, lineNum int) string {
+	var docLines []string
+	inBlock := false
+	for i := lineNum - 2; i >= 0 && i >= lineNum-1-maxPrecedingCommentLines; i-- {
+		s := strings.TrimSpace(lines[i])
+		if inBlock {
+			start := strings.HasPrefix(s, "/*")
+			s = strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!")
+			if s = strings.TrimSpace(s); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			if start {
+				break
+			}
+			continue
+		}
+		if s == "" {
+			break
+		}
+		if strings.HasPrefix(s, "//") {
+			docLines = append([]string{strings.TrimSpace(strings.TrimLeft(s, "/!"))}, docLines...)
+			continue
+		}
+		if strings.HasPrefix(s, "#") && !strings.HasPrefix(s, "#[") {
+			docLines = append([]string{strings.TrimSpace(s[1:])}, docLines...)
+			continue
+		}
+		if strings.HasSuffix(s, "*/") {
+			s = strings.TrimSpace(strings.TrimSuffix(s, "*/"))
+			if strings.HasPrefix(s, "/*") {
+				s = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(s, "/*"), "*!"))
+				if s != "" {
+					docLines = append([]string{s}, docLines...)
+				}
+				break
+			}
+			inBlock = true
+			if s = strings.TrimSpace(strings.TrimLeft(s, "*")); s != "" {
+				docLines = append([]string{s}, docLines...)
+			}
+			continue
+		}
+		break
+	}
+	return strings.Join(docLines, "\n")
+}
+
+func capDefinitionsByTokens(defs []rag.Definition, maxTokens int) []rag.Definition {
+	estimate := func(s string) int {
+		return (len(s) + 3) / 4
+	}
+	used := 0
+	for i := range defs {
+		d := &defs[i]
+		n := estimate(d.Signature) + estimate(d.Docstring)
+		if used+n > maxTokens && i > 0 {
+			return defs[:i]
+		}
+		used += n
+	}
+	return defs
+}
@@ -36,6 +36,10 @@ var defIdent = regexp.MustCompile(`\bdef\s+(\w+)\s*\(`)
 var classIdent = regexp.MustCompile(`\bclass\s+(\w+)\s*[:(]`)
 var callIdent = regexp.MustCompile(`\b(\w+)\s*\(`)
 
+// defNamePattern is the git grep definition pattern with the symbol replaced by
+// a "name" group; it feeds the symbol index (see DefinedNames).
+var defNamePattern = regexp.MustCompile(`def[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*\(|class[[:space:]]+(?P<name>[A-Za-z_][A-Za-z0-9_]*)[[:space:]]*[:(]`)
+
 // Resolver implements rag.Resolver for Python.
 type Resolver struct{}
 
@@ -49,6 +53,17 @@ func New() *Resolver {
 	return &Resolver{}
 }
 
+// IndexLanguage implements rag.DefinitionIndexer.
+func (r *Resolver) IndexLanguage() string {
+	return "python"
+}
+
+// DefinedNames implements rag.DefinitionIndexer: the names line defines
+// according to the same pattern git grep uses for lookups.
+func (r *Resolver) DefinedNames(line string) []string {
+	return rag.MatchDefinedNames(defNamePattern, line)
+}
+
 // ResolveSymbols extracts symbols from hunkContent and looks up their
 // definitions in the repo. Returns up to opts.MaxDefinitions; total size
 // may be capped by opts.MaxTokens.
@@ -61,7 +76,7 @@ func (r *Resolver) ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkC
 	if maxDefs <= 0 {
 		maxDefs = 10
 	}
-	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs)
+	defs, err := lookupDefinitions(ctx, repoRoot, filePath, symbols, maxDefs, opts.Index)
 	if err != nil || len(defs) == 0 {
 		return nil, err
 	}
@@ -93,7 +108,7 @@ func extractSymbols(hunkContent string) []string {
 	return list
 }
 
-func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int) ([]rag.Definition, error) {
+func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols []string, maxDefs int, idx rag.SymbolIndex) ([]rag.Definition, error) {
 	absRepo, err := filepath.Abs(repoRoot)
 	if err != nil {
 		return nil, err
@@ -107,7 +122,7 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 		if seen[sym] {
 			continue
 		}
-		path, line, content, err := gitGrepSymbol(ctx, absRepo, sym)
+		path, line, content, err := findDefinition(ctx, absRepo, sym, idx)
 		if err != nil || path == "" {
 			continue
 		}
@@ -129,6 +144,20 @@ func lookupDefinitions(ctx context.Context, repoRoot, fromFile string, symbols [
 	return defs, nil
 }
 
+// findDefinition looks symbol up in idx when set and falls back to git grep
+// on a miss (the index skips large files and long lines). Returns absPath,
+// line, lineContent like gitGrepSymbol.
+func findDefinition(ctx context.Context, repoRoot, symbol string, idx rag.SymbolIndex) (absPath string, line int, lineContent string, err error) {
+	if idx == nil {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	path, line, lineContent, ok := idx.Lookup("python", symbol)
+	if !ok {
+		return gitGrepSymbol(ctx, repoRoot, symbol)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(path)), line, lineContent, nil
+}
+
 func gitGrepSymbol(ctx context.Context, repoRoot, symbol string) (absPath string, line int, lineContent string, err error) {
 	// Match: def symbol( or class symbol. Use POSIX [[:space:]] so git grep -E works on macOS/BSD.
 	quoted := regexp.QuoteMeta(symbol)
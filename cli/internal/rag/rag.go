@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"path/filepath"
+	"regexp"
 	"sync"
 )
 
@@ -23,9 +24,14 @@ type Definition struct {
 }
 
 // ResolveOptions bounds symbol resolution: max definitions and optional token cap.
+// TypeInfo asks resolvers that support it (Go) to use type-checked resolution
+// instead of grep; resolvers fall back to grep when type information is unavailable.
+// Index, when non-nil, is queried instead of running git grep per symbol.
 type ResolveOptions struct {
-	MaxDefinitions int // max number of definitions to return (0 = no limit)
-	MaxTokens      int // max tokens for combined definitions; 0 = no cap
+	MaxDefinitions int         // max number of definitions to return (0 = no limit)
+	MaxTokens      int         // max tokens for combined definitions; 0 = no cap
+	TypeInfo       bool        // use type information when the resolver supports it
+	Index          SymbolIndex // optional prebuilt symbol index; nil = git grep
 }
 
 // CallGraphResult holds upstream callers and downstream callees for the
@@ -37,9 +43,10 @@ type CallGraphResult struct {
 
 // CallGraphOptions bounds call-graph resolution: max callers, max callees, and optional token cap.
 type CallGraphOptions struct {
-	CallersMax int // max call sites to return (0 = use default)
-	CalleesMax int // max callees to return (0 = use default)
-	MaxTokens  int // max tokens for the combined block; 0 = no cap
+	CallersMax int         // max call sites to return (0 = use default)
+	CalleesMax int         // max callees to return (0 = use default)
+	MaxTokens  int         // max tokens for the combined block; 0 = no cap
+	Index      SymbolIndex // optional prebuilt symbol index for callee lookups; nil = git grep
 }
 
 // Resolver looks up symbols used in a hunk and returns their definitions.
@@ -48,6 +55,54 @@ type Resolver interface {
 	ResolveSymbols(ctx context.Context, repoRoot, filePath, hunkContent string, opts ResolveOptions) ([]Definition, error)
 }
 
+// SymbolIndex serves definition lookups from a prebuilt, ctags-like index so
+// resolvers do not need one git grep per symbol. Lookup returns the first
+// definition of name (ordered by path, then line) among files indexed under
+// language; path is relative to the repo root with forward slashes. The index
+// skips large files and very long lines, so resolvers fall back to git grep on
+// a miss.
+type SymbolIndex interface {
+	Lookup(language, name string) (path string, line int, lineContent string, found bool)
+}
+
+// DefinitionIndexer is implemented by resolvers whose definitions can be
+// indexed ahead of time. IndexLanguage groups extensions that share
+// definitions (e.g. "cpp" for .h and .cpp); DefinedNames returns the names a
+// single source line defines, mirroring the resolver's git grep pattern.
+type DefinitionIndexer interface {
+	IndexLanguage() string
+	DefinedNames(line string) []string
+}
+
+// IndexerFor returns the DefinitionIndexer for the resolver registered for ext,
+// or (nil, false) when there is none or it does not support indexing.
+func IndexerFor(ext string) (DefinitionIndexer, bool) {
+	registryMu.RLock()
+	r, ok := registry[ext]
+	registryMu.RUnlock()
+	if !ok || r == nil {
+		return nil, false
+	}
+	ix, ok := r.(DefinitionIndexer)
+	return ix, ok
+}
+
+// MatchDefinedNames returns the non-empty submatches of every capture group
+// named "name" in re, across all matches in line. Resolvers use it to
+// implement DefinedNames from a pattern with the symbol replaced by a group.
+func MatchDefinedNames(re *regexp.Regexp, line string) []string {
+	var names []string
+	groups := re.SubexpNames()
+	for _, m := range re.FindAllStringSubmatch(line, -1) {
+		for i, g := range groups {
+			if g == "name" && m[i] != "" {
+				names = append(names, m[i])
+			}
+		}
+	}
+	return names
+}
+
 var (
 	registry   = make(map[string]Resolver)
 	registryMu sync.RWMutex
@@ -0,0 +1,468 @@
+// Package symindex builds and persists a ctags-like symbol index for RAG
+// lookups. For every tracked file whose resolver implements
+// rag.DefinitionIndexer, the index records the names each line defines, so
+// resolvers can answer a lookup from memory instead of running one git grep per
+// symbol. One index is stored per tree SHA under the state dir
+// (.review/symindex/<tree>.json); when HEAD's tree changes, the newest stored
+// index is updated incrementally by re-reading only the files that differ.
+package symindex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/git"
+	"stet/cli/internal/rag"
+)
+
+const (
+	dirName          = "symindex"
+	maxStoredIndexes = 3
+	maxFileSize      = 1024 * 1024 // 1 MiB, same as expand
+	maxLineLen       = 1000        // longer lines (minified or generated code) are not indexed
+	gitTimeout       = 2 * time.Minute
+	// formatVersion is bumped when the file format or a resolver's definition
+	// pattern changes, so stored indexes are rebuilt instead of reused.
+	formatVersion = 1
+)
+
+// Build modes reported in Stats.Mode.
+const (
+	ModeCached      = "cached"
+	ModeIncremental = "incremental"
+	ModeFull        = "full"
+)
+
+// Def is one indexed definition: the defined name, its 1-based line, and the
+// line's text (used as the fallback signature, like git grep's match).
+type Def struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// File holds the definitions of one tracked file and the index language of
+// the resolver that produced them.
+type File struct {
+	Language string `json:"language"`
+	Defs     []Def  `json:"defs"`
+}
+
+// Index is the symbol index for one tree. Files is keyed by repo-relative
+// path with forward slashes. Index implements rag.SymbolIndex.
+type Index struct {
+	Version int             `json:"version"`
+	TreeSHA string          `json:"tree_sha"`
+	Files   map[string]File `json:"files"`
+
+	byName map[string]map[string]location // language -> name -> first definition
+}
+
+type location struct {
+	path string
+	line int
+	text string
+}
+
+// Stats describes how Load obtained the index; printed under --trace.
+type Stats struct {
+	Mode         string        // ModeCached, ModeIncremental, or ModeFull
+	TreeSHA      string        // tree the index describes
+	BaseTreeSHA  string        // stored tree the incremental update started from
+	FilesRead    int           // files read from git to (re)index
+	FilesIndexed int           // files with at least one definition
+	Definitions  int           // total definitions in the index
+	Persisted    bool          // false when writing the index to the state dir failed
+	Duration     time.Duration // wall time of Load
+}
+
+// Load returns the symbol index for HEAD's tree in repoRoot. It reads the
+// stored index for that tree when present, otherwise updates the newest stored
+// index from the files changed between the two trees, otherwise indexes every
+// tracked file. The result is written to stateDir (best effort; see
+// Stats.Persisted) and at most maxStoredIndexes indexes are kept.
+func Load(ctx context.Context, repoRoot, stateDir string) (*Index, Stats, error) {
+	start := time.Now()
+	var st Stats
+	if repoRoot == "" || stateDir == "" {
+		return nil, st, errors.New("symindex: repo root and state dir required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+	tree, err := runGit(ctx, repoRoot, "rev-parse", "HEAD^{tree}")
+	if err != nil {
+		return nil, st, err
+	}
+	tree = strings.TrimSpace(tree)
+	st.TreeSHA = tree
+	dir := filepath.Join(stateDir, dirName)
+	path := filepath.Join(dir, tree+".json")
+
+	idx, err := readIndex(path)
+	if err == nil && idx.TreeSHA == tree {
+		st.Mode = ModeCached
+		st.Persisted = true
+		now := time.Now()
+		_ = os.Chtimes(path, now, now) // keep recently used indexes when pruning
+		idx.finish(&st)
+		st.Duration = time.Since(start)
+		return idx, st, nil
+	}
+
+	var toRead []string
+	if base := newestIndex(dir, tree); base != nil {
+		changed, deleted, diffErr := changedPaths(ctx, repoRoot, base.TreeSHA, tree)
+		if diffErr == nil {
+			idx = base
+			st.Mode = ModeIncremental
+			st.BaseTreeSHA = base.TreeSHA
+			for _, p := range deleted {
+				delete(idx.Files, p)
+			}
+			for _, p := range changed {
+				delete(idx.Files, p)
+			}
+			toRead = changed
+		}
+	}
+	if st.Mode == "" {
+		all, lsErr := runGit(ctx, repoRoot, "ls-tree", "-r", "-z", "--name-only", tree)
+		if lsErr != nil {
+			return nil, st, lsErr
+		}
+		idx = &Index{Files: make(map[string]File)}
+		st.Mode = ModeFull
+		toRead = splitNUL(all)
+	}
+	idx.Version = formatVersion
+	idx.TreeSHA = tree
+	toRead = indexablePaths(toRead)
+	st.FilesRead = len(toRead)
+	if err := indexBlobs(ctx, repoRoot, tree, toRead, idx.Files); err != nil {
+		return nil, st, err
+	}
+	st.Persisted = writeIndex(dir, path, idx) == nil
+	pruneIndexes(dir, maxStoredIndexes)
+	idx.finish(&st)
+	st.Duration = time.Since(start)
+	return idx, st, nil
+}
+
+// Lookup implements rag.SymbolIndex: the first definition of name (by path,
+// then line) among files indexed under language.
+func (ix *Index) Lookup(language, name string) (path string, line int, lineContent string, found bool) {
+	if ix == nil {
+		return "", 0, "", false
+	}
+	loc, ok := ix.byName[language][name]
+	if !ok {
+		return "", 0, "", false
+	}
+	return loc.path, loc.line, loc.text, true
+}
+
+// finish builds the lookup table (first definition per language and name, in
+// git grep order) and fills the count fields of st.
+func (ix *Index) finish(st *Stats) {
+	paths := make([]string, 0, len(ix.Files))
+	for p := range ix.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	ix.byName = make(map[string]map[string]location)
+	for _, p := range paths {
+		f := ix.Files[p]
+		if len(f.Defs) == 0 {
+			continue
+		}
+		st.FilesIndexed++
+		st.Definitions += len(f.Defs)
+		names := ix.byName[f.Language]
+		if names == nil {
+			names = make(map[string]location)
+			ix.byName[f.Language] = names
+		}
+		for _, d := range f.Defs {
+			if _, ok := names[d.Name]; !ok {
+				names[d.Name] = location{path: p, line: d.Line, text: d.Text}
+			}
+		}
+	}
+}
+
+// indexablePaths keeps paths whose extension has a DefinitionIndexer.
+func indexablePaths(paths []string) []string {
+	var out []string
+	for _, p := range paths {
+		if p == "" || strings.ContainsAny(p, "\n\r") {
+			continue
+		}
+		if _, ok := rag.IndexerFor(filepath.Ext(p)); ok {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// indexBlobs reads each path from tree with one git cat-file --batch process
+// and stores its definitions in files. Large and binary files are skipped.
+func indexBlobs(ctx context.Context, repoRoot, tree string, paths []string, files map[string]File) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = repoRoot
+	cmd.Env = git.MinimalEnv()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("symindex: git cat-file: %w", err)
+	}
+	go func() {
+		w := bufio.NewWriter(stdin)
+		for _, p := range paths {
+			fmt.Fprintf(w, "%s:%s\n", tree, p)
+		}
+		w.Flush()
+		stdin.Close()
+	}()
+	r := bufio.NewReader(stdout)
+	var readErr error
+	for _, p := range paths {
+		content, ok, err := readBatchEntry(r)
+		if err != nil {
+			readErr = err
+			break
+		}
+		if !ok {
+			continue
+		}
+		ix, _ := rag.IndexerFor(filepath.Ext(p))
+		if defs := extractDefs(ix, content); len(defs) > 0 {
+			files[p] = File{Language: ix.IndexLanguage(), Defs: defs}
+		}
+	}
+	if readErr != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return fmt.Errorf("symindex: git cat-file: %w", readErr)
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("symindex: git cat-file: %w", err)
+	}
+	return nil
+}
+
+// readBatchEntry reads one git cat-file --batch response. ok is false for
+// missing objects, non-blobs, and blobs that are too large or binary.
+func readBatchEntry(r *bufio.Reader) (content []byte, ok bool, err error) {
+	header, err := r.ReadString('\n')
+	if err != nil {
+		return nil, false, err
+	}
+	fields := strings.Fields(header)
+	if len(fields) == 2 && fields[1] == "missing" {
+		return nil, false, nil
+	}
+	if len(fields) != 3 {
+		return nil, false, fmt.Errorf("unexpected header %q", strings.TrimSpace(header))
+	}
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || size < 0 {
+		return nil, false, fmt.Errorf("unexpected header %q", strings.TrimSpace(header))
+	}
+	if fields[1] != "blob" || size > maxFileSize {
+		if _, err := io.CopyN(io.Discard, r, size+1); err != nil {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	buf := make([]byte, size+1) // content plus trailing newline
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, false, err
+	}
+	content = buf[:size]
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil, false, nil
+	}
+	return content, true, nil
+}
+
+// extractDefs runs ix over each line of content.
+func extractDefs(ix rag.DefinitionIndexer, content []byte) []Def {
+	var defs []Def
+	lineNum := 0
+	for len(content) > 0 {
+		lineNum++
+		var line []byte
+		if i := bytes.IndexByte(content, '\n'); i >= 0 {
+			line, content = content[:i], content[i+1:]
+		} else {
+			line, content = content, nil
+		}
+		if len(line) == 0 || len(line) > maxLineLen {
+			continue
+		}
+		text := strings.TrimSuffix(string(line), "\r")
+		seen := make(map[string]bool)
+		for _, name := range ix.DefinedNames(text) {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			defs = append(defs, Def{Name: name, Line: lineNum, Text: text})
+		}
+	}
+	return defs
+}
+
+// changedPaths returns paths added or modified (changed) and removed (deleted)
+// between two trees. Renames are reported as delete plus add.
+func changedPaths(ctx context.Context, repoRoot, fromTree, toTree string) (changed, deleted []string, err error) {
+	out, err := runGit(ctx, repoRoot, "diff-tree", "-r", "-z", "--no-renames", "--name-status", fromTree, toTree)
+	if err != nil {
+		return nil, nil, err
+	}
+	parts := splitNUL(out)
+	for i := 0; i+1 < len(parts); i += 2 {
+		status, p := parts[i], parts[i+1]
+		if strings.HasPrefix(status, "D") {
+			deleted = append(deleted, p)
+		} else {
+			changed = append(changed, p)
+		}
+	}
+	return changed, deleted, nil
+}
+
+func readIndex(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, err
+	}
+	if idx.Version != formatVersion {
+		return nil, fmt.Errorf("symindex: %s: unsupported version %d", path, idx.Version)
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]File)
+	}
+	return &idx, nil
+}
+
+// writeIndex writes idx to path atomically (temp file plus rename).
+func writeIndex(dir, path string, idx *Index) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".symindex-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+// storedIndexes returns the index files in dir, newest first.
+func storedIndexes(dir string) []os.DirEntry {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []os.DirEntry
+	mtimes := make(map[string]time.Time)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		mtimes[e.Name()] = info.ModTime()
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return mtimes[out[i].Name()].After(mtimes[out[j].Name()]) })
+	return out
+}
+
+// newestIndex loads the most recently used stored index other than tree.
+func newestIndex(dir, tree string) *Index {
+	for _, e := range storedIndexes(dir) {
+		if e.Name() == tree+".json" {
+			continue
+		}
+		if idx, err := readIndex(filepath.Join(dir, e.Name())); err == nil && idx.TreeSHA != "" {
+			return idx
+		}
+	}
+	return nil
+}
+
+// pruneIndexes removes all but the keep most recently used index files.
+func pruneIndexes(dir string, keep int) {
+	for i, e := range storedIndexes(dir) {
+		if i >= keep {
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
+
+func runGit(ctx context.Context, repoRoot string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoRoot
+	cmd.Env = git.MinimalEnv()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("symindex: git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func splitNUL(s string) []string {
+	s = strings.TrimSuffix(s, "\x00")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\x00")
+}
@@ -0,0 +1,196 @@
+package symindex
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"stet/cli/internal/rag"
+	_ "stet/cli/internal/rag/cpp"
+	_ "stet/cli/internal/rag/go"
+	_ "stet/cli/internal/rag/java"
+	_ "stet/cli/internal/rag/js"
+	_ "stet/cli/internal/rag/php"
+	_ "stet/cli/internal/rag/python"
+	_ "stet/cli/internal/rag/ruby"
+	_ "stet/cli/internal/rag/rust"
+)
+
+func TestLoad_fullThenCachedThenIncremental(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stateDir := t.TempDir()
+	initGitRepo(t, dir)
+	writeFile(t, dir, "pkg/a.go", "package pkg\n\n// Alpha does a.\nfunc Alpha() {}\n\ntype Beta struct{}\n")
+	writeFile(t, dir, "pkg/old.go", "package pkg\n\nfunc Old() {}\n")
+	writeFile(t, dir, "README.md", "func NotIndexed() {}\n")
+	commitAll(t, dir, "initial")
+
+	idx, st, err := Load(ctx, dir, stateDir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if st.Mode != ModeFull || st.FilesRead != 2 || !st.Persisted {
+		t.Errorf("first Load stats = %+v, want full mode reading 2 files and persisted", st)
+	}
+	path, line, text, ok := idx.Lookup("go", "Alpha")
+	if !ok || path != "pkg/a.go" || line != 4 || text != "func Alpha() {}" {
+		t.Errorf("Lookup(Alpha) = %q, %d, %q, %v", path, line, text, ok)
+	}
+	if _, _, _, ok := idx.Lookup("go", "NotIndexed"); ok {
+		t.Error("Lookup(NotIndexed): files without a resolver must not be indexed")
+	}
+	if _, _, _, ok := idx.Lookup("python", "Alpha"); ok {
+		t.Error("Lookup(python, Alpha): lookups must be scoped to the language")
+	}
+
+	_, st, err = Load(ctx, dir, stateDir)
+	if err != nil {
+		t.Fatalf("Load (cached): %v", err)
+	}
+	if st.Mode != ModeCached {
+		t.Errorf("second Load mode = %q, want %q", st.Mode, ModeCached)
+	}
+
+	writeFile(t, dir, "pkg/a.go", "package pkg\n\nfunc Alpha() {}\n\nfunc Gamma() {}\n")
+	gitCmd(t, dir, "rm", "-q", "pkg/old.go")
+	commitAll(t, dir, "change")
+	idx, st, err = Load(ctx, dir, stateDir)
+	if err != nil {
+		t.Fatalf("Load (incremental): %v", err)
+	}
+	if st.Mode != ModeIncremental || st.FilesRead != 1 || st.BaseTreeSHA == "" {
+		t.Errorf("third Load stats = %+v, want incremental reading 1 file", st)
+	}
+	if _, line, _, ok := idx.Lookup("go", "Alpha"); !ok || line != 3 {
+		t.Errorf("Lookup(Alpha) after change: line %d ok %v, want line 3", line, ok)
+	}
+	if _, _, _, ok := idx.Lookup("go", "Gamma"); !ok {
+		t.Error("Lookup(Gamma): added definition missing after incremental update")
+	}
+	for _, name := range []string{"Beta", "Old"} {
+		if _, _, _, ok := idx.Lookup("go", name); ok {
+			t.Errorf("Lookup(%s): removed definition still indexed", name)
+		}
+	}
+}
+
+func TestLoad_prunesOldIndexes(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stateDir := t.TempDir()
+	initGitRepo(t, dir)
+	for i := 0; i < maxStoredIndexes+2; i++ {
+		writeFile(t, dir, "a.go", "package a\n\nfunc F"+string(rune('A'+i))+"() {}\n")
+		commitAll(t, dir, "c")
+		if _, _, err := Load(ctx, dir, stateDir); err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(stateDir, dirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != maxStoredIndexes {
+		t.Errorf("stored indexes = %d, want %d", len(entries), maxStoredIndexes)
+	}
+}
+
+// TestIndex_matchesGitGrep checks that resolving through the index returns the
+// same definitions as the git grep path for each indexed language.
+func TestIndex_matchesGitGrep(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stateDir := t.TempDir()
+	initGitRepo(t, dir)
+	files := map[string]string{
+		"go/a.go":       "package a\n\n// Run runs.\nfunc Run() int { return helper() }\n\nfunc helper() int { return 1 }\n\ntype Config struct{}\n",
+		"c/w.h":         "#define MAX_ITEMS 16\n\nclass Widget {\n};\n\nint add(int a, int b);\n",
+		"c/w.cpp":       "int add(int a, int b) {\n    return a + b;\n}\n\nvoid Widget::draw() {\n}\n",
+		"java/A.java":   "public class Account {\n    public int balance(int x) {\n        return x;\n    }\n}\n",
+		"js/a.ts":       "export function load(x) {\n}\nexport const Limit = 3;\nclass Store {\n}\n",
+		"php/a.php":     "<?php\nclass UserRepo {\n    public function findUser($id) {\n    }\n}\n",
+		"py/a.py":       "class Parser:\n    def parse(self, text):\n        pass\n",
+		"rb/a.rb":       "module Shop\n  class Cart\n    def empty?\n    end\n  end\nend\nMAX = 3\n",
+		"rs/a.rs":       "pub struct Point {\n}\n\nimpl Display for Point {\n}\n\nfn distance(a: Point) -> f64 {\n}\n",
+		"unused/x.java": "class Other {\n}\n",
+	}
+	for name, content := range files {
+		writeFile(t, dir, name, content)
+	}
+	commitAll(t, dir, "fixtures")
+	idx, _, err := Load(ctx, dir, stateDir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	tests := []struct {
+		file string
+		hunk string
+	}{
+		{file: "go/b.go", hunk: "x := Run() + helper()\nvar c Config\n"},
+		{file: "c/main.cpp", hunk: "Widget w; w.draw(); int n = add(1, MAX_ITEMS);"},
+		{file: "java/B.java", hunk: "Account a = new Account(); a.balance(1);"},
+		{file: "js/b.ts", hunk: "load(Limit); new Store();"},
+		{file: "php/b.php", hunk: "$r = new UserRepo(); $r->findUser(1);"},
+		{file: "py/b.py", hunk: "p = Parser()\np.parse(x)\n"},
+		{file: "rb/b.rb", hunk: "cart = Shop::Cart.new\ncart.empty?\nMAX\n"},
+		{file: "rs/b.rs", hunk: "let p = Point {};\ndistance(p);\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			opts := rag.ResolveOptions{MaxDefinitions: 10}
+			want, err := rag.ResolveSymbols(ctx, dir, tt.file, tt.hunk, opts)
+			if err != nil {
+				t.Fatalf("ResolveSymbols (grep): %v", err)
+			}
+			if len(want) == 0 {
+				t.Fatal("grep path found no definitions; fixture is broken")
+			}
+			opts.Index = idx
+			got, err := rag.ResolveSymbols(ctx, dir, tt.file, tt.hunk, opts)
+			if err != nil {
+				t.Fatalf("ResolveSymbols (index): %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("index result differs from git grep:\n got  %+v\n want %+v", got, want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func initGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	gitCmd(t, dir, "init")
+	gitCmd(t, dir, "config", "user.email", "test@test")
+	gitCmd(t, dir, "config", "user.name", "Test")
+}
+
+func commitAll(t *testing.T, dir, msg string) {
+	t.Helper()
+	gitCmd(t, dir, "add", "-A")
+	gitCmd(t, dir, "commit", "-q", "-m", msg)
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
@@ -0,0 +1,226 @@
+// Package rank scores findings by likely importance so the most important can
+// be listed first (stet list --sort=rank). The score combines the finding's
+// severity, category and (calibrated) confidence with two repository signals:
+// whether it points at lines added in the reviewed diff rather than context,
+// and the recent churn and ownership of its file.
+package rank
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"stet/cli/internal/coverage"
+	"stet/cli/internal/diff"
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/findings"
+	"stet/cli/internal/git"
+)
+
+// Sort orders accepted by Order.
+const (
+	SortRank     = "rank"
+	SortFile     = "file"
+	SortSeverity = "severity"
+)
+
+// ChurnWindow is the git date passed to git.FileChurn: file activity older
+// than this does not affect the score.
+const ChurnWindow = "90 days ago"
+
+// churnCap is the number of recent commits at which a file counts as fully churned.
+const churnCap = 20
+
+var severityWeight = map[findings.Severity]float64{
+	findings.SeverityError:   1.0,
+	findings.SeverityWarning: 0.7,
+	findings.SeverityInfo:    0.4,
+	findings.SeverityNitpick: 0.2,
+}
+
+var categoryWeight = map[findings.Category]float64{
+	findings.CategorySecurity:        1.0,
+	findings.CategoryBug:             1.0,
+	findings.CategoryCorrectness:     0.95,
+	findings.CategoryPerformance:     0.8,
+	findings.CategoryAccessibility:   0.7,
+	findings.CategoryDesign:          0.65,
+	findings.CategoryTesting:         0.6,
+	findings.CategoryMaintainability: 0.55,
+	findings.CategoryBestPractice:    0.5,
+	findings.CategoryDocumentation:   0.4,
+	findings.CategoryStyle:           0.35,
+}
+
+var severityOrder = map[findings.Severity]int{
+	findings.SeverityError:   0,
+	findings.SeverityWarning: 1,
+	findings.SeverityInfo:    2,
+	findings.SeverityNitpick: 3,
+}
+
+// Signals are the repository facts Score uses beyond the finding itself.
+// Either map may be nil when the fact could not be determined.
+type Signals struct {
+	// Added maps each file of the reviewed diff to its added (new-side) line numbers.
+	Added map[string]map[int]bool
+	// Activity is the recent commit activity of the files with findings.
+	Activity map[string]git.FileActivity
+}
+
+// Collect gathers the signals for list from the diff baselineRef..headRef and
+// the history of headRef. It returns what it could gather and the first error,
+// so ranking still works (with neutral signals) when git fails.
+func Collect(ctx context.Context, repoRoot, baselineRef, headRef string, list []findings.Finding) (*Signals, error) {
+	sig := &Signals{}
+	var firstErr error
+	hunks, err := diff.Hunks(ctx, repoRoot, baselineRef, headRef, nil)
+	if err != nil {
+		firstErr = err
+	} else {
+		sig.Added = make(map[string]map[int]bool)
+		for _, h := range hunks {
+			lines := sig.Added[h.FilePath]
+			if lines == nil {
+				lines = make(map[int]bool)
+				sig.Added[h.FilePath] = lines
+			}
+			for _, n := range coverage.AddedLines(h.RawContent) {
+				lines[n] = true
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range list {
+		if f.File != "" && !seen[f.File] {
+			seen[f.File] = true
+			files = append(files, f.File)
+		}
+	}
+	sig.Activity, err = git.FileChurn(repoRoot, headRef, ChurnWindow, files)
+	if err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return sig, firstErr
+}
+
+// Score returns f's rank score in [0, 1]; higher is more important. It is the
+// product of the severity, category, confidence, line and file weights. A
+// confidence of 0 (not reported) counts as 0.5.
+func Score(f findings.Finding, sig *Signals) float64 {
+	sev, ok := severityWeight[findings.Severity(strings.ToLower(string(f.Severity)))]
+	if !ok {
+		sev = 0.5
+	}
+	cat, ok := categoryWeight[findings.Category(strings.ToLower(string(f.Category)))]
+	if !ok {
+		cat = 0.6
+	}
+	conf := math.Min(f.Confidence, 1)
+	if conf <= 0 {
+		conf = 0.5
+	}
+	return round(sev * cat * conf * lineWeight(f, sig) * fileWeight(f, sig))
+}
+
+// lineWeight is 1 when f points at an added line, 0.7 when it points only at
+// context lines, and 0.85 when the diff is unknown.
+func lineWeight(f findings.Finding, sig *Signals) float64 {
+	if sig == nil || sig.Added == nil {
+		return 0.85
+	}
+	start, end := f.Line, f.Line
+	if f.Range != nil {
+		start, end = f.Range.Start, f.Range.End
+	}
+	added := sig.Added[f.File]
+	for n := start; n <= end; n++ {
+		if added[n] {
+			return 1
+		}
+	}
+	return 0.7
+}
+
+// fileWeight favours files with many recent commits (churn) and files whose
+// recent commits are spread over many authors (weak ownership); both are
+// associated with more defects. It ranges from 0.8 (no recent activity) to 1.
+func fileWeight(f findings.Finding, sig *Signals) float64 {
+	var a git.FileActivity
+	if sig != nil {
+		a = sig.Activity[f.File]
+	}
+	churn := math.Min(float64(a.Commits), churnCap) / churnCap
+	diffuse := 0.0
+	if a.Authors > 1 && a.Commits > 0 {
+		diffuse = 1 - float64(a.TopAuthorCommits)/float64(a.Commits)
+	}
+	return (1 + 0.15*churn + 0.1*diffuse) / 1.25
+}
+
+// Apply sets Rank on each finding of list (in place).
+func Apply(list []findings.Finding, sig *Signals) {
+	for i := range list {
+		list[i].Rank = Score(list[i], sig)
+	}
+}
+
+// ValidSort returns a user-facing error unless by is "" (pipeline order) or one
+// of SortRank, SortFile and SortSeverity.
+func ValidSort(by string) error {
+	switch by {
+	case "", SortRank, SortFile, SortSeverity:
+		return nil
+	}
+	return erruser.New(fmt.Sprintf("Invalid sort %q; use rank, file or severity.", by), nil)
+}
+
+// Order sorts list in place by by (see ValidSort): rank is highest Rank first,
+// file is by file and line, severity is most severe first and then by Rank.
+// Ties keep their pipeline order. Call Apply first for rank and severity.
+func Order(list []findings.Finding, by string) {
+	var less func(a, b findings.Finding) bool
+	switch by {
+	case SortRank:
+		less = func(a, b findings.Finding) bool { return a.Rank > b.Rank }
+	case SortFile:
+		less = func(a, b findings.Finding) bool {
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return startLine(a) < startLine(b)
+		}
+	case SortSeverity:
+		less = func(a, b findings.Finding) bool {
+			sa, sb := severityRank(a.Severity), severityRank(b.Severity)
+			if sa != sb {
+				return sa < sb
+			}
+			return a.Rank > b.Rank
+		}
+	default:
+		return
+	}
+	sort.SliceStable(list, func(i, j int) bool { return less(list[i], list[j]) })
+}
+
+func severityRank(s findings.Severity) int {
+	if n, ok := severityOrder[findings.Severity(strings.ToLower(string(s)))]; ok {
+		return n
+	}
+	return len(severityOrder)
+}
+
+func startLine(f findings.Finding) int {
+	if f.Range != nil {
+		return f.Range.Start
+	}
+	return f.Line
+}
+
+func round(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
@@ -0,0 +1,68 @@
+package rank
+
+import (
+	"reflect"
+	"testing"
+
+	"stet/cli/internal/findings"
+	"stet/cli/internal/git"
+)
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+	sig := &Signals{
+		Added:    map[string]map[int]bool{"a.go": {10: true, 11: true}},
+		Activity: map[string]git.FileActivity{"a.go": {Commits: 40, Authors: 2, TopAuthorCommits: 20}},
+	}
+	tests := []struct {
+		name string
+		f    findings.Finding
+		sig  *Signals
+		want float64
+	}{
+		{"added line, hot file", findings.Finding{File: "a.go", Line: 10, Severity: findings.SeverityError, Category: findings.CategoryBug, Confidence: 1}, sig, round(1.2 / 1.25)},
+		{"range overlapping added", findings.Finding{File: "a.go", Range: &findings.LineRange{Start: 5, End: 10}, Severity: findings.SeverityError, Category: findings.CategoryBug, Confidence: 1}, sig, round(1.2 / 1.25)},
+		{"context line", findings.Finding{File: "a.go", Line: 3, Severity: findings.SeverityError, Category: findings.CategoryBug, Confidence: 1}, sig, round(0.7 * 1.2 / 1.25)},
+		{"no signals", findings.Finding{File: "b.go", Line: 1, Severity: findings.SeverityWarning, Category: findings.CategoryStyle, Confidence: 0.8}, nil, round(0.7 * 0.35 * 0.8 * 0.85 * 0.8)},
+		{"missing confidence", findings.Finding{File: "b.go", Line: 1, Severity: findings.SeverityInfo, Category: "unknown"}, nil, round(0.4 * 0.6 * 0.5 * 0.85 * 0.8)},
+	}
+	for _, tt := range tests {
+		if got := Score(tt.f, tt.sig); got != tt.want {
+			t.Errorf("%s: Score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	t.Parallel()
+	list := []findings.Finding{
+		{ID: "1", File: "b.go", Line: 5, Severity: findings.SeverityWarning, Category: findings.CategorySecurity, Confidence: 1},
+		{ID: "2", File: "a.go", Line: 9, Severity: findings.SeverityNitpick, Category: findings.CategoryStyle, Confidence: 1},
+		{ID: "3", File: "a.go", Line: 2, Severity: findings.SeverityError, Category: findings.CategoryStyle, Confidence: 0.6},
+		{ID: "4", File: "b.go", Line: 1, Severity: findings.SeverityWarning, Category: findings.CategoryBug, Confidence: 0.5},
+	}
+	Apply(list, nil)
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{"", []string{"1", "2", "3", "4"}},
+		{SortRank, []string{"1", "4", "3", "2"}},
+		{SortFile, []string{"3", "2", "4", "1"}},
+		{SortSeverity, []string{"3", "1", "4", "2"}},
+	}
+	for _, tt := range tests {
+		got := append([]findings.Finding(nil), list...)
+		Order(got, tt.by)
+		var ids []string
+		for _, f := range got {
+			ids = append(ids, f.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("Order(%q) = %v, want %v", tt.by, ids, tt.want)
+		}
+	}
+	if err := ValidSort("confidence"); err == nil {
+		t.Error("ValidSort(confidence): want error")
+	}
+}
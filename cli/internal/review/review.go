@@ -6,7 +6,9 @@ import (
 	"math"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"stet/cli/internal/coverage"
 	"stet/cli/internal/diff"
 	"stet/cli/internal/expand"
 	"stet/cli/internal/findings"
@@ -16,6 +18,7 @@ import (
 	"stet/cli/internal/prompt"
 	"stet/cli/internal/rag"
 	"stet/cli/internal/rules"
+	"stet/cli/internal/testctx"
 	"stet/cli/internal/tokens"
 	"stet/cli/internal/trace"
 )
@@ -74,6 +77,9 @@ func effectiveRAGTokenCap(contextLimit, basePromptTokens, responseReserve, ragMa
 	return max(0, effective)
 }
 
+// maxRelatedTests caps how many related test functions or blocks are included per hunk.
+const maxRelatedTests = 3
+
 // maxSuppressionExamplesPerHunk caps how many suppression examples can be
 // appended per hunk when using a token budget (per-hunk suppression).
 const maxSuppressionExamplesPerHunk = 30
@@ -91,8 +97,15 @@ const maxSuppressionTokensCap = 8192
 // (only as many as fit in the remaining token budget), and runs RAG when enabled.
 // When ragCallGraphEnabled is true and the file is Go, call-graph (callers/callees)
 // is resolved and appended to the middle block; token cap is ragCallGraphMaxTokens
-// or half of effectiveRAGTokens when 0. Used by the pipeline to prepare the next hunk.
-func PrepareHunkPrompt(ctx context.Context, systemBase string, hunk diff.Hunk, ruleList []rules.CursorRule, repoRoot string, contextLimit int, ragMaxDefs, ragMaxTokens int, ragCallGraphEnabled bool, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens int, useSearchReplaceFormat bool, suppressionExamples []string, traceOut *trace.Tracer) (system, user string, err error) {
+// or half of effectiveRAGTokens when 0. When ragGoTypes is true, Go symbols are
+// resolved from go/packages type information (falling back to grep on load failure).
+// symbolIndex, when non-nil, serves definition lookups instead of git grep.
+// When relatedTests is true, up to maxRelatedTests tests that exercise the hunk's
+// enclosing function are appended (half of effectiveRAGTokens). When
+// coverageProfile is non-nil and instruments the file, the hunk's uncovered
+// added lines are listed so the model can report "testing" findings.
+// Used by the pipeline to prepare the next hunk.
+func PrepareHunkPrompt(ctx context.Context, systemBase string, hunk diff.Hunk, ruleList []rules.CursorRule, repoRoot string, contextLimit int, ragMaxDefs, ragMaxTokens int, ragCallGraphEnabled bool, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens int, ragGoTypes bool, symbolIndex rag.SymbolIndex, relatedTests bool, coverageProfile *coverage.Profile, useSearchReplaceFormat bool, suppressionExamples []string, traceOut *trace.Tracer) (system, user string, err error) {
 	system = prompt.AppendCursorRules(systemBase, ruleList, hunk.FilePath, rules.MaxRuleTokens)
 	if useSearchReplaceFormat {
 		system = prompt.AppendSearchReplaceFormatNote(system)
@@ -200,14 +213,15 @@ func PrepareHunkPrompt(ctx context.Context, systemBase string, hunk diff.Hunk, r
 	var symbolDefsBlock string
 	if doRAG {
 		var defs []rag.Definition
-		defs, err = rag.ResolveSymbols(ctx, repoRoot, hunk.FilePath, hunk.RawContent, rag.ResolveOptions{MaxDefinitions: ragMaxDefs, MaxTokens: effectiveRAGTokens})
+		ragStart := time.Now()
+		defs, err = rag.ResolveSymbols(ctx, repoRoot, hunk.FilePath, hunk.RawContent, rag.ResolveOptions{MaxDefinitions: ragMaxDefs, MaxTokens: effectiveRAGTokens, TypeInfo: ragGoTypes, Index: symbolIndex})
 		if err != nil {
 			return "", "", fmt.Errorf("review: RAG resolve: %w", err)
 		}
 		symbolDefsBlock = prompt.FormatSymbolDefinitions(defs, effectiveRAGTokens)
 		if traceOut != nil && traceOut.Enabled() {
 			traceOut.Section("RAG")
-			traceOut.Printf("effective_rag_tokens=%d definitions=%d\n", effectiveRAGTokens, len(defs))
+			traceOut.Printf("effective_rag_tokens=%d definitions=%d index=%t duration=%s\n", effectiveRAGTokens, len(defs), symbolIndex != nil, time.Since(ragStart).Round(time.Microsecond))
 			for _, d := range defs {
 				traceOut.Printf("  %s %s:%d %s\n", d.Symbol, d.File, d.Line, d.Signature)
 			}
@@ -224,10 +238,12 @@ func PrepareHunkPrompt(ctx context.Context, systemBase string, hunk diff.Hunk, r
 		if callGraphTokenCap <= 0 {
 			callGraphTokenCap = effectiveRAGTokens / 2
 		}
+		cgStart := time.Now()
 		cgResult, cgErr := rag.ResolveCallGraph(ctx, repoRoot, hunk.FilePath, hunk.RawContent, rag.CallGraphOptions{
 			CallersMax: ragCallersMax,
 			CalleesMax: ragCalleesMax,
 			MaxTokens:  callGraphTokenCap,
+			Index:      symbolIndex,
 		})
 		if cgErr == nil && cgResult != nil && (len(cgResult.Callers) > 0 || len(cgResult.Callees) > 0) {
 			callGraphBlock := prompt.FormatCallGraph(cgResult.Callers, cgResult.Callees, callGraphTokenCap)
@@ -239,11 +255,46 @@ func PrepareHunkPrompt(ctx context.Context, systemBase string, hunk diff.Hunk, r
 				}
 				if traceOut != nil && traceOut.Enabled() {
 					traceOut.Section("RAG call-graph")
-					traceOut.Printf("callers=%d callees=%d\n", len(cgResult.Callers), len(cgResult.Callees))
+					traceOut.Printf("callers=%d callees=%d duration=%s\n", len(cgResult.Callers), len(cgResult.Callees), time.Since(cgStart).Round(time.Microsecond))
 				}
 			}
 		}
 	}
+	appendMiddle := func(block string) {
+		if block == "" {
+			return
+		}
+		if middleBlock != "" {
+			middleBlock = middleBlock + "\n\n" + block
+		} else {
+			middleBlock = block
+		}
+	}
+	if repoRoot != "" && relatedTests && (contextLimit <= 0 || effectiveRAGTokens > 0) {
+		testsStart := time.Now()
+		res := testctx.FindRelated(ctx, repoRoot, hunk.FilePath, hunk.RawContent, maxRelatedTests)
+		appendMiddle(prompt.FormatRelatedTests(res, effectiveRAGTokens/2))
+		if traceOut != nil && traceOut.Enabled() {
+			traceOut.Section("Related tests")
+			traceOut.Printf("subject=%q searched=%d tests=%d duration=%s\n", res.Subject, len(res.Searched), len(res.Snippets), time.Since(testsStart).Round(time.Microsecond))
+			for _, s := range res.Snippets {
+				traceOut.Printf("  %s:%d %s\n", s.File, s.Line, s.Name)
+			}
+		}
+	}
+	if coverageProfile != nil {
+		added := coverage.AddedLines(hunk.RawContent)
+		uncovered, instrumented, known := coverageProfile.Uncovered(hunk.FilePath, added)
+		appendMiddle(prompt.FormatCoverage(filepath.Base(coverageProfile.Path), uncovered, instrumented))
+		if traceOut != nil && traceOut.Enabled() {
+			traceOut.Section("Coverage")
+			if known {
+				traceOut.Printf("added=%d instrumented=%d uncovered=%d\n", len(added), instrumented, len(uncovered))
+			} else {
+				traceOut.Printf("no coverage data for %s\n", hunk.FilePath)
+			}
+		}
+	}
 	if middleBlock != "" {
 		user = prompt.UserPromptWithRAGPlacement(user, middleBlock)
 	}
@@ -349,9 +400,12 @@ func ProcessReviewResponse(ctx context.Context, result *ollama.GenerateResult, h
 // returns an error. ragMaxDefs and ragMaxTokens control RAG-lite symbol lookup
 // (Sub-phase 6.8); zero ragMaxDefs disables it. ragCallGraphEnabled and
 // ragCallersMax/ragCalleesMax/ragCallGraphMaxTokens control optional call-graph (Go only).
+// ragGoTypes enables type-checked Go symbol resolution and symbolIndex (may be nil)
+// replaces git grep for definition lookups (see PrepareHunkPrompt). relatedTests and
+// coverageProfile (may be nil) add related tests and uncovered added lines.
 // When nitpicky is true, nitpicky-mode instructions are appended.
 // suppressionExamples, when non-nil and non-empty, are applied per-hunk (as many as fit in the token budget).
-func ReviewHunk(ctx context.Context, client llm.Client, model, stateDir string, hunk diff.Hunk, generateOpts *ollama.GenerateOptions, userIntent *prompt.UserIntent, ruleList []rules.CursorRule, repoRoot string, contextLimit int, ragMaxDefs, ragMaxTokens int, ragCallGraphEnabled bool, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens int, promptShadows []prompt.Shadow, nitpicky bool, useSearchReplaceFormat bool, suppressionExamples []string, traceOut *trace.Tracer) ([]findings.Finding, *HunkUsage, error) {
+func ReviewHunk(ctx context.Context, client llm.Client, model, stateDir string, hunk diff.Hunk, generateOpts *ollama.GenerateOptions, userIntent *prompt.UserIntent, ruleList []rules.CursorRule, repoRoot string, contextLimit int, ragMaxDefs, ragMaxTokens int, ragCallGraphEnabled bool, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens int, ragGoTypes bool, symbolIndex rag.SymbolIndex, relatedTests bool, coverageProfile *coverage.Profile, promptShadows []prompt.Shadow, nitpicky bool, useSearchReplaceFormat bool, suppressionExamples []string, traceOut *trace.Tracer) ([]findings.Finding, *HunkUsage, error) {
 	systemBase, err := prompt.SystemPrompt(stateDir)
 	if err != nil {
 		return nil, nil, fmt.Errorf("review: system prompt: %w", err)
@@ -389,7 +443,7 @@ func ReviewHunk(ctx context.Context, client llm.Client, model, stateDir string,
 			traceOut.Printf("Nitpicky: disabled\n")
 		}
 	}
-	system, user, err := PrepareHunkPrompt(ctx, systemBase, hunk, ruleList, repoRoot, contextLimit, ragMaxDefs, ragMaxTokens, ragCallGraphEnabled, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens, useSearchReplaceFormat, suppressionExamples, traceOut)
+	system, user, err := PrepareHunkPrompt(ctx, systemBase, hunk, ruleList, repoRoot, contextLimit, ragMaxDefs, ragMaxTokens, ragCallGraphEnabled, ragCallersMax, ragCalleesMax, ragCallGraphMaxTokens, ragGoTypes, symbolIndex, relatedTests, coverageProfile, useSearchReplaceFormat, suppressionExamples, traceOut)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"stet/cli/internal/coverage"
 	"stet/cli/internal/diff"
 	"stet/cli/internal/llm"
 	"stet/cli/internal/ollama"
@@ -84,7 +85,7 @@ func TestReviewHunk_successFirstTry(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "a.go", RawContent: "code", Context: "code"}
 	ctx := context.Background()
 
-	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -119,7 +120,7 @@ func TestReviewHunk_retryThenSuccess(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "b.go", RawContent: "x", Context: "x"}
 	ctx := context.Background()
 
-	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -152,7 +153,7 @@ func TestReviewHunk_rustFile_minifiesContext(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "src/lib.rs", RawContent: raw, Context: raw}
 	ctx := context.Background()
 
-	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -170,7 +171,7 @@ func TestReviewHunk_generateFails_returnsError(t *testing.T) {
 	dir := t.TempDir()
 	hunk := diff.Hunk{FilePath: "x.go", RawContent: "code", Context: "code"}
 	ctx := context.Background()
-	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err == nil {
 		t.Fatal("ReviewHunk: want error when generate fails, got nil")
 	}
@@ -259,7 +260,7 @@ func TestReviewHunk_parseFailsTwice_returnsError(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "c.go", RawContent: "y", Context: "y"}
 	ctx := context.Background()
 
-	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err == nil {
 		t.Fatal("ReviewHunk: want error when parse fails twice, got nil")
 	}
@@ -294,7 +295,7 @@ func TestReviewHunk_hunkWithExternalVariable_mockReturnsNoUndefinedFinding(t *te
 	}
 	ctx := context.Background()
 
-	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -346,7 +347,7 @@ func TestReviewHunk_injectsUserIntentIntoPrompt(t *testing.T) {
 	userIntent := &prompt.UserIntent{Branch: "main", CommitMsg: commitMsg}
 	ctx := context.Background()
 
-	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, userIntent, nil, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, userIntent, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -420,7 +421,7 @@ func processData(input string) (int, error) {
 	}
 	ctx := context.Background()
 
-	_, _, err := ReviewHunk(ctx, client, "m", stateDir, hunk, nil, nil, nil, dir, 32768, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", stateDir, hunk, nil, nil, nil, dir, 32768, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -472,7 +473,7 @@ func TestReviewHunk_injectsCursorRulesIntoSystemPrompt(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, ruleList, "", 0, 0, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, ruleList, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -522,7 +523,7 @@ func TestReviewHunk_withRAG_injectsSymbolDefinitions(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "pkg/foo.go", RawContent: "+x := Bar()", Context: "+x := Bar()"}
 	ctx := context.Background()
 
-	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, dir, 0, 5, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, dir, 0, 5, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -587,7 +588,7 @@ func TestReviewHunk_perHunkAdaptiveRAG_truncatesToFitContext(t *testing.T) {
 	client, _ := llm.NewClient("ollama", srv.URL, srv.Client())
 	ctx := context.Background()
 
-	_, _, err = ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, dir, contextLimit, 5, 0, false, 0, 0, 0, nil, false, false, nil, nil)
+	_, _, err = ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, dir, contextLimit, 5, 0, false, 0, 0, 0, false, nil, false, nil, nil, false, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -640,7 +641,7 @@ func TestReviewHunk_nitpickyTrue_appendsNitpickySection(t *testing.T) {
 	hunk := diff.Hunk{FilePath: "a.go", RawContent: "code", Context: "code"}
 	ctx := context.Background()
 
-	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, nil, true, false, nil, nil)
+	list, _, err := ReviewHunk(ctx, client, "m", dir, hunk, nil, nil, nil, "", 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, nil, true, false, nil, nil)
 	if err != nil {
 		t.Fatalf("ReviewHunk: %v", err)
 	}
@@ -673,7 +674,7 @@ func TestPrepareHunkPrompt_callGraphDisabled_noCallGraphSection(t *testing.T) {
 		Context:    "",
 	}
 	ctx := context.Background()
-	_, user, err := PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 32768, 0, 0, false, 3, 3, 0, false, nil, nil)
+	_, user, err := PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 32768, 0, 0, false, 3, 3, 0, false, nil, false, nil, false, nil, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt: %v", err)
 	}
@@ -695,7 +696,7 @@ func TestPrepareHunkPrompt_nonGoFile_noCallGraphSection(t *testing.T) {
 		Context:    "code",
 	}
 	ctx := context.Background()
-	_, user, err := PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 32768, 0, 0, true, 3, 3, 0, false, nil, nil)
+	_, user, err := PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 32768, 0, 0, true, 3, 3, 0, false, nil, false, nil, false, nil, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt: %v", err)
 	}
@@ -704,6 +705,58 @@ func TestPrepareHunkPrompt_nonGoFile_noCallGraphSection(t *testing.T) {
 	}
 }
 
+// TestPrepareHunkPrompt_relatedTestsAndCoverage asserts that related tests and
+// uncovered added lines are placed in the user prompt when enabled.
+func TestPrepareHunkPrompt_relatedTestsAndCoverage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"calc/calc.go":      "package calc\n\nfunc Add(a, b int) int {\n\tif a < 0 {\n\t\treturn 0\n\t}\n\treturn a + b\n}\n",
+		"calc/calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\t_ = Add(1, 2)\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	profile, err := coverage.Parse(strings.NewReader("mode: set\nexample.com/calc/calc.go:3.24,4.11 1 1\nexample.com/calc/calc.go:4.11,6.3 1 0\nexample.com/calc/calc.go:7.2,7.14 1 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile.Path = "cover.out"
+	hunk := diff.Hunk{
+		FilePath:   "calc/calc.go",
+		RawContent: "@@ -3,3 +3,6 @@\n func Add(a, b int) int {\n+\tif a < 0 {\n+\t\treturn 0\n+\t}\n \treturn a + b\n }\n",
+	}
+	ctx := context.Background()
+	_, user, err := PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 0, 0, 0, false, 0, 0, 0, false, nil, true, profile, false, nil, nil)
+	if err != nil {
+		t.Fatalf("PrepareHunkPrompt: %v", err)
+	}
+	for _, want := range []string{"## Related tests", "func TestAdd(t *testing.T) {", "## Test coverage", "2 of 3 instrumented added lines", "lines 5-6."} {
+		if !strings.Contains(user, want) {
+			t.Errorf("user prompt should contain %q; got:\n%s", want, user)
+		}
+	}
+	_, user, err = PrepareHunkPrompt(ctx, "system", hunk, nil, dir, 0, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, nil, nil)
+	if err != nil {
+		t.Fatalf("PrepareHunkPrompt: %v", err)
+	}
+	if strings.Contains(user, "## Related tests") || strings.Contains(user, "## Test coverage") {
+		t.Errorf("disabled: user prompt must not contain related tests or coverage; got:\n%s", user)
+	}
+}
+
 // TestPrepareHunkPrompt_suppressionPerHunk asserts that when suppressionExamples
 // and a generous contextLimit are passed, the returned system prompt contains
 // the "Do not report issues similar to" section and the example text.
@@ -715,7 +768,7 @@ func TestPrepareHunkPrompt_suppressionPerHunk(t *testing.T) {
 	}
 	examples := []string{"pkg/foo.go:42: Consider adding comments"}
 	ctx := context.Background()
-	system, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, examples, nil)
+	system, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt: %v", err)
 	}
@@ -739,11 +792,11 @@ func TestPrepareHunkPrompt_suppressionBudgetCapsExamples(t *testing.T) {
 	}
 	examples := []string{"a.go:1: msg1", "b.go:2: longer message here"}
 	ctx := context.Background()
-	systemSmall, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 500, 0, 0, false, 0, 0, 0, false, examples, nil)
+	systemSmall, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 500, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt(small limit): %v", err)
 	}
-	systemLarge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, examples, nil)
+	systemLarge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt(large limit): %v", err)
 	}
@@ -773,11 +826,11 @@ func TestPrepareHunkPrompt_suppressionBudgetCapBounded(t *testing.T) {
 		"e.go:5: msg5",
 	}
 	ctx := context.Background()
-	systemLarge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 262144, 0, 0, false, 0, 0, 0, false, examples, nil)
+	systemLarge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 262144, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt(large limit): %v", err)
 	}
-	systemHuge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 524288, 0, 0, false, 0, 0, 0, false, examples, nil)
+	systemHuge, _, err := PrepareHunkPrompt(ctx, "base", hunk, nil, "", 524288, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt(huge limit): %v", err)
 	}
@@ -0,0 +1,138 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/git"
+	"stet/cli/internal/history"
+	"stet/cli/internal/session"
+)
+
+// ErrSessionExists indicates an import target already has an active session.
+var ErrSessionExists = errors.New("a review session is already active")
+
+// ExportOptions configures Export. Config and ExportedBy are recorded in the
+// bundle for the importer's information.
+type ExportOptions struct {
+	StateDir   string
+	Session    string // session name; "" is the default session
+	Path       string
+	Config     *history.RunConfigSnapshot
+	ExportedBy string
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	RepoRoot     string
+	StateDir     string
+	WorktreeRoot string
+	Session      string // session name to import into; "" is the default session
+	Path         string
+}
+
+// Export writes the active session to opts.Path as a session bundle. Returns
+// ErrNoSession when there is no active session.
+func Export(opts ExportOptions) error {
+	if opts.StateDir == "" || opts.Path == "" {
+		return erruser.New("Export failed: state directory and file are required.", nil)
+	}
+	s, err := session.Load(session.Dir(opts.StateDir, opts.Session))
+	if err != nil {
+		return err
+	}
+	if s.BaselineRef == "" {
+		return ErrNoSession
+	}
+	return session.WriteBundle(opts.Path, &session.Bundle{
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		ExportedBy: opts.ExportedBy,
+		Config:     opts.Config,
+		Session:    s,
+	})
+}
+
+// Import reads the bundle at opts.Path and makes it the active session: it
+// checks that the baseline and last reviewed commits are full SHAs that exist
+// locally and are in the history of HEAD, creates the review worktree at the
+// baseline and saves the session, holding the session lock from the active
+// session check on. Returns ErrSessionExists when the target session is active, and
+// the bundle on success.
+func Import(opts ImportOptions) (*session.Bundle, error) {
+	if opts.RepoRoot == "" || opts.StateDir == "" || opts.Path == "" {
+		return nil, erruser.New("Import failed: repository root, state directory and file are required.", nil)
+	}
+	b, err := session.ReadBundle(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	s := b.Session
+	refs := []struct{ label, sha string }{{"baseline", s.BaselineRef}, {"last reviewed", s.LastReviewedAt}}
+	// The bundle comes from someone else: only full SHAs reach git, never refs,
+	// revision expressions or values git could read as options.
+	for _, c := range refs {
+		if c.sha != "" && !isFullSHA(c.sha) {
+			return nil, erruser.New(fmt.Sprintf("The %s commit %q of the exported review is not a full commit SHA.", c.label, c.sha), nil)
+		}
+	}
+	sessionDir := session.Dir(opts.StateDir, opts.Session)
+	release, err := session.AcquireLock(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	existing, err := session.Load(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+	if existing.BaselineRef != "" {
+		return nil, ErrSessionExists
+	}
+	for _, c := range refs {
+		if c.sha == "" {
+			continue
+		}
+		ok, err := git.RefExists(opts.RepoRoot, c.sha+"^{commit}")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, erruser.New(fmt.Sprintf("The %s commit %.12s of the exported review is not in this repository; fetch the reviewed branch first.", c.label, c.sha), nil)
+		}
+		ok, err = git.IsAncestor(opts.RepoRoot, c.sha, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, erruser.New(fmt.Sprintf("The %s commit %.12s of the exported review is not in the history of HEAD; check out the reviewed branch first.", c.label, c.sha), nil)
+		}
+	}
+	worktreePath, err := git.CreateForSession(opts.RepoRoot, opts.WorktreeRoot, opts.Session, s.BaselineRef)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Save(sessionDir, &s); err != nil {
+		if removeErr := git.Remove(opts.RepoRoot, worktreePath); removeErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: cleanup worktree: %v\n", removeErr)
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// isFullSHA reports whether s is a full SHA-1 (40) or SHA-256 (64) object name
+// in lowercase hex, as git prints it.
+func isFullSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -16,11 +16,16 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"stet/cli/internal/baseline"
+	"stet/cli/internal/calibration"
 	"stet/cli/internal/config"
+	"stet/cli/internal/coverage"
+	"stet/cli/internal/dangling"
 	"stet/cli/internal/diff"
 	"stet/cli/internal/erruser"
 	"stet/cli/internal/expand"
@@ -31,10 +36,14 @@ import (
 	"stet/cli/internal/llm"
 	"stet/cli/internal/ollama"
 	"stet/cli/internal/prompt"
+	"stet/cli/internal/rag"
+	"stet/cli/internal/rag/symindex"
 	"stet/cli/internal/review"
 	"stet/cli/internal/rules"
 	"stet/cli/internal/scope"
 	"stet/cli/internal/session"
+	"stet/cli/internal/suppress"
+	"stet/cli/internal/summary"
 	"stet/cli/internal/tokens"
 	"stet/cli/internal/trace"
 	"stet/cli/internal/version"
@@ -74,6 +83,8 @@ const (
 	keepAliveAfterRun = 0
 	// maxSuppressionExamples caps the number of history records to consider for suppression; applied per-hunk (as many as fit in the token budget) in PrepareHunkPrompt (roadmap 9.1).
 	maxSuppressionExamples = 30
+	// fileBatchMaxTokensCap caps the raw diff tokens of one file-level batch (see fileBatchTokens).
+	fileBatchMaxTokensCap = 4096
 )
 
 // truncateForPromptContext truncates s to maxLen and appends "\n[truncated]" if truncated.
@@ -86,11 +97,12 @@ func truncateForPromptContext(s string, maxLen int) string {
 
 // preparedPrompt holds a ready-to-send prompt for one hunk, or an error from preparation.
 type preparedPrompt struct {
-	System string
-	User   string
-	Hunk   diff.Hunk
-	Index  int
-	Err    error
+	System   string
+	User     string
+	Hunk     diff.Hunk
+	Index    int
+	Err      error
+	Dangling []dangling.Result // removed declarations and their remaining references; nil when disabled
 }
 
 // genResult holds the result of a single Generate call for one hunk (used by the pipeline worker).
@@ -110,6 +122,7 @@ type reviewPipelineOpts struct {
 	GenOpts                  *ollama.GenerateOptions
 	SystemBase               string
 	RepoRoot                 string
+	ContextRoot              string // checkout the prompt context (expansion, RAG, related tests) is read from; "" = RepoRoot
 	EffectiveContextLimit    int
 	RAGSymbolMaxDefinitions  int
 	RAGSymbolMaxTokens       int
@@ -117,6 +130,11 @@ type reviewPipelineOpts struct {
 	RAGCallersMax            int
 	RAGCalleesMax            int
 	RAGCallGraphMaxTokens    int
+	RAGGoTypesEnabled        bool
+	SymbolIndex              rag.SymbolIndex
+	RelatedTests             bool
+	CoverageProfile          *coverage.Profile
+	DanglingRefs             bool // check declarations removed by each hunk for references left in HEAD
 	RulesByFile              map[string][]rules.CursorRule
 	MinKeep, MinMaint         float64
 	ApplyFP                  bool
@@ -127,6 +145,13 @@ type reviewPipelineOpts struct {
 	TraceOut                 *trace.Tracer
 	PromptShadows            []prompt.Shadow
 	UseSearchReplaceFormat   bool
+	HunkIDLevel              hunkid.Level // normalization level for the semantic IDs written to the trace
+	Commit                   string       // per-commit review: SHA recorded on each finding (see findings.SetCommit)
+	ToHead                   *diff.LineMap // per-commit review: maps lines at Commit to HEAD; nil when the hunks are HEAD's
+	Baseline                 *baseline.Baseline // committed baseline (.stet/baseline.json); matching findings are dropped
+	Suppress                 *suppress.Tracker  // inline stet:ignore directives; matching findings are dropped after parsing
+	Filters                  *findings.Filter   // config [filters]; applied after the FP kill list
+	Calibration              *calibration.Calibration // confidence calibration for Model; abstention still uses the raw confidence
 	// SuppressionExamples is the list of "do not report" examples from history; applied per-hunk (as many as fit in token budget). Nil when suppression disabled.
 	SuppressionExamples []string
 }
@@ -164,12 +189,20 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 				}
 				hunk := opts.Hunks[i]
 				cursorRules := opts.RulesByFile[hunk.FilePath]
-				system, user, prepErr := review.PrepareHunkPrompt(ctx, opts.SystemBase, hunk, cursorRules, opts.RepoRoot, opts.EffectiveContextLimit, opts.RAGSymbolMaxDefinitions, opts.RAGSymbolMaxTokens, opts.RAGCallGraphEnabled, opts.RAGCallersMax, opts.RAGCalleesMax, opts.RAGCallGraphMaxTokens, opts.UseSearchReplaceFormat, opts.SuppressionExamples, opts.TraceOut)
+				contextRoot := opts.RepoRoot
+				if opts.ContextRoot != "" {
+					contextRoot = opts.ContextRoot
+				}
+				system, user, prepErr := review.PrepareHunkPrompt(ctx, opts.SystemBase, hunk, cursorRules, contextRoot, opts.EffectiveContextLimit, opts.RAGSymbolMaxDefinitions, opts.RAGSymbolMaxTokens, opts.RAGCallGraphEnabled, opts.RAGCallersMax, opts.RAGCalleesMax, opts.RAGCallGraphMaxTokens, opts.RAGGoTypesEnabled, opts.SymbolIndex, opts.RelatedTests, opts.CoverageProfile, opts.UseSearchReplaceFormat, opts.SuppressionExamples, opts.TraceOut)
 				if prepErr != nil {
 					readyCh <- preparedPrompt{Index: i, Hunk: hunk, Err: prepErr}
 					continue
 				}
-				readyCh <- preparedPrompt{System: system, User: user, Hunk: hunk, Index: i}
+				var danglingResults []dangling.Result
+				if opts.DanglingRefs {
+					danglingResults = dangling.Check(ctx, opts.RepoRoot, hunk, 0)
+				}
+				readyCh <- preparedPrompt{System: system, User: user, Hunk: hunk, Index: i, Dangling: danglingResults}
 			}
 		}()
 	}
@@ -256,7 +289,9 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 				}
 				if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 					opts.TraceOut.Section("Hunk " + fmt.Sprintf("%d/%d", p.Index+1, total) + ": " + p.Hunk.FilePath)
-					opts.TraceOut.Printf("strict_id=%s semantic_id=%s\n", hunkid.StrictHunkID(p.Hunk.FilePath, p.Hunk.RawContent), hunkid.SemanticHunkID(p.Hunk.FilePath, p.Hunk.RawContent))
+					for _, h := range hunkParts(p.Hunk) {
+						opts.TraceOut.Printf("strict_id=%s semantic_id=%s\n", hunkid.StrictHunkID(h.FilePath, h.RawContent), hunkid.SemanticHunkIDLevel(h.FilePath, h.RawContent, opts.HunkIDLevel))
+					}
 					opts.TraceOut.Printf("estimated_prompt_tokens=%d wall_duration_sec=%.1f\n", tokens.Estimate(p.System+"\n"+p.User), res.WallDuration.Seconds())
 				}
 				requestOpts := *opts.GenOpts
@@ -274,9 +309,13 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 					sumCompletion += usage.EvalCount
 					sumDuration += usage.EvalDurationNs
 				}
-				batch := findings.FilterAbstention(list, opts.MinKeep, opts.MinMaint)
 				if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 					opts.TraceOut.Section("Post-filters")
+				}
+				list = applySuppressions(list, p.Hunk, opts.Suppress, opts.ToHead, opts.TraceOut)
+				applyCalibration(list, opts.Calibration, opts.Model, opts.TraceOut)
+				batch := findings.FilterAbstention(list, opts.MinKeep, opts.MinMaint)
+				if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 					opts.TraceOut.Printf("Abstention: %d -> %d\n", len(list), len(batch))
 				}
 				if opts.ApplyFP {
@@ -286,9 +325,9 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 						opts.TraceOut.Printf("FP kill list: %d -> %d\n", beforeFP, len(batch))
 					}
 				}
-				if hunkStart, hunkEnd, ok := expand.HunkLineRange(p.Hunk); ok {
+				if ranges := hunkLineRanges(p.Hunk); len(ranges) > 0 {
 					beforeEvidence := len(batch)
-					batch = findings.FilterByHunkLines(batch, p.Hunk.FilePath, hunkStart, hunkEnd)
+					batch = findings.FilterByHunkRanges(batch, p.Hunk.FilePath, ranges)
 					if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 						opts.TraceOut.Printf("Evidence (hunk lines): %d -> %d\n", beforeEvidence, len(batch))
 					}
@@ -304,7 +343,7 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 					}
 					kept := batch[:0]
 					for _, f := range batch {
-						keep, verr := review.VerifyFinding(ctx, opts.Client, opts.CriticModel, f, p.Hunk.RawContent, criticOpts)
+						keep, verr := review.VerifyFinding(ctx, opts.Client, opts.CriticModel, f, hunkForFinding(p.Hunk, f).RawContent, criticOpts)
 						if verr != nil {
 							if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 								opts.TraceOut.Printf("Critic request failed: %v\n", verr)
@@ -320,12 +359,12 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 						opts.TraceOut.Printf("Critic: %d -> %d\n", beforeCritic, len(batch))
 					}
 				}
-				findings.SetCursorURIs(opts.RepoRoot, batch)
-				hunkCtx := truncateForPromptContext(p.Hunk.RawContent, maxPromptContextStoreLen)
+				atCommit := findingIDSet(batch)
+				batch = appendDanglingFindings(batch, p, &opts)
+				batch = applyConfigFilters(batch, opts.Filters, opts.TraceOut)
+				batch = applyBaseline(batch, p.Hunk, opts.Baseline, opts.TraceOut)
+				finishFindings(batch, p.Hunk, opts.RepoRoot, opts.Commit, opts.ToHead, atCommit, findingPromptContext)
 				for _, f := range batch {
-					if f.ID != "" {
-						findingPromptContext[f.ID] = hunkCtx
-					}
 					if opts.StreamOut != nil {
 						tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "finding", "data": f})
 					}
@@ -359,7 +398,9 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 			}
 			if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 				opts.TraceOut.Section("Hunk " + fmt.Sprintf("%d/%d", p.Index+1, total) + ": " + p.Hunk.FilePath)
-				opts.TraceOut.Printf("strict_id=%s semantic_id=%s\n", hunkid.StrictHunkID(p.Hunk.FilePath, p.Hunk.RawContent), hunkid.SemanticHunkID(p.Hunk.FilePath, p.Hunk.RawContent))
+				for _, h := range hunkParts(p.Hunk) {
+					opts.TraceOut.Printf("strict_id=%s semantic_id=%s\n", hunkid.StrictHunkID(h.FilePath, h.RawContent), hunkid.SemanticHunkIDLevel(h.FilePath, h.RawContent, opts.HunkIDLevel))
+				}
 				opts.TraceOut.Printf("estimated_prompt_tokens=%d wall_duration_sec=%.1f\n", tokens.Estimate(p.System+"\n"+p.User), res.WallDuration.Seconds())
 			}
 			requestOpts := *opts.GenOpts
@@ -377,9 +418,13 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 				sumCompletion += usage.EvalCount
 				sumDuration += usage.EvalDurationNs
 			}
-			batch := findings.FilterAbstention(list, opts.MinKeep, opts.MinMaint)
 			if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 				opts.TraceOut.Section("Post-filters")
+			}
+			list = applySuppressions(list, p.Hunk, opts.Suppress, opts.ToHead, opts.TraceOut)
+			applyCalibration(list, opts.Calibration, opts.Model, opts.TraceOut)
+			batch := findings.FilterAbstention(list, opts.MinKeep, opts.MinMaint)
+			if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 				opts.TraceOut.Printf("Abstention: %d -> %d\n", len(list), len(batch))
 			}
 			if opts.ApplyFP {
@@ -389,9 +434,9 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 					opts.TraceOut.Printf("FP kill list: %d -> %d\n", beforeFP, len(batch))
 				}
 			}
-			if hunkStart, hunkEnd, ok := expand.HunkLineRange(p.Hunk); ok {
+			if ranges := hunkLineRanges(p.Hunk); len(ranges) > 0 {
 				beforeEvidence := len(batch)
-				batch = findings.FilterByHunkLines(batch, p.Hunk.FilePath, hunkStart, hunkEnd)
+				batch = findings.FilterByHunkRanges(batch, p.Hunk.FilePath, ranges)
 				if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 					opts.TraceOut.Printf("Evidence (hunk lines): %d -> %d\n", beforeEvidence, len(batch))
 				}
@@ -410,7 +455,7 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 				// verification is not possible without upstream API changes.
 				kept := batch[:0]
 				for _, f := range batch {
-					keep, verr := review.VerifyFinding(ctx, opts.Client, opts.CriticModel, f, p.Hunk.RawContent, criticOpts)
+					keep, verr := review.VerifyFinding(ctx, opts.Client, opts.CriticModel, f, hunkForFinding(p.Hunk, f).RawContent, criticOpts)
 					if verr != nil {
 						if opts.TraceOut != nil && opts.TraceOut.Enabled() {
 							opts.TraceOut.Printf("Critic request failed: %v\n", verr)
@@ -426,12 +471,12 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 					opts.TraceOut.Printf("Critic: %d -> %d\n", beforeCritic, len(batch))
 				}
 			}
-			findings.SetCursorURIs(opts.RepoRoot, batch)
-			hunkCtx := truncateForPromptContext(p.Hunk.RawContent, maxPromptContextStoreLen)
+			atCommit := findingIDSet(batch)
+			batch = appendDanglingFindings(batch, p, &opts)
+			batch = applyConfigFilters(batch, opts.Filters, opts.TraceOut)
+			batch = applyBaseline(batch, p.Hunk, opts.Baseline, opts.TraceOut)
+			finishFindings(batch, p.Hunk, opts.RepoRoot, opts.Commit, opts.ToHead, atCommit, findingPromptContext)
 			for _, f := range batch {
-				if f.ID != "" {
-					findingPromptContext[f.ID] = hunkCtx
-				}
 				if opts.StreamOut != nil {
 					tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "finding", "data": f})
 				}
@@ -443,6 +488,140 @@ func runReviewPipeline(ctx context.Context, opts reviewPipelineOpts) (collected
 	return collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, nil
 }
 
+// loadSymbolIndex loads (or builds and persists) the symbol index for the repo's
+// HEAD tree when enabled. Timing and build mode are written to tr. On failure the
+// error is traced and nil is returned so resolvers fall back to git grep.
+func loadSymbolIndex(ctx context.Context, repoRoot, stateDir string, enabled bool, tr *trace.Tracer) rag.SymbolIndex {
+	if !enabled {
+		return nil
+	}
+	idx, st, err := symindex.Load(ctx, repoRoot, stateDir)
+	if tr != nil && tr.Enabled() {
+		tr.Section("Symbol index")
+		if err != nil {
+			tr.Printf("error=%v (falling back to git grep)\n", err)
+		} else {
+			tr.Printf("mode=%s tree=%s base_tree=%s files_read=%d files_indexed=%d definitions=%d persisted=%t duration=%s\n",
+				st.Mode, st.TreeSHA, st.BaseTreeSHA, st.FilesRead, st.FilesIndexed, st.Definitions, st.Persisted, st.Duration.Round(time.Millisecond))
+		}
+	}
+	if err != nil {
+		return nil
+	}
+	return idx
+}
+
+// loadCoverageProfile reads the coverage profile at path (relative to repoRoot
+// unless absolute). Returns nil, nil when path is empty. Unlike the symbol index,
+// a profile the user asked for that cannot be read is an error.
+func loadCoverageProfile(repoRoot, path string, tr *trace.Tracer) (*coverage.Profile, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoRoot, path)
+	}
+	p, err := coverage.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if tr != nil && tr.Enabled() {
+		tr.Section("Coverage profile")
+		tr.Printf("path=%s format=%s\n", p.Path, p.Format)
+	}
+	return p, nil
+}
+
+// appendDanglingFindings adds findings for references left behind by the
+// declarations p's hunk removed (dangling.Check ran in the preparer) and traces
+// every removed symbol, including removals confirmed safe. The findings go
+// through inline suppressions, abstention and the FP kill list like model
+// findings, and the caller applies config filters after appending them; the
+// evidence filter and critic judge the hunk and do not apply.
+func appendDanglingFindings(batch []findings.Finding, p *preparedPrompt, opts *reviewPipelineOpts) []findings.Finding {
+	if len(p.Dangling) == 0 {
+		return batch
+	}
+	tr := opts.TraceOut
+	if tr != nil && tr.Enabled() {
+		tr.Section("Removed symbols")
+		for _, r := range p.Dangling {
+			tr.Printf("%s\n", dangling.Summary(r))
+		}
+	}
+	list := dangling.Findings(p.Hunk, p.Dangling)
+	kept := opts.Suppress.Filter(list)
+	kept = findings.FilterAbstention(kept, opts.MinKeep, opts.MinMaint)
+	if opts.ApplyFP {
+		kept = findings.FilterFPKillList(kept)
+	}
+	if len(kept) < len(list) && tr != nil && tr.Enabled() {
+		tr.Printf("Removed-symbol filters: %d -> %d\n", len(list), len(kept))
+	}
+	return append(batch, kept...)
+}
+
+// changeSummary runs the whole-change summary pass: commit messages in
+// baseline..head plus the file list and hunk headers of hunks are summarized
+// by the model once, for injection into every hunk's system prompt. Failures
+// are warnings (the review proceeds without a summary); returns "" and nil then.
+func changeSummary(ctx context.Context, client llm.Client, model, repoRoot, baseline, head string, hunks []diff.Hunk, genOpts *ollama.GenerateOptions, tr *trace.Tracer) (string, *ollama.GenerateResult) {
+	var msgs []string
+	shas, err := git.RevList(repoRoot, baseline, head)
+	if err == nil {
+		msgs, err = git.CommitMessages(repoRoot, shas)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read commit messages for change summary: %v\n", err)
+	}
+	overview := summary.BuildOverview(msgs, hunks, 0)
+	text, res, err := summary.Summarize(ctx, client, model, overview, genOpts)
+	if tr != nil && tr.Enabled() {
+		tr.Section("Change summary")
+		tr.Printf("commits=%d hunks=%d overview_bytes=%d\n", len(msgs), len(hunks), len(overview))
+		if err != nil {
+			tr.Printf("error=%v\n", err)
+		} else {
+			tr.Printf("%s\n", text)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not build change summary: %v\n", err)
+		return "", nil
+	}
+	return text, res
+}
+
+// planHunks shapes the hunks to review into prompts via diff.Plan (merge
+// nearby small hunks, split oversized ones) and, when batchTokens > 0,
+// diff.BatchByFile. Partitioning and auto-dismiss keep using the raw hunks,
+// so hunk IDs do not depend on the plan.
+func planHunks(ctx context.Context, repoRoot, head string, hunks []diff.Hunk, maxLines, mergeGap, batchTokens int, tr *trace.Tracer) []diff.Hunk {
+	planned := diff.Plan(ctx, repoRoot, head, hunks, diff.PlanOptions{MaxLines: maxLines, MergeGap: mergeGap})
+	batched := diff.BatchByFile(planned, batchTokens)
+	if tr != nil && tr.Enabled() {
+		tr.Section("Hunk plan")
+		tr.Printf("max_lines=%d merge_gap=%d batch_tokens=%d hunks=%d planned=%d prompts=%d\n", maxLines, mergeGap, batchTokens, len(hunks), len(planned), len(batched))
+	}
+	return batched
+}
+
+// fileBatchTokens returns the token budget for the hunks of one file batched
+// into a prompt: a quarter of contextLimit (leaving room for the system
+// prompt, rules, RAG and the response), capped at fileBatchMaxTokensCap. 0
+// (no batching) when disabled or when search-replace format is used, since
+// that format cannot show several hunks apart.
+func fileBatchTokens(enabled, searchReplace bool, contextLimit int) int {
+	if !enabled || searchReplace {
+		return 0
+	}
+	budget := contextLimit / 4
+	if budget <= 0 || budget > fileBatchMaxTokensCap {
+		budget = fileBatchMaxTokensCap
+	}
+	return budget
+}
+
 // runPromptShadows converts the session's PromptShadows to []prompt.Shadow for injection.
 func runPromptShadows(s *session.Session) []prompt.Shadow {
 	if s == nil || len(s.PromptShadows) == 0 {
@@ -453,6 +632,7 @@ func runPromptShadows(s *session.Session) []prompt.Shadow {
 		out[i] = prompt.Shadow{
 			FindingID:     s.PromptShadows[i].FindingID,
 			PromptContext: s.PromptShadows[i].PromptContext,
+			Note:          s.PromptShadows[i].Note,
 		}
 	}
 	return out
@@ -490,6 +670,189 @@ func tryWriteStreamLine(w io.Writer, obj interface{}) {
 	}
 }
 
+// hunkParts returns the hunks a prompt covers: the parts of a batched hunk
+// (diff.BatchByFile), else h alone.
+func hunkParts(h diff.Hunk) []diff.Hunk {
+	if len(h.Parts) > 0 {
+		return h.Parts
+	}
+	return []diff.Hunk{h}
+}
+
+// hunkLineRanges returns the new-file line range of each hunk a prompt covers,
+// for the evidence filter. Unparsable headers are skipped.
+func hunkLineRanges(h diff.Hunk) [][2]int {
+	var ranges [][2]int
+	for _, part := range hunkParts(h) {
+		if start, end, ok := expand.HunkLineRange(part); ok {
+			ranges = append(ranges, [2]int{start, end})
+		}
+	}
+	return ranges
+}
+
+// hunkForFinding attributes f to the first hunk of a batched prompt that its
+// location (Line or Range) overlaps, so prompt context and the critic see that
+// hunk only. File-level and unattributable findings map to the first part; h
+// itself is returned when it is not batched.
+func hunkForFinding(h diff.Hunk, f findings.Finding) diff.Hunk {
+	if len(h.Parts) == 0 {
+		return h
+	}
+	lo, hi := f.Line, f.Line
+	if f.Range != nil {
+		lo, hi = f.Range.Start, f.Range.End
+	}
+	for _, part := range h.Parts {
+		if start, end, ok := expand.HunkLineRange(part); ok && lo <= end && hi >= start {
+			return part
+		}
+	}
+	return h.Parts[0]
+}
+
+// applySuppressions records the lines of hunk as reviewed and drops the findings
+// covered by an inline stet:ignore directive (see package suppress). The
+// directives are read from HEAD, so with toHead (per-commit review) lines are
+// mapped to HEAD first; the returned findings keep their own lines. sup and
+// toHead may be nil.
+func applySuppressions(batch []findings.Finding, hunk diff.Hunk, sup *suppress.Tracker, toHead *diff.LineMap, tr *trace.Tracer) []findings.Finding {
+	if sup == nil {
+		return batch
+	}
+	for _, part := range hunkParts(hunk) {
+		if start, end, ok := expand.HunkLineRange(part); ok {
+			path := part.FilePath
+			if toHead != nil {
+				loc := toHead.Locate(path, start, end)
+				if loc.Deleted {
+					continue
+				}
+				path, start, end = loc.Path, loc.Start, loc.End
+			}
+			sup.Reviewed(path, start, end)
+		}
+	}
+	var kept []findings.Finding
+	if toHead == nil {
+		kept = sup.Filter(batch)
+	} else {
+		for _, f := range batch {
+			atHead, _, _ := relocateFinding(f, toHead)
+			if len(sup.Filter([]findings.Finding{atHead})) == 1 {
+				kept = append(kept, f)
+			}
+		}
+	}
+	if len(kept) < len(batch) && tr != nil && tr.Enabled() {
+		tr.Printf("Inline suppressions: %d -> %d\n", len(batch), len(kept))
+	}
+	return kept
+}
+
+// finishFindings sets the file status and commit of batch from hunk and records
+// each finding's prompt context, then moves the findings to their lines at HEAD
+// with toHead (per-commit review; only IDs in atCommit, or all when atCommit is
+// nil) and sets their cursor URIs. toHead may be nil.
+func finishFindings(batch []findings.Finding, hunk diff.Hunk, repoRoot, commit string, toHead *diff.LineMap, atCommit map[string]struct{}, promptContext map[string]string) {
+	findings.SetFileStatus(batch, hunk.FilePath, string(hunk.Status), hunk.OldPath)
+	findings.SetCommit(batch, commit)
+	for _, f := range batch {
+		if f.ID != "" {
+			promptContext[f.ID] = truncateForPromptContext(hunkForFinding(hunk, f).RawContent, maxPromptContextStoreLen)
+		}
+	}
+	if toHead != nil {
+		for i := range batch {
+			if _, ok := atCommit[batch[i].ID]; ok || atCommit == nil {
+				batch[i], _, _ = relocateFinding(batch[i], toHead)
+			}
+		}
+	}
+	findings.SetCursorURIs(repoRoot, batch)
+}
+
+// findingIDSet returns the IDs of list.
+func findingIDSet(list []findings.Finding) map[string]struct{} {
+	ids := make(map[string]struct{}, len(list))
+	for _, f := range list {
+		ids[f.ID] = struct{}{}
+	}
+	return ids
+}
+
+// suppressionStats fills the inline suppression counts of st from sup and
+// traces the stale directives.
+func suppressionStats(st RunStats, sup *suppress.Tracker, tr *trace.Tracer) RunStats {
+	st.Suppressed = sup.Suppressed()
+	for _, d := range sup.Stale() {
+		st.StaleSuppressions = append(st.StaleSuppressions, d.String())
+	}
+	if tr != nil && tr.Enabled() && (st.Suppressed > 0 || len(st.StaleSuppressions) > 0) {
+		tr.Section("Inline suppressions")
+		tr.Printf("suppressed=%d\n", st.Suppressed)
+		for _, d := range st.StaleSuppressions {
+			tr.Printf("stale: %s\n", d)
+		}
+	}
+	return st
+}
+
+// loadCalibration returns the confidence calibration of stateDir when enabled.
+// A missing or unreadable file disables calibration for the review (with a
+// warning when unreadable) rather than failing it.
+func loadCalibration(stateDir string, enabled bool, tr *trace.Tracer) *calibration.Calibration {
+	if !enabled {
+		return nil
+	}
+	cal, err := calibration.Load(stateDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; confidence is not calibrated.\n", err)
+		return nil
+	}
+	if cal != nil && tr != nil && tr.Enabled() {
+		tr.Printf("Calibration: %d curve(s) fitted at %s\n", len(cal.Curves), cal.FittedAt)
+	}
+	return cal
+}
+
+// applyCalibration replaces the confidence of the findings in batch with the
+// value calibrated for model (see calibration.Calibration.Apply). cal may be nil.
+// The model's value is kept in RawConfidence, which findings.FilterAbstention checks.
+func applyCalibration(batch []findings.Finding, cal *calibration.Calibration, model string, tr *trace.Tracer) {
+	if n := cal.Apply(batch, model); n > 0 && tr != nil && tr.Enabled() {
+		tr.Printf("Calibration: %d of %d finding(s) calibrated\n", n, len(batch))
+	}
+}
+
+// applyConfigFilters applies flt, the team filters from config [filters], and
+// traces the count for each rule that matched. flt may be nil.
+func applyConfigFilters(batch []findings.Finding, flt *findings.Filter, tr *trace.Tracer) []findings.Finding {
+	kept, hits := flt.Apply(batch)
+	if len(hits) > 0 && tr != nil && tr.Enabled() {
+		tr.Printf("Config filters: %d -> %d\n", len(batch), len(kept))
+		for _, h := range hits {
+			tr.Printf("  %s: %d\n", h.Rule, h.Count)
+		}
+	}
+	return kept
+}
+
+// applyBaseline sets each finding's baseline fingerprint from the hunk it is
+// attributed to (hunkForFinding) and drops the findings listed in bl, the
+// committed baseline. bl may be nil.
+func applyBaseline(batch []findings.Finding, hunk diff.Hunk, bl *baseline.Baseline, tr *trace.Tracer) []findings.Finding {
+	for i := range batch {
+		part := hunkForFinding(hunk, batch[i])
+		batch[i].Fingerprint = baseline.Fingerprint(part.FilePath, part.RawContent, batch[i].Message)
+	}
+	kept, dropped := bl.Filter(batch)
+	if dropped > 0 && tr != nil && tr.Enabled() {
+		tr.Printf("Baseline: %d -> %d\n", len(batch), len(kept))
+	}
+	return kept
+}
+
 // cannedFindingsForHunks returns one deterministic finding per hunk for dry-run
 // (CI). IDs are stable and unique per hunk via StrictHunkID in the message stem.
 func cannedFindingsForHunks(hunks []diff.Hunk) []findings.Finding {
@@ -529,8 +892,9 @@ type hunkLineRange struct {
 
 // addressedFindingIDs returns IDs of existing findings that lie inside a reviewed hunk
 // and are not in newFindingIDSet (i.e. re-review did not report them, so they are considered addressed).
-// Finding location uses f.Line, or f.Range.Start when Range is set.
-func addressedFindingIDs(hunks []diff.Hunk, existingFindings []findings.Finding, newFindingIDSet map[string]struct{}) []string {
+// Finding location uses f.Line, or f.Range.Start when Range is set. When modified is non-nil
+// (findings were relocated, see relocateFindings), only findings in modified can be addressed.
+func addressedFindingIDs(hunks []diff.Hunk, existingFindings []findings.Finding, newFindingIDSet, modified map[string]struct{}) []string {
 	var ranges []hunkLineRange
 	for _, h := range hunks {
 		start, end, ok := expand.HunkLineRange(h)
@@ -556,6 +920,11 @@ func addressedFindingIDs(hunks []diff.Hunk, existingFindings []findings.Finding,
 		if _, inNew := newFindingIDSet[f.ID]; inNew {
 			continue
 		}
+		if modified != nil {
+			if _, ok := modified[f.ID]; !ok {
+				continue
+			}
+		}
 		line := f.Line
 		if f.Range != nil {
 			line = f.Range.Start
@@ -570,17 +939,18 @@ func addressedFindingIDs(hunks []diff.Hunk, existingFindings []findings.Finding,
 	return addressed
 }
 
-// applyAutoDismiss updates s.DismissedIDs for findings in toReview that are not in newFindingIDSet
-// and appends a history record when any are addressed. Caller must save the session after.
+// applyAutoDismiss marks findings in toReview that are not in newFindingIDSet as fixed (adding them
+// to s.DismissedIDs) and appends a history record when any are addressed. Caller must save the session after.
+// modified is passed to addressedFindingIDs (nil when findings were not relocated).
 // runConfig is attached to the history record when non-nil.
-func applyAutoDismiss(s *session.Session, toReview []diff.Hunk, newFindingIDSet map[string]struct{}, headSHA, stateDir string, runConfig *history.RunConfigSnapshot) error {
+func applyAutoDismiss(s *session.Session, toReview []diff.Hunk, newFindingIDSet, modified map[string]struct{}, headSHA, stateDir string, runConfig *history.RunConfigSnapshot) error {
 	if stateDir == "" {
 		return erruser.New("Could not record review history: state directory is required.", nil)
 	}
 	if s == nil || len(s.Findings) == 0 {
 		return nil
 	}
-	addressed := addressedFindingIDs(toReview, s.Findings, newFindingIDSet)
+	addressed := addressedFindingIDs(toReview, s.Findings, newFindingIDSet, modified)
 	dismissedSet := make(map[string]struct{}, len(s.DismissedIDs))
 	for _, id := range s.DismissedIDs {
 		dismissedSet[id] = struct{}{}
@@ -588,7 +958,7 @@ func applyAutoDismiss(s *session.Session, toReview []diff.Hunk, newFindingIDSet
 	for _, id := range addressed {
 		if _, ok := dismissedSet[id]; !ok {
 			dismissedSet[id] = struct{}{}
-			s.DismissedIDs = append(s.DismissedIDs, id)
+			session.SetStatus(s, id, findings.StatusFixed, "", "")
 		}
 	}
 	if len(addressed) == 0 {
@@ -617,6 +987,148 @@ func applyAutoDismiss(s *session.Session, toReview []diff.Hunk, newFindingIDSet
 	return nil
 }
 
+// relocateFindings moves the active (not dismissed) findings of s from their position at
+// s.LastReviewedAt to their position at headSHA, following the line mapping of
+// git diff last_reviewed_at..HEAD: File (on rename), Line, Range and CursorURI are updated.
+// It returns the IDs of active findings whose anchored lines were changed or removed; only
+// those may be auto-dismissed. Returns nil when the session has no last review.
+func relocateFindings(ctx context.Context, repoRoot string, s *session.Session, headSHA string) (map[string]struct{}, error) {
+	if s.LastReviewedAt == "" {
+		return nil, nil
+	}
+	modified := make(map[string]struct{})
+	if len(s.Findings) == 0 || s.LastReviewedAt == headSHA {
+		return modified, nil
+	}
+	lm, err := diff.NewLineMap(ctx, repoRoot, s.LastReviewedAt, headSHA)
+	if err != nil {
+		return nil, err
+	}
+	dismissed := make(map[string]struct{}, len(s.DismissedIDs))
+	for _, id := range s.DismissedIDs {
+		dismissed[id] = struct{}{}
+	}
+	var moved []findings.Finding
+	for i := range s.Findings {
+		f := &s.Findings[i]
+		if _, ok := dismissed[f.ID]; ok || f.ID == "" {
+			continue
+		}
+		relocated, loc, ok := relocateFinding(*f, lm)
+		if loc.Modified || loc.Deleted {
+			modified[f.ID] = struct{}{}
+		}
+		if !ok {
+			continue
+		}
+		*f = relocated
+		moved = append(moved, *f)
+	}
+	if len(moved) > 0 {
+		findings.SetCursorURIs(repoRoot, moved)
+		byID := make(map[string]string, len(moved))
+		for _, f := range moved {
+			byID[f.ID] = f.CursorURI
+		}
+		for i := range s.Findings {
+			if uri, ok := byID[s.Findings[i].ID]; ok {
+				s.Findings[i].CursorURI = uri
+			}
+		}
+	}
+	return modified, nil
+}
+
+// relocateFinding returns f moved to its position in lm (File on rename, Line
+// and Range), where its lines went, and whether it moved. A moved finding has
+// its CursorURI cleared. Findings without a line and findings whose file was
+// deleted do not move.
+func relocateFinding(f findings.Finding, lm *diff.LineMap) (relocated findings.Finding, loc diff.Location, moved bool) {
+	start, end := f.Line, f.Line
+	if f.Range != nil {
+		start, end = f.Range.Start, f.Range.End
+	}
+	if start <= 0 {
+		return f, loc, false
+	}
+	loc = lm.Locate(f.File, start, end)
+	if loc.Deleted || (loc.Path == f.File && loc.Start == start && loc.End == end) {
+		return f, loc, false
+	}
+	f.File = loc.Path
+	if f.Range != nil {
+		f.Range = &findings.LineRange{Start: loc.Start, End: loc.End}
+		if f.Line > 0 {
+			f.Line = loc.Start
+		}
+	} else {
+		f.Line = loc.Start
+	}
+	f.CursorURI = ""
+	return f, loc, true
+}
+
+// dropRelocatedDuplicates removes from newFindings the findings that re-report an existing
+// finding at its relocated position: the new ID is then the stable ID of the existing
+// finding's current location and message, but differs from the existing ID. The existing
+// ID is added to newFindingIDSet (re-reported, so not addressed) and keeps the new prompt context.
+func dropRelocatedDuplicates(s *session.Session, newFindings []findings.Finding, newFindingIDSet map[string]struct{}) []findings.Finding {
+	existingByStableID := make(map[string]string, len(s.Findings))
+	for _, f := range s.Findings {
+		if f.ID == "" {
+			continue
+		}
+		var rs, re int
+		if f.Range != nil {
+			rs, re = f.Range.Start, f.Range.End
+		}
+		if sid := hunkid.StableFindingID(f.File, f.Line, rs, re, f.Message); sid != f.ID {
+			existingByStableID[sid] = f.ID
+		}
+	}
+	if len(existingByStableID) == 0 {
+		return newFindings
+	}
+	out := newFindings[:0]
+	for _, f := range newFindings {
+		oldID, ok := existingByStableID[f.ID]
+		if !ok {
+			out = append(out, f)
+			continue
+		}
+		newFindingIDSet[oldID] = struct{}{}
+		if c := s.FindingPromptContext[f.ID]; c != "" {
+			s.FindingPromptContext[oldID] = c
+		}
+		delete(s.FindingPromptContext, f.ID)
+	}
+	return out
+}
+
+// mergeFindings appends added to existing. A finding of added whose ID is already
+// in existing replaces it in place and keeps its lifecycle (Status, DeferredUntil,
+// DismissReason and Comments), so a re-reported finding that was accepted or
+// deferred does not come back as a second, open copy.
+func mergeFindings(existing, added []findings.Finding) []findings.Finding {
+	byID := make(map[string]int, len(existing))
+	for i, f := range existing {
+		if f.ID != "" {
+			byID[f.ID] = i
+		}
+	}
+	for _, f := range added {
+		i, ok := byID[f.ID]
+		if !ok || f.ID == "" {
+			existing = append(existing, f)
+			continue
+		}
+		old := existing[i]
+		f.Status, f.DeferredUntil, f.DismissReason, f.Comments = old.Status, old.DeferredUntil, old.DismissReason, old.Comments
+		existing[i] = f
+	}
+	return existing
+}
+
 // dismissedLocation is file + line range (1-based, inclusive) for a dismissed finding.
 type dismissedLocation struct {
 	file                string
@@ -682,7 +1194,15 @@ type StartOptions struct {
 	RepoRoot                string
 	StateDir                string
 	WorktreeRoot            string
+	// Session is the session name (stet --session); "" is the default session.
+	Session                 string
 	Ref                     string
+	// Against, when set, is a target branch (e.g. origin/main): the baseline is its merge
+	// base with HEAD instead of Ref, and the branch is recorded in the session.
+	Against                 string
+	// PerCommit reviews each commit of baseline..HEAD on its own with its message as intent;
+	// findings record the commit SHA.
+	PerCommit               bool
 	DryRun                  bool
 	AllowDirty              bool
 	Model                   string
@@ -702,6 +1222,22 @@ type StartOptions struct {
 	RAGCallersMax           int
 	RAGCalleesMax           int
 	RAGCallGraphMaxTokens   int
+	RAGGoTypesEnabled       bool
+	RAGSymbolIndexEnabled   bool
+	RAGRelatedTestsEnabled  bool
+	// CoverageProfile is a Go coverprofile or LCOV file (relative to RepoRoot when not absolute); "" = none.
+	CoverageProfile         string
+	// SummaryEnabled runs the whole-change summary pass and injects its result into every hunk prompt.
+	SummaryEnabled          bool
+	// DanglingRefsEnabled reports references left in HEAD to declarations removed by a hunk.
+	DanglingRefsEnabled     bool
+	// HunkMaxLines and HunkMergeGap shape hunks into review units (see diff.Plan); 0 disables each step.
+	HunkMaxLines            int
+	HunkMergeGap            int
+	// FileBatchingEnabled reviews all hunks of a file in one prompt when they fit the batch budget (see diff.BatchByFile).
+	FileBatchingEnabled     bool
+	// HunkIDNormalization is the semantic hunk ID level (text, tokens, ast) used to carry reviews across edits.
+	HunkIDNormalization     string
 	// MinConfidenceKeep and MinConfidenceMaintainability are abstention thresholds (0,0 = use 0.8, 0.9).
 	// ApplyFPKillList nil = apply FP kill list (true); set to false for strict+ presets.
 	MinConfidenceKeep            float64
@@ -713,6 +1249,11 @@ type StartOptions struct {
 	CriticEnabled bool
 	// CriticModel is the model name for the critic; default qwen3-coder:30b (same as main) so one model stays loaded; used only when CriticEnabled and not DryRun.
 	CriticModel string
+	// Filters are the team finding filters from config [filters] (see findings.NewFilter); applied after the FP kill list.
+	Filters findings.FilterRules
+	// CalibrationEnabled replaces finding confidence with the value calibrated from history
+	// (StateDir/calibration.json); abstention thresholds still apply to the raw confidence.
+	CalibrationEnabled bool
 	// Session-persisted options (from stet start flags); when set, stored in session.
 	PersistStrictness              *string
 	PersistRAGSymbolMaxDefinitions *int
@@ -735,6 +1276,7 @@ type FinishOptions struct {
 	RepoRoot     string
 	StateDir     string
 	WorktreeRoot string
+	Session      string // session name; "" is the default session
 }
 
 // RunOptions configures Run. DryRun skips the LLM and injects canned findings.
@@ -746,6 +1288,8 @@ type FinishOptions struct {
 type RunOptions struct {
 	RepoRoot                     string
 	StateDir                     string
+	// Session is the session name (stet --session); "" is the default session.
+	Session                      string
 	DryRun                       bool
 	Model                        string
 	Provider                     string // "ollama" or "openai"
@@ -764,12 +1308,30 @@ type RunOptions struct {
 	RAGCallersMax                int
 	RAGCalleesMax                int
 	RAGCallGraphMaxTokens        int
+	RAGGoTypesEnabled            bool
+	RAGSymbolIndexEnabled        bool
+	RAGRelatedTestsEnabled       bool
+	// CoverageProfile is a Go coverprofile or LCOV file (relative to RepoRoot when not absolute); "" = none.
+	CoverageProfile              string
+	// SummaryEnabled runs the whole-change summary pass and injects its result into every hunk prompt.
+	SummaryEnabled               bool
+	// DanglingRefsEnabled reports references left in HEAD to declarations removed by a hunk.
+	DanglingRefsEnabled          bool
+	// HunkMaxLines and HunkMergeGap shape hunks into review units (see diff.Plan); 0 disables each step.
+	HunkMaxLines                 int
+	HunkMergeGap                 int
+	// FileBatchingEnabled reviews all hunks of a file in one prompt when they fit the batch budget (see diff.BatchByFile).
+	FileBatchingEnabled          bool
+	// HunkIDNormalization is the semantic hunk ID level (text, tokens, ast) used to carry reviews across edits.
+	HunkIDNormalization          string
 	MinConfidenceKeep            float64
 	MinConfidenceMaintainability float64
 	ApplyFPKillList              *bool
 	Nitpicky                     bool
 	CriticEnabled                bool
 	CriticModel                  string
+	Filters                      findings.FilterRules
+	CalibrationEnabled           bool
 	// TraceOut, when non-nil, receives internal trace output. Used when --trace is set.
 	TraceOut io.Writer
 	// UseSearchReplaceFormat, when true, sends the hunk in search-replace style for testing.
@@ -790,6 +1352,404 @@ type RunStats struct {
 	PromptTokens     int64
 	CompletionTokens int64
 	EvalDurationNs   int64
+	// Suppressed is the number of findings dropped by inline stet:ignore directives.
+	Suppressed int
+	// StaleSuppressions lists directives ("file:line") whose lines were reviewed
+	// but that suppressed nothing; candidates for removal.
+	StaleSuppressions []string
+}
+
+// reviewUnit is one diff reviewed by startReview: the whole baseline..HEAD range, a
+// single commit in per-commit mode (Commit set; its message replaces HEAD's as user intent),
+// or a patch file (Patch set; CommitMsg is its subject and the branch is not used).
+type reviewUnit struct {
+	Base, Head string
+	ToReview   []diff.Hunk
+	Approved   []diff.Hunk
+	Commit     string
+	CommitMsg  string
+	Patch      bool
+	Suppress   *suppress.Tracker // inline stet:ignore directives; shared across the units of one review
+	ToHead     *diff.LineMap     // per-commit review: maps lines at Head to the reviewed HEAD; nil when Head is HEAD
+	// ContextRoot is a worktree checked out at Head when Head is not the reviewed HEAD
+	// (per-commit review), so prompt context matches the hunks' line numbers; "" = RepoRoot.
+	ContextRoot string
+	Resources  *reviewResources  // symbol index and coverage profile shared across units; nil = load for this unit
+}
+
+// reviewResources are loaded once per review and shared by its units.
+type reviewResources struct {
+	SymbolIndex rag.SymbolIndex
+	Coverage    *coverage.Profile
+}
+
+// loadReviewResources loads the symbol index (when enabled) and coverage profile
+// for a review. See loadSymbolIndex and loadCoverageProfile.
+func loadReviewResources(ctx context.Context, opts StartOptions, tr *trace.Tracer) (*reviewResources, error) {
+	coverageProfile, err := loadCoverageProfile(opts.RepoRoot, opts.CoverageProfile, tr)
+	if err != nil {
+		return nil, err
+	}
+	return &reviewResources{
+		SymbolIndex: loadSymbolIndex(ctx, opts.RepoRoot, opts.StateDir, opts.RAGSymbolIndexEnabled && opts.RAGSymbolMaxDefinitions > 0, tr),
+		Coverage:    coverageProfile,
+	}, nil
+}
+
+// startReview runs the Start review loop (dry-run canned findings or the LLM pipeline)
+// over u and returns the collected findings with their prompt context and token usage.
+// Findings are tagged with u.Commit.
+func startReview(ctx context.Context, opts StartOptions, s *session.Session, llmClient llm.Client, u reviewUnit, tr *trace.Tracer) (collected []findings.Finding, findingPromptContext map[string]string, sumPrompt, sumCompletion int, sumDuration int64, err error) {
+	reviewHunks := planHunks(ctx, opts.RepoRoot, u.Head, u.ToReview, opts.HunkMaxLines, opts.HunkMergeGap, fileBatchTokens(opts.FileBatchingEnabled, opts.UseSearchReplaceFormat, opts.ContextLimit), tr)
+	bl, err := baseline.Load(opts.RepoRoot)
+	if err != nil {
+		return nil, nil, 0, 0, 0, err
+	}
+	flt, err := findings.NewFilter(opts.Filters)
+	if err != nil {
+		return nil, nil, 0, 0, 0, err
+	}
+	cal := loadCalibration(opts.StateDir, opts.CalibrationEnabled, tr)
+	minKeep, minMaint := opts.MinConfidenceKeep, opts.MinConfidenceMaintainability
+	if minKeep == 0 && minMaint == 0 {
+		minKeep, minMaint = findings.DefaultMinConfidenceKeep, findings.DefaultMinConfidenceMaintainability
+	}
+	applyFP := true
+	if opts.Nitpicky {
+		applyFP = false
+	} else if opts.ApplyFPKillList != nil {
+		applyFP = *opts.ApplyFPKillList
+	}
+
+	findingPromptContext = make(map[string]string)
+	total := len(reviewHunks)
+	if opts.StreamOut != nil {
+		tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("%d hunks to review", total)})
+	}
+
+	effectiveNumCtx := opts.NumCtx
+	effectiveContextLimit := opts.ContextLimit
+	// effectiveNumCtx is sent to Ollama; effectiveContextLimit is the single source of
+	// truth for token warnings and ReviewHunk RAG budget (config/flag/session only; no bump from Ollama Show).
+
+	if opts.DryRun {
+		for i, hunk := range reviewHunks {
+			if opts.StreamOut != nil {
+				tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("Reviewing hunk %d/%d: %s", i+1, total, hunk.FilePath)})
+			}
+			batch := cannedFindingsForHunks(hunkParts(hunk))
+			batch = applySuppressions(batch, hunk, u.Suppress, u.ToHead, tr)
+			batch = findings.FilterAbstention(batch, minKeep, minMaint)
+			if applyFP {
+				batch = findings.FilterFPKillList(batch)
+			}
+			batch = applyConfigFilters(batch, flt, tr)
+			if ranges := hunkLineRanges(hunk); len(ranges) > 0 {
+				batch = findings.FilterByHunkRanges(batch, hunk.FilePath, ranges)
+			}
+			batch = applyBaseline(batch, hunk, bl, tr)
+			finishFindings(batch, hunk, opts.RepoRoot, u.Commit, u.ToHead, nil, findingPromptContext)
+			for _, f := range batch {
+				if opts.StreamOut != nil {
+					tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "finding", "data": f})
+				}
+				collected = append(collected, f)
+			}
+		}
+	} else {
+		var branch, commitMsg string
+		if u.Patch {
+			commitMsg = u.CommitMsg
+		} else {
+			var intentErr error
+			branch, commitMsg, intentErr = git.UserIntent(opts.RepoRoot)
+			if intentErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not retrieve Git intent (branch/commit): %v; using placeholder\n", intentErr)
+			}
+			if u.Commit != "" {
+				commitMsg = u.CommitMsg
+			}
+		}
+		// Token estimation: warn once if any hunk's prompt would exceed context threshold (Phase 3.2).
+		if effectiveContextLimit > 0 && opts.WarnThreshold > 0 {
+			systemPrompt, err := prompt.SystemPrompt(opts.StateDir)
+			if err != nil {
+				return nil, nil, 0, 0, 0, err
+			}
+			systemPrompt = prompt.InjectUserIntent(systemPrompt, branch, commitMsg)
+			if opts.Nitpicky {
+				systemPrompt = prompt.AppendNitpickyInstructions(systemPrompt)
+			}
+			maxPromptTokens := 0
+			for _, h := range reviewHunks {
+				userPrompt := prompt.UserPrompt(h)
+				n := tokens.Estimate(systemPrompt + "\n" + userPrompt)
+				if n > maxPromptTokens {
+					maxPromptTokens = n
+				}
+			}
+			if w := tokens.WarnIfOver(maxPromptTokens, tokens.DefaultResponseReserve, effectiveContextLimit, opts.WarnThreshold); w != "" {
+				fmt.Fprintln(os.Stderr, w)
+			}
+		}
+		systemBase, err := prompt.SystemPrompt(opts.StateDir)
+		if err != nil {
+			return nil, nil, 0, 0, 0, err
+		}
+		systemBase = prompt.InjectUserIntent(systemBase, branch, commitMsg)
+		systemBase = prompt.AppendPromptShadows(systemBase, runPromptShadows(s))
+		if opts.Nitpicky {
+			systemBase = prompt.AppendNitpickyInstructions(systemBase)
+		}
+		// Load suppression examples once; applied per-hunk (as many as fit in token budget) in PrepareHunkPrompt.
+		var suppressionExamples []string
+		if opts.SuppressionEnabled && opts.SuppressionHistoryCount > 0 {
+			if examples, err := history.SuppressionExamples(opts.StateDir, opts.SuppressionHistoryCount, maxSuppressionExamples); err == nil && len(examples) > 0 {
+				suppressionExamples = examples
+			}
+		}
+		genOpts := &ollama.GenerateOptions{Temperature: opts.Temperature, NumCtx: effectiveNumCtx, MaxCompletionTokens: opts.MaxCompletionTokens, KeepAlive: keepAliveDuringRun}
+		var summaryRes *ollama.GenerateResult
+		if opts.SummaryEnabled && len(u.ToReview) > 0 {
+			var text string
+			text, summaryRes = changeSummary(ctx, llmClient, opts.Model, opts.RepoRoot, u.Base, u.Head, append(append([]diff.Hunk(nil), u.ToReview...), u.Approved...), genOpts, tr)
+			systemBase = prompt.InjectChangeSummary(systemBase, text)
+		}
+		rulesLoader := rules.NewLoader(opts.RepoRoot)
+		rulesByFile := make(map[string][]rules.CursorRule)
+		for _, h := range reviewHunks {
+			if _, ok := rulesByFile[h.FilePath]; !ok {
+				rulesByFile[h.FilePath] = rulesLoader.RulesForFile(h.FilePath)
+			}
+		}
+		res := u.Resources
+		if res == nil {
+			if res, err = loadReviewResources(ctx, opts, tr); err != nil {
+				return nil, nil, 0, 0, 0, err
+			}
+		}
+		if u.ContextRoot != "" {
+			// The symbol index and coverage profile describe HEAD; an older commit's
+			// checkout uses git grep and gets no coverage.
+			res = &reviewResources{}
+		}
+		collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, err = runReviewPipeline(ctx, reviewPipelineOpts{
+			Client:                  llmClient,
+			Model:                   opts.Model,
+			Hunks:                   reviewHunks,
+			GenOpts:                 genOpts,
+			SystemBase:              systemBase,
+			RepoRoot:                opts.RepoRoot,
+			ContextRoot:             u.ContextRoot,
+			EffectiveContextLimit:   effectiveContextLimit,
+			RAGSymbolMaxDefinitions: opts.RAGSymbolMaxDefinitions,
+			RAGSymbolMaxTokens:      opts.RAGSymbolMaxTokens,
+			RAGCallGraphEnabled:     opts.RAGCallGraphEnabled,
+			RAGCallersMax:           opts.RAGCallersMax,
+			RAGCalleesMax:           opts.RAGCalleesMax,
+			RAGCallGraphMaxTokens:   opts.RAGCallGraphMaxTokens,
+			RAGGoTypesEnabled:       opts.RAGGoTypesEnabled,
+			SymbolIndex:             res.SymbolIndex,
+			RelatedTests:            opts.RAGRelatedTestsEnabled,
+			CoverageProfile:         res.Coverage,
+			DanglingRefs:            opts.DanglingRefsEnabled,
+			RulesByFile:             rulesByFile,
+			MinKeep:                 minKeep,
+			MinMaint:                minMaint,
+			ApplyFP:                 applyFP,
+			CriticEnabled:          opts.CriticEnabled,
+			CriticModel:             opts.CriticModel,
+			StreamOut:               opts.StreamOut,
+			Verbose:                 opts.Verbose,
+			TraceOut:                tr,
+			UseSearchReplaceFormat:  opts.UseSearchReplaceFormat,
+			HunkIDLevel:             hunkid.Level(opts.HunkIDNormalization),
+			Commit:                  u.Commit,
+			ToHead:                  u.ToHead,
+			Baseline:                bl,
+			Suppress:                u.Suppress,
+			Filters:                 flt,
+			Calibration:             cal,
+			SuppressionExamples:     suppressionExamples,
+		})
+		if err != nil {
+			return nil, nil, 0, 0, 0, err
+		}
+		if summaryRes != nil {
+			sumPrompt += summaryRes.PromptEvalCount
+			sumCompletion += summaryRes.EvalCount
+			sumDuration += summaryRes.EvalDuration
+		}
+	}
+	return collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, nil
+}
+
+// startPerCommit reviews each commit of baseline..head on its own, oldest first, with the
+// commit's message as user intent, and tags the findings with the commit SHA. Merge
+// commits are skipped; their changes are reviewed in the commits they bring in. Each
+// commit's findings are moved to their lines at head (diff.LineMap), so they can be
+// listed, suppressed and relocated like findings of a whole-range review. The symbol
+// index and coverage profile are loaded once for all commits. Prompt context for a
+// commit older than head is read from a temporary worktree at that commit, so it
+// matches the hunks' line numbers.
+func startPerCommit(ctx context.Context, opts StartOptions, s *session.Session, llmClient llm.Client, baseline, head string, sup *suppress.Tracker, tr *trace.Tracer) (collected []findings.Finding, findingPromptContext map[string]string, sumPrompt, sumCompletion int, sumDuration int64, err error) {
+	shas, err := git.RevList(opts.RepoRoot, baseline, head)
+	if err != nil {
+		return nil, nil, 0, 0, 0, err
+	}
+	// rev-list is newest first.
+	for i, j := 0, len(shas)-1; i < j; i, j = i+1, j-1 {
+		shas[i], shas[j] = shas[j], shas[i]
+	}
+	msgs, err := git.CommitMessages(opts.RepoRoot, shas)
+	if err != nil {
+		return nil, nil, 0, 0, 0, err
+	}
+	var res *reviewResources
+	if !opts.DryRun {
+		if res, err = loadReviewResources(ctx, opts, tr); err != nil {
+			return nil, nil, 0, 0, 0, err
+		}
+	}
+	findingPromptContext = make(map[string]string)
+	for i, c := range shas {
+		merge, err := git.RefExists(opts.RepoRoot, c+"^2")
+		if err != nil {
+			return nil, nil, 0, 0, 0, err
+		}
+		if merge {
+			continue
+		}
+		hunks, err := diff.Hunks(ctx, opts.RepoRoot, c+"^", c, nil)
+		if err != nil {
+			return nil, nil, 0, 0, 0, erruser.New(fmt.Sprintf("Could not compute diff for commit %.12s.", c), err)
+		}
+		if len(hunks) == 0 {
+			continue
+		}
+		subject, _, _ := strings.Cut(msgs[i], "\n")
+		if opts.StreamOut != nil {
+			tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("Reviewing commit %d/%d %.12s: %s", i+1, len(shas), c, subject)})
+		}
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "Commit %.12s (%d hunks): %s\n", c, len(hunks), subject)
+		}
+		if tr.Enabled() {
+			tr.Section("Commit " + c)
+			tr.Printf("hunks=%d subject=%s\n", len(hunks), subject)
+		}
+		var toHead *diff.LineMap
+		var contextRoot string
+		if c != head {
+			if toHead, err = diff.NewLineMap(ctx, opts.RepoRoot, c, head); err != nil {
+				return nil, nil, 0, 0, 0, err
+			}
+			if !opts.DryRun {
+				if contextRoot, err = addCommitWorktree(opts.RepoRoot, c); err != nil {
+					return nil, nil, 0, 0, 0, err
+				}
+			}
+		}
+		batch, batchContext, p, cpl, d, err := startReview(ctx, opts, s, llmClient, reviewUnit{Base: c + "^", Head: c, ToReview: hunks, Commit: c, CommitMsg: msgs[i], Suppress: sup, ToHead: toHead, ContextRoot: contextRoot, Resources: res}, tr)
+		if contextRoot != "" {
+			removeCommitWorktree(opts.RepoRoot, contextRoot)
+		}
+		if err != nil {
+			return nil, nil, 0, 0, 0, err
+		}
+		collected = append(collected, batch...)
+		for id, pc := range batchContext {
+			findingPromptContext[id] = pc
+		}
+		sumPrompt += p
+		sumCompletion += cpl
+		sumDuration += d
+	}
+	return collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, nil
+}
+
+// addCommitWorktree checks out sha in a new detached worktree under the system
+// temp directory and returns its path. Remove it with removeCommitWorktree.
+func addCommitWorktree(repoRoot, sha string) (string, error) {
+	dir, err := os.MkdirTemp("", "stet-commit-")
+	if err != nil {
+		return "", erruser.New("Could not create a worktree for the commit.", err)
+	}
+	path := filepath.Join(dir, "wt")
+	if err := git.AddDetached(repoRoot, path, sha); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	return path, nil
+}
+
+// removeCommitWorktree removes a worktree made by addCommitWorktree. Failures
+// are reported as warnings; git worktree prune drops stale entries later.
+func removeCommitWorktree(repoRoot, path string) {
+	if err := git.Remove(repoRoot, path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	_ = os.RemoveAll(filepath.Dir(path))
+}
+
+// ReviewPatch reviews the hunks of patch (see diff.ParsePatch) with the options of
+// opts that shape a review, and returns the findings. It does not touch the session,
+// lock or worktree, and the patch is not applied: RAG and rules read the current
+// checkout. Steps that need the patch's base or result in git (hunk merging, the
+// change summary, dangling references) are skipped. A format-patch subject is the
+// user intent.
+func ReviewPatch(ctx context.Context, opts StartOptions, patch string) ([]findings.Finding, RunStats, error) {
+	if opts.RepoRoot == "" || opts.StateDir == "" {
+		return nil, RunStats{}, erruser.New("Review failed: repository root and state directory are required.", nil)
+	}
+	hunks, err := diff.ParsePatch(patch)
+	if err != nil {
+		return nil, RunStats{}, erruser.New("Could not parse the patch.", err)
+	}
+	if len(hunks) == 0 {
+		return nil, RunStats{}, erruser.New("The patch contains no hunks to review.", nil)
+	}
+	if opts.RAGSymbolMaxDefinitions < 0 {
+		opts.RAGSymbolMaxDefinitions = 0
+	}
+	if opts.RAGSymbolMaxTokens < 0 {
+		opts.RAGSymbolMaxTokens = 0
+	}
+	opts.HunkMergeGap = 0
+	opts.SummaryEnabled = false
+	opts.DanglingRefsEnabled = false
+
+	var llmClient llm.Client
+	if !opts.DryRun {
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = _defaultOllamaTimeout
+		}
+		llmClient, err = llm.NewClient(opts.Provider, opts.LLMBaseURL, &http.Client{Timeout: timeout})
+		if err != nil {
+			return nil, RunStats{}, err
+		}
+		if _, err := llmClient.Check(ctx, opts.Model); err != nil {
+			return nil, RunStats{}, err
+		}
+	}
+	tr := trace.New(opts.TraceOut)
+	if tr.Enabled() {
+		tr.Section("Patch")
+		tr.Printf("hunks=%d\n", len(hunks))
+	}
+	if opts.Verbose {
+		fmt.Fprintf(os.Stderr, "%d hunks to review\n", len(hunks))
+	}
+	sup := suppress.NewTracker(opts.RepoRoot)
+	collected, _, sumPrompt, sumCompletion, sumDuration, err := startReview(ctx, opts, &session.Session{}, llmClient, reviewUnit{Head: "HEAD", ToReview: hunks, CommitMsg: diff.PatchSubject(patch), Patch: true, Suppress: sup}, tr)
+	if err != nil {
+		return nil, RunStats{}, err
+	}
+	if opts.StreamOut != nil {
+		tryWriteStreamLine(opts.StreamOut, map[string]string{"type": "done"})
+	}
+	return collected, suppressionStats(RunStats{PromptTokens: int64(sumPrompt), CompletionTokens: int64(sumCompletion), EvalDurationNs: sumDuration}, sup, tr), nil
 }
 
 // Start creates a worktree at the given ref, writes the session, then runs the
@@ -797,12 +1757,14 @@ type RunStats struct {
 // either calls Ollama for each hunk or injects canned findings when DryRun.
 // Session is updated with findings and last_reviewed_at = HEAD. Validates
 // clean worktree and that ref is an ancestor of HEAD. Caller should default
-// Ref to "HEAD" if unset. Errors are wrapped with %w so callers can use
+// Ref to "HEAD" if unset. When Against is set, the baseline is the merge base
+// of Against and HEAD instead of Ref. Errors are wrapped with %w so callers can use
 // errors.Is for session.ErrLocked, git.ErrWorktreeExists, git.ErrBaselineNotAncestor, ollama.ErrUnreachable.
 func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 	if opts.RepoRoot == "" || opts.StateDir == "" {
 		return RunStats{}, erruser.New("Start failed: repository root and state directory are required.", nil)
 	}
+	sessionDir := session.Dir(opts.StateDir, opts.Session)
 	ref := opts.Ref
 	if ref == "" {
 		ref = "HEAD"
@@ -826,12 +1788,24 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		}
 	}
 
-	release, err := session.AcquireLock(opts.StateDir)
+	release, err := session.AcquireLock(sessionDir)
 	if err != nil {
 		return RunStats{}, err
 	}
 	defer release()
 
+	if opts.Against != "" {
+		// Merge-base semantics (git diff against...HEAD): commits of the target branch that
+		// were merged into HEAD are behind the baseline and not reviewed.
+		mb, mbErr := git.MergeBase(opts.RepoRoot, opts.Against, "HEAD")
+		if mbErr != nil {
+			return RunStats{}, mbErr
+		}
+		ref = mb
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "Reviewing against %s (merge base %.12s)\n", opts.Against, mb)
+		}
+	}
 	sha, err := git.RevParse(opts.RepoRoot, ref)
 	if err != nil {
 		return RunStats{}, erruser.New(fmt.Sprintf("Could not resolve baseline ref %q.", ref), err)
@@ -849,6 +1823,7 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 			BaselineRef:    sha,
 			LastReviewedAt: headSHA,
 			DismissedIDs:   nil,
+			TargetBranch:   opts.Against,
 		}
 		if opts.PersistStrictness != nil {
 			s.Strictness = *opts.PersistStrictness
@@ -872,7 +1847,7 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 			v := *opts.PersistNumCtx
 			s.NumCtx = &v
 		}
-		if err := session.Save(opts.StateDir, &s); err != nil {
+		if err := session.Save(sessionDir, &s); err != nil {
 			return RunStats{}, err
 		}
 		if opts.Verbose {
@@ -899,7 +1874,7 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		}
 	}
 
-	worktreePath, err := git.Create(opts.RepoRoot, opts.WorktreeRoot, ref)
+	worktreePath, err := git.CreateForSession(opts.RepoRoot, opts.WorktreeRoot, opts.Session, ref)
 	if err != nil {
 		return RunStats{}, err
 	}
@@ -920,6 +1895,7 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		BaselineRef:    sha,
 		LastReviewedAt: "",
 		DismissedIDs:   nil,
+		TargetBranch:   opts.Against,
 	}
 	if opts.PersistStrictness != nil {
 		s.Strictness = *opts.PersistStrictness
@@ -943,11 +1919,11 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		v := *opts.PersistNumCtx
 		s.NumCtx = &v
 	}
-	if err := session.Save(opts.StateDir, &s); err != nil {
+	if err := session.Save(sessionDir, &s); err != nil {
 		return RunStats{}, err
 	}
 
-	part, err := scope.Partition(ctx, opts.RepoRoot, sha, headSHA, "", nil)
+	part, err := scope.Partition(ctx, opts.RepoRoot, sha, headSHA, "", hunkid.Level(opts.HunkIDNormalization), nil)
 	if err != nil {
 		return RunStats{}, erruser.New("Could not compute hunks to review.", err)
 	}
@@ -980,142 +1956,24 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 			fmt.Fprintln(os.Stderr, "Nothing to review.")
 		}
 		s.LastReviewedAt = headSHA
-		if err := session.Save(opts.StateDir, &s); err != nil {
+		if err := session.Save(sessionDir, &s); err != nil {
 			return RunStats{}, err
 		}
 		return RunStats{}, nil
 	}
 
-	minKeep, minMaint := opts.MinConfidenceKeep, opts.MinConfidenceMaintainability
-	if minKeep == 0 && minMaint == 0 {
-		minKeep, minMaint = findings.DefaultMinConfidenceKeep, findings.DefaultMinConfidenceMaintainability
-	}
-	applyFP := true
-	if opts.Nitpicky {
-		applyFP = false
-	} else if opts.ApplyFPKillList != nil {
-		applyFP = *opts.ApplyFPKillList
-	}
-
 	var collected []findings.Finding
-	findingPromptContext := make(map[string]string)
+	var findingPromptContext map[string]string
 	var sumPrompt, sumCompletion int
 	var sumDuration int64
-	total := len(part.ToReview)
-	if opts.StreamOut != nil {
-		tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("%d hunks to review", total)})
-	}
-
-	effectiveNumCtx := opts.NumCtx
-	effectiveContextLimit := opts.ContextLimit
-	// effectiveNumCtx is sent to Ollama; effectiveContextLimit is the single source of
-	// truth for token warnings and ReviewHunk RAG budget (config/flag/session only; no bump from Ollama Show).
-
-	if opts.DryRun {
-		for i, hunk := range part.ToReview {
-			if opts.StreamOut != nil {
-				tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("Reviewing hunk %d/%d: %s", i+1, total, hunk.FilePath)})
-			}
-			batch := cannedFindingsForHunks([]diff.Hunk{hunk})
-			batch = findings.FilterAbstention(batch, minKeep, minMaint)
-			if applyFP {
-				batch = findings.FilterFPKillList(batch)
-			}
-			if hunkStart, hunkEnd, ok := expand.HunkLineRange(hunk); ok {
-				batch = findings.FilterByHunkLines(batch, hunk.FilePath, hunkStart, hunkEnd)
-			}
-			findings.SetCursorURIs(opts.RepoRoot, batch)
-			ctx := truncateForPromptContext(hunk.RawContent, maxPromptContextStoreLen)
-			for _, f := range batch {
-				if f.ID != "" {
-					findingPromptContext[f.ID] = ctx
-				}
-				if opts.StreamOut != nil {
-					tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "finding", "data": f})
-				}
-				collected = append(collected, f)
-			}
-		}
+	sup := suppress.NewTracker(opts.RepoRoot)
+	if opts.PerCommit {
+		collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, err = startPerCommit(ctx, opts, &s, llmClient, sha, headSHA, sup, tr)
 	} else {
-		branch, commitMsg, intentErr := git.UserIntent(opts.RepoRoot)
-		if intentErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not retrieve Git intent (branch/commit): %v; using placeholder\n", intentErr)
-		}
-		// Token estimation: warn once if any hunk's prompt would exceed context threshold (Phase 3.2).
-		if effectiveContextLimit > 0 && opts.WarnThreshold > 0 {
-			systemPrompt, err := prompt.SystemPrompt(opts.StateDir)
-			if err != nil {
-				return RunStats{}, err
-			}
-			systemPrompt = prompt.InjectUserIntent(systemPrompt, branch, commitMsg)
-			if opts.Nitpicky {
-				systemPrompt = prompt.AppendNitpickyInstructions(systemPrompt)
-			}
-			maxPromptTokens := 0
-			for _, h := range part.ToReview {
-				userPrompt := prompt.UserPrompt(h)
-				n := tokens.Estimate(systemPrompt + "\n" + userPrompt)
-				if n > maxPromptTokens {
-					maxPromptTokens = n
-				}
-			}
-			if w := tokens.WarnIfOver(maxPromptTokens, tokens.DefaultResponseReserve, effectiveContextLimit, opts.WarnThreshold); w != "" {
-				fmt.Fprintln(os.Stderr, w)
-			}
-		}
-		systemBase, err := prompt.SystemPrompt(opts.StateDir)
-		if err != nil {
-			return RunStats{}, err
-		}
-		systemBase = prompt.InjectUserIntent(systemBase, branch, commitMsg)
-		systemBase = prompt.AppendPromptShadows(systemBase, runPromptShadows(&s))
-		if opts.Nitpicky {
-			systemBase = prompt.AppendNitpickyInstructions(systemBase)
-		}
-		// Load suppression examples once; applied per-hunk (as many as fit in token budget) in PrepareHunkPrompt.
-		var suppressionExamples []string
-		if opts.SuppressionEnabled && opts.SuppressionHistoryCount > 0 {
-			if examples, err := history.SuppressionExamples(opts.StateDir, opts.SuppressionHistoryCount, maxSuppressionExamples); err == nil && len(examples) > 0 {
-				suppressionExamples = examples
-			}
-		}
-		genOpts := &ollama.GenerateOptions{Temperature: opts.Temperature, NumCtx: effectiveNumCtx, MaxCompletionTokens: opts.MaxCompletionTokens, KeepAlive: keepAliveDuringRun}
-		rulesLoader := rules.NewLoader(opts.RepoRoot)
-		rulesByFile := make(map[string][]rules.CursorRule)
-		for _, h := range part.ToReview {
-			if _, ok := rulesByFile[h.FilePath]; !ok {
-				rulesByFile[h.FilePath] = rulesLoader.RulesForFile(h.FilePath)
-			}
-		}
-		collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, err = runReviewPipeline(ctx, reviewPipelineOpts{
-			Client:                  llmClient,
-			Model:                   opts.Model,
-			Hunks:                   part.ToReview,
-			GenOpts:                 genOpts,
-			SystemBase:              systemBase,
-			RepoRoot:                opts.RepoRoot,
-			EffectiveContextLimit:   effectiveContextLimit,
-			RAGSymbolMaxDefinitions: opts.RAGSymbolMaxDefinitions,
-			RAGSymbolMaxTokens:      opts.RAGSymbolMaxTokens,
-			RAGCallGraphEnabled:     opts.RAGCallGraphEnabled,
-			RAGCallersMax:           opts.RAGCallersMax,
-			RAGCalleesMax:           opts.RAGCalleesMax,
-			RAGCallGraphMaxTokens:   opts.RAGCallGraphMaxTokens,
-			RulesByFile:             rulesByFile,
-			MinKeep:                 minKeep,
-			MinMaint:                minMaint,
-			ApplyFP:                 applyFP,
-			CriticEnabled:          opts.CriticEnabled,
-			CriticModel:             opts.CriticModel,
-			StreamOut:               opts.StreamOut,
-			Verbose:                 opts.Verbose,
-			TraceOut:                tr,
-			UseSearchReplaceFormat:  opts.UseSearchReplaceFormat,
-			SuppressionExamples:     suppressionExamples,
-		})
-		if err != nil {
-			return RunStats{}, err
-		}
+		collected, findingPromptContext, sumPrompt, sumCompletion, sumDuration, err = startReview(ctx, opts, &s, llmClient, reviewUnit{Base: sha, Head: headSHA, ToReview: part.ToReview, Approved: part.Approved, Suppress: sup}, tr)
+	}
+	if err != nil {
+		return RunStats{}, err
 	}
 	if opts.StreamOut != nil {
 		tryWriteStreamLine(opts.StreamOut, map[string]string{"type": "done"})
@@ -1129,7 +1987,7 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		}
 	}
 	runConfig := history.NewRunConfigSnapshot(opts.Model, s.Strictness, opts.RAGSymbolMaxDefinitions, opts.RAGSymbolMaxTokens, opts.Nitpicky)
-	if err := applyAutoDismiss(&s, part.ToReview, collectedIDSet, headSHA, opts.StateDir, runConfig); err != nil {
+	if err := applyAutoDismiss(&s, part.ToReview, collectedIDSet, nil, headSHA, opts.StateDir, runConfig); err != nil {
 		return RunStats{}, err
 	}
 	s.Findings = collected
@@ -1140,10 +1998,10 @@ func Start(ctx context.Context, opts StartOptions) (stats RunStats, err error) {
 		s.LastRunCompletionTokens = int64(sumCompletion)
 		s.LastRunEvalDurationNs = sumDuration
 	}
-	if err := session.Save(opts.StateDir, &s); err != nil {
+	if err := session.Save(sessionDir, &s); err != nil {
 		return RunStats{}, err
 	}
-	return RunStats{PromptTokens: int64(sumPrompt), CompletionTokens: int64(sumCompletion), EvalDurationNs: sumDuration}, nil
+	return suppressionStats(RunStats{PromptTokens: int64(sumPrompt), CompletionTokens: int64(sumCompletion), EvalDurationNs: sumDuration}, sup, tr), nil
 }
 
 // Finish removes the session's worktree and releases the lock. The session
@@ -1153,8 +2011,9 @@ func Finish(ctx context.Context, opts FinishOptions) error {
 	if opts.RepoRoot == "" || opts.StateDir == "" {
 		return erruser.New("Finish failed: repository root and state directory are required.", nil)
 	}
+	sessionDir := session.Dir(opts.StateDir, opts.Session)
 
-	s, err := session.Load(opts.StateDir)
+	s, err := session.Load(sessionDir)
 	if err != nil {
 		return err
 	}
@@ -1162,13 +2021,13 @@ func Finish(ctx context.Context, opts FinishOptions) error {
 		return ErrNoSession
 	}
 
-	release, err := session.AcquireLock(opts.StateDir)
+	release, err := session.AcquireLock(sessionDir)
 	if err != nil {
 		return err
 	}
 	defer release()
 
-	path, err := git.PathForRef(opts.RepoRoot, opts.WorktreeRoot, s.BaselineRef)
+	path, err := git.PathForSession(opts.RepoRoot, opts.WorktreeRoot, opts.Session, s.BaselineRef)
 	if err != nil {
 		return erruser.New("Could not determine worktree path.", err)
 	}
@@ -1207,12 +2066,14 @@ func Finish(ctx context.Context, opts FinishOptions) error {
 			diffRef = s.BaselineRef
 		}
 		var runConfig *history.RunConfigSnapshot
+		refitCalibration := false
 		if cfg, err := config.Load(ctx, config.LoadOptions{RepoRoot: opts.RepoRoot}); err == nil {
 			runConfig = history.NewRunConfigSnapshot(cfg.Model, cfg.Strictness, cfg.RAGSymbolMaxDefinitions, cfg.RAGSymbolMaxTokens, cfg.Nitpicky)
+			refitCalibration = cfg.CalibrationEnabled
 		}
 		rec := history.Record{
 			DiffRef:      diffRef,
-			ReviewOutput: s.Findings,
+			ReviewOutput: session.FindingsWithStatus(&s, time.Now()),
 			UserAction: history.UserAction{
 				DismissedIDs: s.DismissedIDs,
 				FinishedAt:   time.Now().UTC().Format(time.RFC3339),
@@ -1239,6 +2100,12 @@ func Finish(ctx context.Context, opts FinishOptions) error {
 		if err := history.Append(opts.StateDir, rec, history.DefaultMaxRecords); err != nil {
 			return erruser.New("Could not record review history.", err)
 		}
+		// The finished session's dismissals are new calibration data.
+		if refitCalibration {
+			if _, err := calibration.Refit(opts.StateDir, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not update confidence calibration: %v\n", err)
+			}
+		}
 	}
 	var noteModel string
 	if captureUsage() {
@@ -1296,7 +2163,7 @@ func Finish(ctx context.Context, opts FinishOptions) error {
 	if err := git.AddNote(opts.RepoRoot, git.NotesRefStet, headSHA, string(noteJSON)); err != nil {
 		return erruser.New("Could not write Git note for this review.", err)
 	}
-	if err := session.Delete(opts.StateDir); err != nil {
+	if err := session.Delete(sessionDir); err != nil {
 		return err
 	}
 	return nil
@@ -1311,6 +2178,7 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 	if opts.RepoRoot == "" || opts.StateDir == "" {
 		return RunStats{}, erruser.New("Run failed: repository root and state directory are required.", nil)
 	}
+	sessionDir := session.Dir(opts.StateDir, opts.Session)
 	if opts.RAGSymbolMaxDefinitions < 0 {
 		opts.RAGSymbolMaxDefinitions = 0
 	}
@@ -1318,7 +2186,7 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 		opts.RAGSymbolMaxTokens = 0
 	}
 
-	s, err := session.Load(opts.StateDir)
+	s, err := session.Load(sessionDir)
 	if err != nil {
 		return RunStats{}, err
 	}
@@ -1335,7 +2203,7 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 	if opts.ForceFullReview {
 		lastReviewedAt = ""
 	}
-	part, err := scope.Partition(ctx, opts.RepoRoot, s.BaselineRef, headSHA, lastReviewedAt, nil)
+	part, err := scope.Partition(ctx, opts.RepoRoot, s.BaselineRef, headSHA, lastReviewedAt, hunkid.Level(opts.HunkIDNormalization), nil)
 	if err != nil {
 		return RunStats{}, erruser.New("Could not compute hunks to review.", err)
 	}
@@ -1351,6 +2219,15 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 		trRun.Section("AGENTS.md")
 		trRun.Printf("AGENTS.md: not used by stet (only .cursor/rules/ used).\n")
 	}
+	// Relocate before anything reads finding lines (dismissed-hunk filter, auto-dismiss);
+	// the nothing-to-review path below saves the moved findings too.
+	modifiedIDs, err := relocateFindings(ctx, opts.RepoRoot, &s, headSHA)
+	if err != nil {
+		return RunStats{}, err
+	}
+	if trRun.Enabled() && modifiedIDs != nil {
+		trRun.Printf("Relocated findings since last_reviewed_at=%s; anchored lines modified=%d\n", s.LastReviewedAt, len(modifiedIDs))
+	}
 	toReview := part.ToReview
 	skippedDismissed := 0
 	if !opts.ForceFullReview && len(s.DismissedIDs) > 0 {
@@ -1376,12 +2253,23 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 			fmt.Fprintln(os.Stderr, "Nothing to review.")
 		}
 		s.LastReviewedAt = headSHA
-		if saveErr := session.Save(opts.StateDir, &s); saveErr != nil {
+		if saveErr := session.Save(sessionDir, &s); saveErr != nil {
 			return RunStats{}, saveErr
 		}
 		return RunStats{}, nil
 	}
 
+	reviewHunks := planHunks(ctx, opts.RepoRoot, headSHA, toReview, opts.HunkMaxLines, opts.HunkMergeGap, fileBatchTokens(opts.FileBatchingEnabled, opts.UseSearchReplaceFormat, opts.ContextLimit), trRun)
+	bl, err := baseline.Load(opts.RepoRoot)
+	if err != nil {
+		return RunStats{}, err
+	}
+	sup := suppress.NewTracker(opts.RepoRoot)
+	flt, err := findings.NewFilter(opts.Filters)
+	if err != nil {
+		return RunStats{}, err
+	}
+	cal := loadCalibration(opts.StateDir, opts.CalibrationEnabled, trRun)
 	var sumPrompt, sumCompletion int
 	var sumDuration int64
 	if s.FindingPromptContext == nil {
@@ -1399,29 +2287,32 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 	}
 
 	var newFindings []findings.Finding
-	total := len(toReview)
+	total := len(reviewHunks)
 	if opts.StreamOut != nil {
 		tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("%d hunks to review", total)})
 	}
 
 	if opts.DryRun {
-		for i, hunk := range toReview {
+		for i, hunk := range reviewHunks {
 			if opts.StreamOut != nil {
 				tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "progress", "msg": fmt.Sprintf("Reviewing hunk %d/%d: %s", i+1, total, hunk.FilePath)})
 			}
-			batch := cannedFindingsForHunks([]diff.Hunk{hunk})
+			batch := cannedFindingsForHunks(hunkParts(hunk))
+			batch = applySuppressions(batch, hunk, sup, nil, trRun)
 			batch = findings.FilterAbstention(batch, minKeep, minMaint)
 			if applyFP {
 				batch = findings.FilterFPKillList(batch)
 			}
-			if hunkStart, hunkEnd, ok := expand.HunkLineRange(hunk); ok {
-				batch = findings.FilterByHunkLines(batch, hunk.FilePath, hunkStart, hunkEnd)
+			batch = applyConfigFilters(batch, flt, trRun)
+			if ranges := hunkLineRanges(hunk); len(ranges) > 0 {
+				batch = findings.FilterByHunkRanges(batch, hunk.FilePath, ranges)
 			}
+			batch = applyBaseline(batch, hunk, bl, trRun)
 			findings.SetCursorURIs(opts.RepoRoot, batch)
-			hunkCtx := truncateForPromptContext(hunk.RawContent, maxPromptContextStoreLen)
+			findings.SetFileStatus(batch, hunk.FilePath, string(hunk.Status), hunk.OldPath)
 			for _, f := range batch {
 				if f.ID != "" {
-					s.FindingPromptContext[f.ID] = hunkCtx
+					s.FindingPromptContext[f.ID] = truncateForPromptContext(hunkForFinding(hunk, f).RawContent, maxPromptContextStoreLen)
 				}
 				if opts.StreamOut != nil {
 					tryWriteStreamLine(opts.StreamOut, map[string]interface{}{"type": "finding", "data": f})
@@ -1462,7 +2353,7 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 				systemPrompt = prompt.AppendNitpickyInstructions(systemPrompt)
 			}
 			maxPromptTokens := 0
-			for _, h := range toReview {
+			for _, h := range reviewHunks {
 				userPrompt := prompt.UserPrompt(h)
 				n := tokens.Estimate(systemPrompt + "\n" + userPrompt)
 				if n > maxPromptTokens {
@@ -1490,18 +2381,29 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 			}
 		}
 		genOpts := &ollama.GenerateOptions{Temperature: opts.Temperature, NumCtx: effectiveNumCtx, MaxCompletionTokens: opts.MaxCompletionTokens, KeepAlive: keepAliveDuringRun}
+		var summaryRes *ollama.GenerateResult
+		if opts.SummaryEnabled && len(part.ToReview) > 0 {
+			var text string
+			text, summaryRes = changeSummary(ctx, client, opts.Model, opts.RepoRoot, s.BaselineRef, headSHA, append(append([]diff.Hunk(nil), part.ToReview...), part.Approved...), genOpts, trRun)
+			systemBase = prompt.InjectChangeSummary(systemBase, text)
+		}
 		rulesLoader := rules.NewLoader(opts.RepoRoot)
 		rulesByFile := make(map[string][]rules.CursorRule)
-		for _, h := range toReview {
+		for _, h := range reviewHunks {
 			if _, ok := rulesByFile[h.FilePath]; !ok {
 				rulesByFile[h.FilePath] = rulesLoader.RulesForFile(h.FilePath)
 			}
 		}
+		symbolIndex := loadSymbolIndex(ctx, opts.RepoRoot, opts.StateDir, opts.RAGSymbolIndexEnabled && opts.RAGSymbolMaxDefinitions > 0, trRun)
+		coverageProfile, err := loadCoverageProfile(opts.RepoRoot, opts.CoverageProfile, trRun)
+		if err != nil {
+			return RunStats{}, err
+		}
 		var pipelineContext map[string]string
 		newFindings, pipelineContext, sumPrompt, sumCompletion, sumDuration, err = runReviewPipeline(ctx, reviewPipelineOpts{
 			Client:                  client,
 			Model:                   opts.Model,
-			Hunks:                   toReview,
+			Hunks:                   reviewHunks,
 			GenOpts:                 genOpts,
 			SystemBase:              systemBase,
 			RepoRoot:                opts.RepoRoot,
@@ -1512,6 +2414,11 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 			RAGCallersMax:           opts.RAGCallersMax,
 			RAGCalleesMax:           opts.RAGCalleesMax,
 			RAGCallGraphMaxTokens:   opts.RAGCallGraphMaxTokens,
+			RAGGoTypesEnabled:       opts.RAGGoTypesEnabled,
+			SymbolIndex:             symbolIndex,
+			RelatedTests:            opts.RAGRelatedTestsEnabled,
+			CoverageProfile:         coverageProfile,
+			DanglingRefs:            opts.DanglingRefsEnabled,
 			RulesByFile:             rulesByFile,
 			MinKeep:                 minKeep,
 			MinMaint:                minMaint,
@@ -1522,11 +2429,21 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 			Verbose:                 opts.Verbose,
 			TraceOut:                trRun,
 			UseSearchReplaceFormat:  opts.UseSearchReplaceFormat,
+			HunkIDLevel:             hunkid.Level(opts.HunkIDNormalization),
+			Baseline:                bl,
+			Suppress:                sup,
+			Filters:                 flt,
+			Calibration:             cal,
 			SuppressionExamples:     suppressionExamples,
 		})
 		if err != nil {
 			return RunStats{}, err
 		}
+		if summaryRes != nil {
+			sumPrompt += summaryRes.PromptEvalCount
+			sumCompletion += summaryRes.EvalCount
+			sumDuration += summaryRes.EvalDuration
+		}
 		for id, ctx := range pipelineContext {
 			s.FindingPromptContext[id] = ctx
 		}
@@ -1542,6 +2459,7 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 
 	// Replace: clear state. Merge: auto-dismiss then append; applyAutoDismiss uses existing s.Findings.
 	if opts.ReplaceFindings {
+		replaced := session.FindingsWithStatus(&s, time.Now())
 		s.Findings = newFindings
 		// Keep only prompt context for the new findings.
 		newContext := make(map[string]string, len(newFindings))
@@ -1559,10 +2477,11 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 		}
 		runConfig := history.NewRunConfigSnapshot(opts.Model, s.Strictness, opts.RAGSymbolMaxDefinitions, opts.RAGSymbolMaxTokens, opts.Nitpicky)
 		rec := history.Record{
-			DiffRef:      diffRef,
-			ReviewOutput: newFindings,
-			UserAction:   history.UserAction{ReplaceFindings: true},
-			RunConfig:    runConfig,
+			DiffRef:        diffRef,
+			ReviewOutput:   newFindings,
+			UserAction:     history.UserAction{ReplaceFindings: true},
+			RunConfig:      runConfig,
+			ReplacedOutput: replaced,
 		}
 		if err := history.Append(opts.StateDir, rec, history.DefaultMaxRecords); err != nil {
 			return RunStats{}, erruser.New("Could not record review history.", err)
@@ -1575,16 +2494,17 @@ func Run(ctx context.Context, opts RunOptions) (RunStats, error) {
 				newFindingIDSet[f.ID] = struct{}{}
 			}
 		}
+		newFindings = dropRelocatedDuplicates(&s, newFindings, newFindingIDSet)
 		runConfig := history.NewRunConfigSnapshot(opts.Model, s.Strictness, opts.RAGSymbolMaxDefinitions, opts.RAGSymbolMaxTokens, opts.Nitpicky)
-		if err := applyAutoDismiss(&s, toReview, newFindingIDSet, headSHA, opts.StateDir, runConfig); err != nil {
+		if err := applyAutoDismiss(&s, toReview, newFindingIDSet, modifiedIDs, headSHA, opts.StateDir, runConfig); err != nil {
 			return RunStats{}, err
 		}
-		s.Findings = append(s.Findings, newFindings...)
+		s.Findings = mergeFindings(s.Findings, newFindings)
 	}
 
 	s.LastReviewedAt = headSHA
-	if err := session.Save(opts.StateDir, &s); err != nil {
+	if err := session.Save(sessionDir, &s); err != nil {
 		return RunStats{}, err
 	}
-	return RunStats{PromptTokens: int64(sumPrompt), CompletionTokens: int64(sumCompletion), EvalDurationNs: sumDuration}, nil
+	return suppressionStats(RunStats{PromptTokens: int64(sumPrompt), CompletionTokens: int64(sumCompletion), EvalDurationNs: sumDuration}, sup, trRun), nil
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -12,15 +13,21 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
+	"stet/cli/internal/baseline"
+	"stet/cli/internal/calibration"
 	"stet/cli/internal/diff"
 	"stet/cli/internal/findings"
 	"stet/cli/internal/git"
 	"stet/cli/internal/history"
+	"stet/cli/internal/hunkid"
 	"stet/cli/internal/prompt"
+	_ "stet/cli/internal/rag/go" // register Go resolver (definition patterns for dangling references)
 	"stet/cli/internal/review"
 	"stet/cli/internal/session"
+	"stet/cli/internal/summary"
 )
 
 const dryRunMsg = "Dry-run placeholder (CI)"
@@ -156,6 +163,56 @@ func TestStart_refEqualsHEAD_skipsWorktree(t *testing.T) {
 	}
 }
 
+func TestStart_against_reviewsOnlyBranchChangesSinceMergeBase(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	runGit(t, repo, "git", "branch", "main-target")
+	writeFile(t, repo, "feature.txt", "feature\n")
+	runGit(t, repo, "git", "add", "feature.txt")
+	runGit(t, repo, "git", "commit", "-m", "feature work")
+	// The target moves on and is merged into the feature branch.
+	runGit(t, repo, "git", "checkout", "-q", "main-target")
+	writeFile(t, repo, "upstream.txt", "upstream\n")
+	runGit(t, repo, "git", "add", "upstream.txt")
+	runGit(t, repo, "git", "commit", "-m", "upstream work")
+	upstream := runOut(t, repo, "git", "rev-parse", "HEAD")
+	runGit(t, repo, "git", "checkout", "-q", "-")
+	runGit(t, repo, "git", "merge", "-q", "--no-edit", "main-target")
+
+	opts := StartOptions{RepoRoot: repo, StateDir: stateDir, Against: "main-target", DryRun: true, Provider: "ollama"}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start(--against): %v", err)
+	}
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("Load session: %v", err)
+	}
+	if s.BaselineRef != upstream {
+		t.Errorf("BaselineRef = %s, want merge base %s (merged-in target commit)", s.BaselineRef, upstream)
+	}
+	if s.TargetBranch != "main-target" {
+		t.Errorf("TargetBranch = %q, want main-target", s.TargetBranch)
+	}
+	for _, f := range s.Findings {
+		if f.File != "feature.txt" {
+			t.Errorf("finding in %s; only feature.txt changed on the branch", f.File)
+		}
+	}
+	if len(s.Findings) == 0 {
+		t.Error("want a finding for feature.txt")
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	opts.Against = "no-such-branch"
+	if _, err := Start(ctx, opts); err == nil {
+		t.Error("Start(--against invalid branch): expected error")
+	}
+}
+
 func TestStart_refEqualsHEAD_withPersistStrictness_storesInSession(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -442,6 +499,211 @@ func TestFinish_removesWorktreeAndClearsSession(t *testing.T) {
 	}
 }
 
+func TestStart_namedSessionsAreIndependent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	// Same baseline: each session still gets its own worktree, session file and lock.
+	for _, name := range []string{"", "colleague"} {
+		if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Session: name, Ref: "HEAD~1", DryRun: true}); err != nil {
+			t.Fatalf("Start(session %q): %v", name, err)
+		}
+	}
+	def, err := session.Load(stateDir)
+	if err != nil || def.BaselineRef == "" {
+		t.Fatalf("default session = %+v, %v; want active", def, err)
+	}
+	named, err := session.Load(session.Dir(stateDir, "colleague"))
+	if err != nil || named.BaselineRef == "" || named.SessionID == def.SessionID {
+		t.Fatalf("named session = %+v, %v; want a separate active session", named, err)
+	}
+	if _, err := Run(ctx, RunOptions{RepoRoot: repo, StateDir: stateDir, Session: "colleague", DryRun: true}); err != nil {
+		t.Fatalf("Run(colleague): %v", err)
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir, Session: "colleague"}); err != nil {
+		t.Fatalf("Finish(colleague): %v", err)
+	}
+	wtPath, _ := git.PathForSession(repo, "", "colleague", named.BaselineRef)
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Errorf("named worktree %s should be removed by its finish, stat err = %v", wtPath, err)
+	}
+	if after, _ := session.Load(stateDir); after.SessionID != def.SessionID {
+		t.Error("finishing the named session must not touch the default session")
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish(default): %v", err)
+	}
+}
+
+func TestStart_baselineFiltersFindings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: true}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s, err := session.Load(stateDir)
+	if err != nil || len(s.Findings) == 0 {
+		t.Fatalf("session findings = %d, %v; want at least one", len(s.Findings), err)
+	}
+	target := s.Findings[0]
+	if target.Fingerprint == "" {
+		t.Fatal("finding has no baseline fingerprint")
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	bl := &baseline.Baseline{}
+	bl.Add(target)
+	if err := baseline.Save(repo, bl); err != nil {
+		t.Fatalf("baseline.Save: %v", err)
+	}
+	runGit(t, repo, "git", "add", ".stet")
+	runGit(t, repo, "git", "commit", "-m", "baseline")
+	// HEAD~2 covers the same change plus the baseline commit.
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~2", DryRun: true}); err != nil {
+		t.Fatalf("Start after baseline: %v", err)
+	}
+	s, err = session.Load(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Findings) == 0 {
+		t.Fatal("want the baseline file's own finding to remain")
+	}
+	for _, f := range s.Findings {
+		if f.Fingerprint == target.Fingerprint {
+			t.Errorf("baselined finding %s:%d was reported again", f.File, f.Line)
+		}
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+}
+
+func TestStart_inlineSuppressions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	writeFile(t, repo, "x.go", "// stet:ignore maintainability -- generated\npackage x\n")
+	writeFile(t, repo, "y.go", "package y\n\n// stet:ignore-next-line security\nfunc f() {}\n")
+	runGit(t, repo, "git", "add", "x.go", "y.go")
+	runGit(t, repo, "git", "commit", "-m", "c3")
+	stats, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: true})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Findings) != 1 || s.Findings[0].File != "y.go" {
+		t.Errorf("findings = %+v, want the y.go finding only", s.Findings)
+	}
+	if stats.Suppressed != 1 {
+		t.Errorf("Suppressed = %d, want 1", stats.Suppressed)
+	}
+	if len(stats.StaleSuppressions) != 1 || stats.StaleSuppressions[0] != "y.go:3" {
+		t.Errorf("StaleSuppressions = %v, want [y.go:3]", stats.StaleSuppressions)
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+}
+
+func TestStart_configFilters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	writeFile(t, repo, "f3.txt", "c\n")
+	runGit(t, repo, "git", "add", "f3.txt")
+	runGit(t, repo, "git", "commit", "-m", "c3")
+	filters := findings.FilterRules{
+		Drop:  []findings.DropRule{{Path: "f2.txt"}},
+		Remap: []findings.RemapRule{{DropRule: findings.DropRule{Category: "maintainability"}, Severity: findings.SeverityNitpick}},
+	}
+	var traceBuf bytes.Buffer
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~2", DryRun: true, Filters: filters, TraceOut: &traceBuf}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Findings) != 1 || s.Findings[0].File != "f3.txt" || s.Findings[0].Severity != findings.SeverityNitpick {
+		t.Errorf("findings = %+v, want the f3.txt finding remapped to nitpick", s.Findings)
+	}
+	if out := traceBuf.String(); !strings.Contains(out, "drop[0] path=f2.txt: 1") || !strings.Contains(out, "remap[0] category=maintainability -> nitpick: 1") {
+		t.Errorf("trace missing per-rule counts:\n%s", out)
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	_, err = Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: true, Filters: findings.FilterRules{BannedPatterns: []string{"("}}})
+	if err == nil {
+		t.Error("Start with invalid filter pattern: want error")
+	}
+}
+
+func TestStart_calibrationKeepsRawThresholds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	resp := `[{"file":"f2.txt","line":1,"severity":"info","category":"maintainability","message":"rename b","confidence":1}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}, {"name": "other"}}})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": resp, "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	cal := &calibration.Calibration{Curves: []calibration.Curve{
+		{Model: "m", Category: calibration.AllCategories, Samples: 20, Points: []calibration.Point{{Confidence: 1, Calibrated: 0.85, Count: 20}}},
+	}}
+	if err := calibration.Save(stateDir, cal); err != nil {
+		t.Fatal(err)
+	}
+	// The finding is maintainability at confidence 1.0: calibrated to 0.85 it would fail the maintainability threshold (0.9),
+	// but abstention judges the raw confidence, so it is kept with the calibrated value.
+	// Dry runs (canned findings) and other models are not calibrated.
+	for _, tc := range []struct {
+		model    string
+		dryRun   bool
+		wantConf float64
+		wantRaw  float64
+	}{
+		{"m", false, 0.85, 1},
+		{"m", true, 1, 0},
+		{"other", false, 1, 0},
+	} {
+		opts := StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: tc.dryRun, Model: tc.model, Provider: "ollama", LLMBaseURL: srv.URL, CalibrationEnabled: true}
+		if _, err := Start(ctx, opts); err != nil {
+			t.Fatalf("Start(%s, dry run %v): %v", tc.model, tc.dryRun, err)
+		}
+		s, err := session.Load(stateDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Findings) != 1 {
+			t.Errorf("Start(%s, dry run %v): findings = %+v, want 1", tc.model, tc.dryRun, s.Findings)
+		} else if s.Findings[0].Confidence != tc.wantConf || s.Findings[0].RawConfidence != tc.wantRaw {
+			t.Errorf("Start(%s, dry run %v): confidence = %v (raw %v), want %v (raw %v)", tc.model, tc.dryRun, s.Findings[0].Confidence, s.Findings[0].RawConfidence, tc.wantConf, tc.wantRaw)
+		}
+		if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: stateDir}); err != nil {
+			t.Fatalf("Finish: %v", err)
+		}
+	}
+}
+
 func TestFinish_noSession(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -846,7 +1108,14 @@ func TestRun_forceFullReview_partitionsAllHunks(t *testing.T) {
 		t.Fatalf("Load session: %v", err)
 	}
 	afterFirstRun := len(s1.Findings)
-	// Rerun with ForceFullReview: all hunks go to ToReview, so review loop runs again and appends findings (merge mode).
+	if afterFirstRun == 0 {
+		t.Fatal("first Run: want findings")
+	}
+	s1.Findings[0].Status = findings.StatusAccepted
+	if err := session.Save(stateDir, &s1); err != nil {
+		t.Fatalf("Save session: %v", err)
+	}
+	// Rerun with ForceFullReview: all hunks go to ToReview, so the review loop re-reports the same findings (merge mode).
 	runOpts.ForceFullReview = true
 	if _, err := Run(ctx, runOpts); err != nil {
 		t.Fatalf("Run(ForceFullReview): %v", err)
@@ -855,8 +1124,11 @@ func TestRun_forceFullReview_partitionsAllHunks(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Load session: %v", err)
 	}
-	if len(s2.Findings) <= afterFirstRun {
-		t.Errorf("After ForceFullReview run: findings got %d, want > %d (review loop should have run)", len(s2.Findings), afterFirstRun)
+	if len(s2.Findings) != afterFirstRun {
+		t.Errorf("After ForceFullReview run: findings got %d, want %d (re-reported findings merged by ID)", len(s2.Findings), afterFirstRun)
+	}
+	if s2.Findings[0].Status != findings.StatusAccepted {
+		t.Errorf("After ForceFullReview run: status = %q, want accepted kept", s2.Findings[0].Status)
 	}
 	if initialCount == 0 && afterFirstRun == 0 {
 		t.Errorf("Start at HEAD~1 should produce findings; initial=%d afterFirstRun=%d", initialCount, afterFirstRun)
@@ -908,6 +1180,15 @@ func TestRun_replaceFindings(t *testing.T) {
 			t.Errorf("finding[%d].Message = %q, want %q", i, f.Message, dryRunMsg)
 		}
 	}
+	// The replace record keeps the replaced findings for stet diff-findings.
+	records, err := history.ReadRecords(stateDir)
+	if err != nil || len(records) == 0 {
+		t.Fatalf("ReadRecords: %d records, err=%v", len(records), err)
+	}
+	last := records[len(records)-1]
+	if !last.UserAction.ReplaceFindings || len(last.ReplacedOutput) == 0 {
+		t.Errorf("replace record: ReplaceFindings=%v, %d replaced findings; want true and > 0", last.UserAction.ReplaceFindings, len(last.ReplacedOutput))
+	}
 }
 
 func TestStart_dryRun_noHunks(t *testing.T) {
@@ -1058,6 +1339,7 @@ func TestAddressedFindingIDs(t *testing.T) {
 		hunks         []diff.Hunk
 		existing      []findings.Finding
 		newIDSet      map[string]struct{}
+		modified      map[string]struct{}
 		wantAddressed []string
 	}{
 		{
@@ -1115,10 +1397,21 @@ func TestAddressedFindingIDs(t *testing.T) {
 			newIDSet:      map[string]struct{}{},
 			wantAddressed: []string{"f2"},
 		},
+		{
+			name:  "relocated: only findings with modified lines are addressed",
+			hunks: []diff.Hunk{hunkWithRange},
+			existing: []findings.Finding{
+				{ID: "f1", File: "a.go", Line: 2},
+				{ID: "f2", File: "a.go", Line: 3},
+			},
+			newIDSet:      map[string]struct{}{},
+			modified:      map[string]struct{}{"f2": {}},
+			wantAddressed: []string{"f2"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := addressedFindingIDs(tt.hunks, tt.existing, tt.newIDSet)
+			got := addressedFindingIDs(tt.hunks, tt.existing, tt.newIDSet, tt.modified)
 			if len(got) != len(tt.wantAddressed) {
 				t.Errorf("addressedFindingIDs() len = %d, want %d; got %v", len(got), len(tt.wantAddressed), got)
 				return
@@ -1136,6 +1429,108 @@ func TestAddressedFindingIDs(t *testing.T) {
 	}
 }
 
+func TestRelocateFindings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	writeFile(t, repo, "r.go", "package p\n\nfunc A() {}\n\nfunc B() {}\n\nfunc C() {}\n")
+	runGit(t, repo, "git", "add", "r.go")
+	runGit(t, repo, "git", "commit", "-m", "add r")
+	reviewed := runOut(t, repo, "git", "rev-parse", "HEAD")
+	// Two lines inserted above A; B's line is rewritten; C only shifts.
+	writeFile(t, repo, "r.go", "package p\n\nvar x = 1\nvar y = 2\n\nfunc A() {}\n\nfunc B() { x++ }\n\nfunc C() {}\n")
+	runGit(t, repo, "git", "commit", "-am", "edit r")
+	head := runOut(t, repo, "git", "rev-parse", "HEAD")
+
+	s := session.Session{
+		LastReviewedAt: reviewed,
+		Findings: []findings.Finding{
+			{ID: "a", File: "r.go", Line: 3, Message: "A", CursorURI: "file:///stale#L3"},
+			{ID: "b", File: "r.go", Line: 5, Message: "B"},
+			{ID: "c", File: "r.go", Range: &findings.LineRange{Start: 7, End: 7}, Message: "C"},
+			{ID: "d", File: "r.go", Line: 5, Message: "dismissed"},
+		},
+		DismissedIDs: []string{"d"},
+	}
+	modified, err := relocateFindings(ctx, repo, &s, head)
+	if err != nil {
+		t.Fatalf("relocateFindings: %v", err)
+	}
+	if len(modified) != 1 {
+		t.Errorf("modified = %v, want only b", modified)
+	}
+	if _, ok := modified["b"]; !ok {
+		t.Errorf("modified = %v, want b", modified)
+	}
+	if got := s.Findings[0]; got.Line != 6 || !strings.HasSuffix(got.CursorURI, "r.go#L6") {
+		t.Errorf("a: Line=%d CursorURI=%q, want 6 and #L6", got.Line, got.CursorURI)
+	}
+	if got := s.Findings[1].Line; got != 8 {
+		t.Errorf("b: Line = %d, want 8", got)
+	}
+	if got := s.Findings[2].Range; got == nil || got.Start != 10 || got.End != 10 {
+		t.Errorf("c: Range = %+v, want 10-10", got)
+	}
+	if got := s.Findings[3].Line; got != 5 {
+		t.Errorf("dismissed finding moved to %d, want 5", got)
+	}
+
+	// No last review: nothing to relocate from.
+	if got, err := relocateFindings(ctx, repo, &session.Session{}, head); err != nil || got != nil {
+		t.Errorf("no last review: %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestDropRelocatedDuplicates(t *testing.T) {
+	t.Parallel()
+	moved := findings.Finding{ID: "old", File: "a.go", Line: 8, Message: "nil check"}
+	s := session.Session{
+		Findings:             []findings.Finding{moved},
+		FindingPromptContext: map[string]string{},
+	}
+	dupID := hunkid.StableFindingID("a.go", 8, 0, 0, "nil check")
+	s.FindingPromptContext[dupID] = "ctx"
+	newFindings := []findings.Finding{
+		{ID: dupID, File: "a.go", Line: 8, Message: "nil check"},
+		{ID: "other", File: "a.go", Line: 9, Message: "other"},
+	}
+	idSet := map[string]struct{}{dupID: {}, "other": {}}
+	got := dropRelocatedDuplicates(&s, newFindings, idSet)
+	if len(got) != 1 || got[0].ID != "other" {
+		t.Fatalf("got %+v, want only other", got)
+	}
+	if _, ok := idSet["old"]; !ok {
+		t.Error("relocated finding should count as re-reported")
+	}
+	if s.FindingPromptContext["old"] != "ctx" || s.FindingPromptContext[dupID] != "" {
+		t.Errorf("prompt context not moved: %v", s.FindingPromptContext)
+	}
+}
+
+func TestMergeFindings(t *testing.T) {
+	t.Parallel()
+	comments := []findings.Comment{{Text: "later"}}
+	existing := []findings.Finding{
+		{ID: "a", File: "a.go", Line: 3, Message: "m", Status: findings.StatusDeferred, DeferredUntil: "2099-01-01", Comments: comments},
+		{ID: "b", File: "b.go", Line: 1, Message: "n", Status: findings.StatusAccepted},
+	}
+	added := []findings.Finding{
+		{ID: "a", File: "a.go", Line: 3, Message: "m", Severity: findings.SeverityError},
+		{ID: "c", File: "c.go", Line: 2, Message: "new"},
+	}
+	got := mergeFindings(existing, added)
+	if len(got) != 3 || got[0].ID != "a" || got[1].ID != "b" || got[2].ID != "c" {
+		t.Fatalf("got %+v, want a, b, c with no duplicate of a", got)
+	}
+	a := got[0]
+	if a.Status != findings.StatusDeferred || a.DeferredUntil != "2099-01-01" || len(a.Comments) != 1 || a.Severity != findings.SeverityError {
+		t.Errorf("re-reported a = %+v, want the new finding with the deferral and comments kept", a)
+	}
+	if got[2].Status != "" {
+		t.Errorf("new finding status = %q, want open", got[2].Status)
+	}
+}
+
 func TestFilterHunksWithDismissedFindings(t *testing.T) {
 	t.Parallel()
 	hunkA := diff.Hunk{FilePath: "a.go", RawContent: "@@ -1,3 +1,4 @@\n context\n+added", Context: ""}
@@ -1381,12 +1776,13 @@ func TestRun_autoDismiss_addressedFindingNotRereported(t *testing.T) {
 	if err := session.Save(stateDir, &s0); err != nil {
 		t.Fatalf("Save session: %v", err)
 	}
-	// Add a new commit so Run has hunks to review (baseline..HEAD vs last_reviewed_at).
-	writeFile(t, repo, firstHunkFile, "new line\nb\n")
+	// Add a new commit that rewrites the extra finding's line so Run has hunks to review
+	// (baseline..HEAD vs last_reviewed_at) and the finding's anchored line was modified.
+	writeFile(t, repo, firstHunkFile, "b changed\n")
 	runGit(t, repo, "git", "add", firstHunkFile)
 	runGit(t, repo, "git", "commit", "-m", "change for re-review")
 	// Run: dry-run will add one finding per hunk (deterministic IDs). Our extra finding
-	// is in a reviewed hunk and its ID is not in the new set -> auto-dismissed.
+	// is in a reviewed hunk, its line changed and its ID is not in the new set -> auto-dismissed.
 	runOpts := RunOptions{RepoRoot: repo, StateDir: stateDir, DryRun: true}
 	if _, err := Run(ctx, runOpts); err != nil {
 		t.Fatalf("Run: %v", err)
@@ -1405,6 +1801,11 @@ func TestRun_autoDismiss_addressedFindingNotRereported(t *testing.T) {
 	if !hasExtra {
 		t.Errorf("DismissedIDs: want %q (auto-dismissed); got %v", extraID, s1.DismissedIDs)
 	}
+	for _, f := range s1.Findings {
+		if f.ID == extraID && f.Status != findings.StatusFixed {
+			t.Errorf("auto-dismissed finding status = %q, want fixed", f.Status)
+		}
+	}
 	// Findings are not removed from session; activeFindings() filters by DismissedIDs.
 	// Verify the extra finding is excluded from the active set (as list/status/JSON would show).
 	dismissedSet := make(map[string]struct{}, len(s1.DismissedIDs))
@@ -1431,6 +1832,55 @@ func TestRun_autoDismiss_addressedFindingNotRereported(t *testing.T) {
 	}
 }
 
+
+func TestRun_relocatesShiftedFindingInsteadOfDismissing(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: true, Provider: "ollama"}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s0, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("Load session: %v", err)
+	}
+	s0.Findings = append(s0.Findings, findings.Finding{
+		ID:       "shifted-finding",
+		File:     "f2.txt",
+		Line:     1,
+		Severity: findings.SeverityInfo,
+		Category: findings.CategoryMaintainability,
+		Message:  "pre-existing finding",
+	})
+	if err := session.Save(stateDir, &s0); err != nil {
+		t.Fatalf("Save session: %v", err)
+	}
+	// Insert a line above the finding: the hunk is re-reviewed but line "b" is unchanged.
+	writeFile(t, repo, "f2.txt", "new line\nb\n")
+	runGit(t, repo, "git", "commit", "-am", "insert above")
+	if _, err := Run(ctx, RunOptions{RepoRoot: repo, StateDir: stateDir, DryRun: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	s1, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("Load session: %v", err)
+	}
+	for _, id := range s1.DismissedIDs {
+		if id == "shifted-finding" {
+			t.Fatal("finding whose line only shifted was auto-dismissed")
+		}
+	}
+	for _, f := range s1.Findings {
+		if f.ID == "shifted-finding" {
+			if f.Line != 2 || !strings.HasSuffix(f.CursorURI, "f2.txt#L2") {
+				t.Errorf("Line=%d CursorURI=%q, want relocated to line 2", f.Line, f.CursorURI)
+			}
+			return
+		}
+	}
+	t.Error("shifted finding missing from session")
+}
 func TestStart_withMockOllama(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -2321,6 +2771,496 @@ func TestRun_pipeline_multipleHunks_ordersFindingsAndOneGeneratePerHunk(t *testi
 	}
 }
 
+// TestStart_summaryEnabled_injectsChangeSummary asserts that with SummaryEnabled
+// Start makes one summary call over the whole change (commit messages included)
+// and every hunk's system prompt carries the resulting Change Summary section.
+func TestStart_summaryEnabled_injectsChangeSummary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var overviews, reviewSystems []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		if r.URL.Path != "/api/generate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			System string `json:"system"`
+			Prompt string `json:"prompt"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := `[]`
+		mu.Lock()
+		if body.System == summary.SystemPrompt {
+			overviews = append(overviews, body.Prompt)
+			resp = "- Adds f3 and f4 together"
+		} else {
+			reviewSystems = append(reviewSystems, body.System)
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": resp, "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	writeFile(t, repo, "f3.txt", "c\n")
+	writeFile(t, repo, "f4.txt", "d\n")
+	runGit(t, repo, "git", "add", "f3.txt", "f4.txt")
+	runGit(t, repo, "git", "commit", "-m", "add f3 and f4")
+	opts := StartOptions{
+		RepoRoot:       repo,
+		StateDir:       stateDir,
+		Ref:            "HEAD~1",
+		Model:          "m",
+		Provider:       "ollama",
+		LLMBaseURL:     srv.URL,
+		SummaryEnabled: true,
+	}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if len(overviews) != 1 {
+		t.Fatalf("summary calls = %d, want 1", len(overviews))
+	}
+	for _, want := range []string{"add f3 and f4", "f3.txt (+1 -0; new file)", "f4.txt (+1 -0; new file)"} {
+		if !strings.Contains(overviews[0], want) {
+			t.Errorf("overview missing %q:\n%s", want, overviews[0])
+		}
+	}
+	if len(reviewSystems) != 2 {
+		t.Fatalf("review calls = %d, want 2", len(reviewSystems))
+	}
+	for i, sys := range reviewSystems {
+		if !strings.Contains(sys, "## Change Summary\n- Adds f3 and f4 together") {
+			t.Errorf("review system prompt %d missing change summary", i)
+		}
+	}
+}
+
+// TestStart_danglingRefs_reportsRemainingReferences asserts that when a hunk
+// removes a Go function that HEAD still calls, Start adds a correctness warning
+// at the call site alongside the model's (empty) findings, and that the
+// finding goes through abstention and config filters like model findings.
+func TestStart_danglingRefs_reportsRemainingReferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": "[]", "done": true})
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name    string
+		minKeep float64
+		filters findings.FilterRules
+		want    int
+	}{
+		{"default thresholds", 0, findings.FilterRules{}, 1},
+		{"abstention drops", 0.9, findings.FilterRules{}, 0},
+		{"config filter drops", 0, findings.FilterRules{Drop: []findings.DropRule{{Path: "use.go"}}}, 0},
+	} {
+		repo := initRepo(t)
+		stateDir := filepath.Join(repo, ".review")
+		writeFile(t, repo, "lib.go", "package main\n\nfunc Helper() int {\n\treturn 1\n}\n")
+		writeFile(t, repo, "use.go", "package main\n\nfunc use() int {\n\treturn Helper()\n}\n")
+		runGit(t, repo, "git", "add", "lib.go", "use.go")
+		runGit(t, repo, "git", "commit", "-m", "add helper")
+		writeFile(t, repo, "lib.go", "package main\n")
+		runGit(t, repo, "git", "add", "lib.go")
+		runGit(t, repo, "git", "commit", "-m", "remove helper")
+		opts := StartOptions{
+			RepoRoot:            repo,
+			StateDir:            stateDir,
+			Ref:                 "HEAD~1",
+			Model:               "m",
+			Provider:            "ollama",
+			LLMBaseURL:          srv.URL,
+			DanglingRefsEnabled: true,
+			MinConfidenceKeep:   tc.minKeep,
+			Filters:             tc.filters,
+		}
+		if tc.minKeep != 0 {
+			opts.MinConfidenceMaintainability = findings.DefaultMinConfidenceMaintainability
+		}
+		if _, err := Start(ctx, opts); err != nil {
+			t.Fatalf("%s: Start: %v", tc.name, err)
+		}
+		s, err := session.Load(stateDir)
+		if err != nil {
+			t.Fatalf("%s: Load session: %v", tc.name, err)
+		}
+		if len(s.Findings) != tc.want {
+			t.Fatalf("%s: Findings = %+v, want %d dangling-reference finding(s)", tc.name, s.Findings, tc.want)
+		}
+		if tc.want == 0 {
+			continue
+		}
+		f := s.Findings[0]
+		if f.File != "use.go" || f.Line != 4 || f.Severity != findings.SeverityWarning || !strings.Contains(f.Message, "Helper was removed from lib.go") || f.CursorURI == "" {
+			t.Errorf("%s: finding = %+v, want use.go:4 warning referencing removed Helper", tc.name, f)
+		}
+	}
+}
+
+func TestStart_hunkMergeGap_reviewsNearbyHunksInOnePrompt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var prompts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		var req struct {
+			Prompt string `json:"prompt"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		prompts = append(prompts, req.Prompt)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": "[]", "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line%d", i))
+	}
+	writeFile(t, repo, "big.txt", strings.Join(lines, "\n")+"\n")
+	runGit(t, repo, "git", "add", "big.txt")
+	runGit(t, repo, "git", "commit", "-m", "add big")
+	lines[4], lines[12] = "changed5", "changed13"
+	writeFile(t, repo, "big.txt", strings.Join(lines, "\n")+"\n")
+	runGit(t, repo, "git", "add", "big.txt")
+	runGit(t, repo, "git", "commit", "-m", "edit big")
+	opts := StartOptions{
+		RepoRoot:     repo,
+		StateDir:     stateDir,
+		Ref:          "HEAD~1",
+		Model:        "m",
+		Provider:     "ollama",
+		LLMBaseURL:   srv.URL,
+		HunkMergeGap: 3,
+	}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(prompts) != 1 {
+		t.Fatalf("generate calls = %d, want 1 (two hunks one line apart merged)", len(prompts))
+	}
+	for _, want := range []string{"+changed5", "+changed13", " line9", "@@ -2,15 +2,15 @@"} {
+		if !strings.Contains(prompts[0], want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompts[0])
+		}
+	}
+}
+
+func TestStart_perCommit_reviewsEachCommitWithItsMessage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var systems []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		var req struct {
+			System string `json:"system"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		systems = append(systems, req.System)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": "[]", "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	writeFile(t, repo, "alpha.txt", "alpha\n")
+	runGit(t, repo, "git", "add", "alpha.txt")
+	runGit(t, repo, "git", "commit", "-m", "feat: add alpha")
+	alpha := runOut(t, repo, "git", "rev-parse", "HEAD")
+	writeFile(t, repo, "beta.txt", "beta\n")
+	writeFile(t, repo, "alpha.txt", "header\n\nalpha\n")
+	runGit(t, repo, "git", "add", "alpha.txt", "beta.txt")
+	runGit(t, repo, "git", "commit", "-m", "fix: add beta")
+	beta := runOut(t, repo, "git", "rev-parse", "HEAD")
+
+	// Dry run: one canned finding per hunk, tagged with its commit. The first
+	// commit's finding (alpha.txt:1 in that commit) is moved to its line at HEAD.
+	dryState, wtRoot := filepath.Join(t.TempDir(), "dry"), t.TempDir()
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: dryState, WorktreeRoot: wtRoot, Ref: "HEAD~2", PerCommit: true, DryRun: true, Provider: "ollama"}); err != nil {
+		t.Fatalf("Start(dry-run, per-commit): %v", err)
+	}
+	s, err := session.Load(dryState)
+	if err != nil {
+		t.Fatalf("Load session: %v", err)
+	}
+	if len(s.Findings) != 3 {
+		t.Fatalf("findings = %+v, want 3 (alpha in the first commit; alpha and beta in the second)", s.Findings)
+	}
+	type loc struct {
+		file string
+		line int
+	}
+	want := map[loc]string{{"alpha.txt", 3}: alpha, {"alpha.txt", 1}: beta, {"beta.txt", 1}: beta}
+	for _, f := range s.Findings {
+		c, ok := want[loc{f.File, f.Line}]
+		if !ok || f.Commit != c {
+			t.Errorf("finding %s:%d from %.7s, want one of %v", f.File, f.Line, f.Commit, want)
+		}
+		if !strings.HasSuffix(f.CursorURI, fmt.Sprintf("#L%d", f.Line)) {
+			t.Errorf("%s:%d: CursorURI = %q, want the HEAD line", f.File, f.Line, f.CursorURI)
+		}
+	}
+	if err := Finish(ctx, FinishOptions{RepoRoot: repo, StateDir: dryState, WorktreeRoot: wtRoot}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	// LLM: each commit is its own prompt with its own message as intent.
+	opts := StartOptions{RepoRoot: repo, StateDir: filepath.Join(repo, ".review"), Ref: "HEAD~2", PerCommit: true, Model: "m", Provider: "ollama", LLMBaseURL: srv.URL}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start(per-commit): %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(systems) != 3 {
+		t.Fatalf("generate calls = %d, want 3", len(systems))
+	}
+	if !strings.Contains(systems[0], "feat: add alpha") || strings.Contains(systems[0], "fix: add beta") {
+		t.Errorf("first prompt should carry only the first commit message:\n%s", systems[0])
+	}
+	if !strings.Contains(systems[1], "fix: add beta") {
+		t.Errorf("second prompt should carry the second commit message:\n%s", systems[1])
+	}
+}
+
+// TestStart_perCommit_contextAtCommit asserts that per-commit review reads prompt
+// context at each commit: the second commit adds a function above the one the
+// first commit changed, so reading the first commit's hunk lines at HEAD would
+// expand the wrong function.
+func TestStart_perCommit_contextAtCommit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	prompts := make(map[string]string) // commit subject -> user prompt
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		var req struct {
+			System string `json:"system"`
+			Prompt string `json:"prompt"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		for _, subject := range []string{"fix: add b", "feat: add sub"} {
+			if strings.Contains(req.System, subject) {
+				prompts[subject] += req.Prompt
+			}
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": "[]", "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	body := "\t// top of Add\n\tx := a\n\tx++\n\tx++\n\tx++\n\tx++\n\tx++\n\tx++\n\t%s\n\tx++\n\tx++\n\tx++\n\tx++\n\tx++\n\treturn x\n"
+	writeFile(t, repo, "calc.go", "package calc\n\nfunc Add(a, b int) int {\n"+fmt.Sprintf(body, "x++")+"}\n")
+	runGit(t, repo, "git", "add", "calc.go")
+	runGit(t, repo, "git", "commit", "-m", "add calc")
+	addB := "package calc\n\nfunc Add(a, b int) int {\n" + fmt.Sprintf(body, "x += b") + "}\n"
+	writeFile(t, repo, "calc.go", addB)
+	runGit(t, repo, "git", "commit", "-am", "fix: add b")
+	sub := "func Sub(a, b int) int {\n\t// inside Sub\n" + strings.Repeat("\ta--\n", 12) + "\treturn a - b\n}\n\n"
+	writeFile(t, repo, "calc.go", strings.Replace(addB, "func Add", sub+"func Add", 1))
+	runGit(t, repo, "git", "commit", "-am", "feat: add sub")
+
+	opts := StartOptions{RepoRoot: repo, StateDir: filepath.Join(repo, ".review"), Ref: "HEAD~2", PerCommit: true, Model: "m", Provider: "ollama", LLMBaseURL: srv.URL, ContextLimit: 32768}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start(per-commit): %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	first := prompts["fix: add b"]
+	if !strings.Contains(first, "## Enclosing function context") || !strings.Contains(first, "// top of Add") || strings.Contains(first, "inside Sub") {
+		t.Errorf("first commit prompt should expand Add as of that commit:\n%s", first)
+	}
+	if prompts["feat: add sub"] == "" {
+		t.Error("second commit was not reviewed")
+	}
+	list, err := git.List(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range list {
+		if strings.Contains(w.Path, "stet-commit-") {
+			t.Errorf("commit worktree %s was not removed", w.Path)
+		}
+	}
+}
+
+func TestReviewPatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var systems []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		var req struct {
+			System string `json:"system"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		systems = append(systems, req.System)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": "[]", "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	patch := "From: Dev <dev@example.com>\nSubject: [PATCH] Greet twice\n\n---\n" +
+		"diff --git a/f1.txt b/f1.txt\n--- a/f1.txt\n+++ b/f1.txt\n@@ -1 +1,2 @@\n a\n+hello\n" +
+		"-- \n2.43.0\n"
+
+	got, _, err := ReviewPatch(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, DryRun: true}, patch)
+	if err != nil {
+		t.Fatalf("ReviewPatch(dry-run): %v", err)
+	}
+	if len(got) != 1 || got[0].File != "f1.txt" {
+		t.Fatalf("findings = %+v, want one canned finding in f1.txt", got)
+	}
+	if _, err := os.Stat(stateDir); !os.IsNotExist(err) {
+		t.Errorf("state dir should not be created by a patch review, stat err = %v", err)
+	}
+
+	if _, _, err := ReviewPatch(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Model: "m", Provider: "ollama", LLMBaseURL: srv.URL}, patch); err != nil {
+		t.Fatalf("ReviewPatch: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(systems) != 1 {
+		t.Fatalf("generate calls = %d, want 1", len(systems))
+	}
+	if !strings.Contains(systems[0], "Commit: Greet twice") || strings.Contains(systems[0], "Branch:") {
+		t.Errorf("patch subject should be the only user intent:\n%s", systems[0])
+	}
+
+	if _, _, err := ReviewPatch(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, DryRun: true}, "not a diff\n"); err == nil {
+		t.Error("ReviewPatch without hunks: want error")
+	}
+}
+
+func TestStart_fileBatching_attributesFindingsToHunks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var prompts []string
+	resp := `[{"file":"big.txt","line":5,"severity":"warning","category":"correctness","confidence":0.95,"message":"first hunk issue"},` +
+		`{"file":"big.txt","line":20,"severity":"warning","category":"correctness","confidence":0.95,"message":"between hunks"},` +
+		`{"file":"big.txt","line":35,"severity":"warning","category":"correctness","confidence":0.95,"message":"second hunk issue"}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"models": []map[string]interface{}{{"name": "m"}}})
+			return
+		}
+		var req struct {
+			Prompt string `json:"prompt"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		prompts = append(prompts, req.Prompt)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": resp, "done": true})
+	}))
+	defer srv.Close()
+
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	var lines []string
+	for i := 1; i <= 40; i++ {
+		lines = append(lines, fmt.Sprintf("line%d", i))
+	}
+	writeFile(t, repo, "big.txt", strings.Join(lines, "\n")+"\n")
+	runGit(t, repo, "git", "add", "big.txt")
+	runGit(t, repo, "git", "commit", "-m", "add big")
+	lines[4], lines[34] = "changed5", "changed35"
+	writeFile(t, repo, "big.txt", strings.Join(lines, "\n")+"\n")
+	runGit(t, repo, "git", "add", "big.txt")
+	runGit(t, repo, "git", "commit", "-m", "edit big")
+	opts := StartOptions{
+		RepoRoot:            repo,
+		StateDir:            stateDir,
+		Ref:                 "HEAD~1",
+		Model:               "m",
+		Provider:            "ollama",
+		LLMBaseURL:          srv.URL,
+		FileBatchingEnabled: true,
+	}
+	if _, err := Start(ctx, opts); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	mu.Lock()
+	if len(prompts) != 1 {
+		t.Fatalf("generate calls = %d, want 1 (both hunks of big.txt batched)", len(prompts))
+	}
+	if !strings.Contains(prompts[0], "File: big.txt (2 hunks)") || !strings.Contains(prompts[0], "+changed35") {
+		t.Errorf("batched prompt missing header or second hunk:\n%s", prompts[0])
+	}
+	mu.Unlock()
+	s, err := session.Load(stateDir)
+	if err != nil {
+		t.Fatalf("Load session: %v", err)
+	}
+	if len(s.Findings) != 2 {
+		t.Fatalf("Findings = %+v, want 2 (finding between hunks dropped)", s.Findings)
+	}
+	for _, f := range s.Findings {
+		ctx := s.FindingPromptContext[f.ID]
+		want, other := "+changed5", "+changed35"
+		if f.Line == 35 {
+			want, other = other, want
+		}
+		if !strings.Contains(ctx, want) || strings.Contains(ctx, other) {
+			t.Errorf("prompt context for line %d = %q, want only its own hunk", f.Line, ctx)
+		}
+	}
+}
+
 // TestSuppressionWiring_systemPromptContainsSection asserts that when suppression
 // examples are loaded from history and passed to PrepareHunkPrompt (per-hunk
 // wiring), the returned system prompt contains the "Do not report issues similar to"
@@ -2353,7 +3293,7 @@ func TestSuppressionWiring_systemPromptContainsSection(t *testing.T) {
 	}
 	hunk := diff.Hunk{FilePath: "pkg/foo.go", RawContent: "@@ -1,1 +1,1 @@\n code\n", Context: "code"}
 	ctx := context.Background()
-	system, _, err := review.PrepareHunkPrompt(ctx, systemBase, hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, examples, nil)
+	system, _, err := review.PrepareHunkPrompt(ctx, systemBase, hunk, nil, "", 32768, 0, 0, false, 0, 0, 0, false, nil, false, nil, false, examples, nil)
 	if err != nil {
 		t.Fatalf("PrepareHunkPrompt: %v", err)
 	}
@@ -2365,3 +3305,80 @@ func TestSuppressionWiring_systemPromptContainsSection(t *testing.T) {
 		t.Errorf("system prompt should contain example %q", wantEx)
 	}
 }
+
+func TestExportImport_cloneContinuesReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepo(t)
+	stateDir := filepath.Join(repo, ".review")
+	if _, err := Start(ctx, StartOptions{RepoRoot: repo, StateDir: stateDir, Ref: "HEAD~1", DryRun: true}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	s, err := session.Load(stateDir)
+	if err != nil || len(s.Findings) == 0 {
+		t.Fatalf("need findings after Start; err=%v", err)
+	}
+	session.SetStatus(&s, s.Findings[0].ID, findings.StatusDismissed, "", history.ReasonFalsePositive)
+	if err := session.Save(stateDir, &s); err != nil {
+		t.Fatal(err)
+	}
+	bundle := filepath.Join(t.TempDir(), "review.stet.gz")
+	cfg := history.NewRunConfigSnapshot("model-a", "strict", 0, 0, false)
+	if err := Export(ExportOptions{StateDir: stateDir, Path: bundle, Config: cfg, ExportedBy: "Test <test@stet.local>"}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if err := Export(ExportOptions{StateDir: stateDir, Session: "other", Path: bundle + ".x"}); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Export without session: err = %v, want ErrNoSession", err)
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, repo, "git", "clone", "-q", repo, clone)
+	cloneState := filepath.Join(clone, ".review")
+	b, err := Import(ImportOptions{RepoRoot: clone, StateDir: cloneState, Path: bundle})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if b.ExportedBy != "Test <test@stet.local>" || b.Config == nil || b.Config.Model != "model-a" {
+		t.Errorf("bundle = %+v", b)
+	}
+	got, err := session.Load(cloneState)
+	if err != nil {
+		t.Fatalf("Load imported session: %v", err)
+	}
+	if got.BaselineRef != s.BaselineRef || got.SessionID != s.SessionID || len(got.Findings) != len(s.Findings) || len(got.DismissedIDs) != 1 {
+		t.Errorf("imported session = %+v, want %+v", got, s)
+	}
+	wt, err := git.PathForSession(clone, "", "", got.BaselineRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(wt); err != nil {
+		t.Errorf("worktree not created at %s: %v", wt, err)
+	}
+	if _, err := Import(ImportOptions{RepoRoot: clone, StateDir: cloneState, Path: bundle}); !errors.Is(err, ErrSessionExists) {
+		t.Errorf("second Import: err = %v, want ErrSessionExists", err)
+	}
+
+	b.Session.BaselineRef = strings.Repeat("ab", 20)
+	missing := filepath.Join(t.TempDir(), "missing.stet.gz")
+	if err := session.WriteBundle(missing, b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Import(ImportOptions{RepoRoot: clone, StateDir: cloneState, Session: "x", Path: missing}); err == nil || !strings.Contains(err.Error(), "not in this repository") {
+		t.Errorf("Import with unknown baseline: err = %v, want not in this repository", err)
+	}
+
+	// Refs, revision expressions, short SHAs and option-like values are rejected before git runs.
+	for _, c := range []struct{ baseline, last string }{
+		{"HEAD~1", ""}, {"master", ""}, {"--output=/tmp/x", ""}, {s.BaselineRef[:12], ""}, {strings.ToUpper(s.BaselineRef), ""}, {s.BaselineRef, "HEAD"},
+	} {
+		b.Session.BaselineRef, b.Session.LastReviewedAt = c.baseline, c.last
+		bad := filepath.Join(t.TempDir(), "bad.stet.gz")
+		if err := session.WriteBundle(bad, b); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Import(ImportOptions{RepoRoot: clone, StateDir: cloneState, Session: "y", Path: bad}); err == nil || !strings.Contains(err.Error(), "not a full commit SHA") {
+			t.Errorf("Import(baseline %q, last reviewed %q): err = %v, want not a full commit SHA", c.baseline, c.last, err)
+		}
+	}
+}
@@ -16,15 +16,17 @@ type Result struct {
 	// ToReview are hunks to send to the LLM (new or changed since last_reviewed_at).
 	ToReview []diff.Hunk
 	// Approved are hunks that existed at last_reviewed_at and are unchanged
-	// (strict match) or same semantically (comment/whitespace only).
+	// (strict match) or the same after normalization at the given level
+	// (semantic match; see hunkid.Level).
 	Approved []diff.Hunk
 }
 
 // Partition splits hunks for baseline..head into ToReview and Approved using
 // the "already reviewed" set from baseline..lastReviewedAt. When
 // lastReviewedAt is empty (first run), all current hunks go to ToReview and
-// Approved is nil. Context is used for cancellation when running git diff.
-func Partition(ctx context.Context, repoRoot, baselineRef, headRef, lastReviewedAt string, opts *diff.Options) (Result, error) {
+// Approved is nil. level selects the semantic normalization ("" = text).
+// Context is used for cancellation when running git diff.
+func Partition(ctx context.Context, repoRoot, baselineRef, headRef, lastReviewedAt string, level hunkid.Level, opts *diff.Options) (Result, error) {
 	current, err := diff.Hunks(ctx, repoRoot, baselineRef, headRef, opts)
 	if err != nil {
 		return Result{}, erruser.New("Could not compute diff.", err)
@@ -45,14 +47,14 @@ func Partition(ctx context.Context, repoRoot, baselineRef, headRef, lastReviewed
 	semanticIDs := make(map[string]struct{}, len(reviewed))
 	for _, h := range reviewed {
 		strictIDs[hunkid.StrictHunkID(h.FilePath, h.RawContent)] = struct{}{}
-		semanticIDs[hunkid.SemanticHunkID(h.FilePath, h.RawContent)] = struct{}{}
+		semanticIDs[hunkid.SemanticHunkIDLevel(h.FilePath, h.RawContent, level)] = struct{}{}
 	}
 
 	toReview := make([]diff.Hunk, 0, len(current))
 	approved := make([]diff.Hunk, 0, len(current))
 	for _, h := range current {
 		sid := hunkid.StrictHunkID(h.FilePath, h.RawContent)
-		mid := hunkid.SemanticHunkID(h.FilePath, h.RawContent)
+		mid := hunkid.SemanticHunkIDLevel(h.FilePath, h.RawContent, level)
 		if _, ok := strictIDs[sid]; ok {
 			approved = append(approved, h)
 			continue
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"stet/cli/internal/diff"
+	"stet/cli/internal/hunkid"
 )
 
 func initRepoScope(t *testing.T) string {
@@ -72,7 +73,7 @@ func TestPartition_firstRun(t *testing.T) {
 	ctx := context.Background()
 	repo := initRepoScope(t)
 	// baseline=HEAD~2 (gitignore), head=HEAD (c2): diff has f1 and f2. First run → all to review.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -96,7 +97,7 @@ func TestPartition_noNewWork(t *testing.T) {
 	ctx := context.Background()
 	repo := initRepoScope(t)
 	// baseline=HEAD~2, head=HEAD~1, lastReviewedAt=HEAD~1 → current = [f1], reviewed = [f1] → all approved.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD~1", "HEAD~1", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD~1", "HEAD~1", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -116,7 +117,7 @@ func TestPartition_incremental(t *testing.T) {
 	ctx := context.Background()
 	repo := initRepoScope(t)
 	// baseline=HEAD~2, head=HEAD, lastReviewedAt=HEAD~1 → current = [f1,f2], reviewed = [f1] → f1 approved, f2 to review.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -138,7 +139,7 @@ func TestPartition_emptyDiff(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	repo := initRepoScope(t)
-	got, err := Partition(ctx, repo, "HEAD", "HEAD", "", nil)
+	got, err := Partition(ctx, repo, "HEAD", "HEAD", "", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -154,7 +155,7 @@ func TestPartition_emptyDiffWithLastReviewed(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	repo := initRepoScope(t)
-	got, err := Partition(ctx, repo, "HEAD", "HEAD", "HEAD", nil)
+	got, err := Partition(ctx, repo, "HEAD", "HEAD", "HEAD", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -170,7 +171,7 @@ func TestPartition_invalidLastReviewedAt(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	repo := initRepoScope(t)
-	_, err := Partition(ctx, repo, "HEAD~2", "HEAD", "invalid-ref-no-such-commit", nil)
+	_, err := Partition(ctx, repo, "HEAD~2", "HEAD", "invalid-ref-no-such-commit", "", nil)
 	if err == nil {
 		t.Fatal("Partition with invalid lastReviewedAt: expected error")
 	}
@@ -185,7 +186,7 @@ func TestPartition_semanticMatch(t *testing.T) {
 	repo := initRepoGoComment(t)
 	// baseline=HEAD~2, head=HEAD, lastReviewedAt=HEAD~1 → reviewed = [original p.go hunk],
 	// current = [p.go hunk with comment]. Semantic ID same → approved.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -203,7 +204,7 @@ func TestPartition_semanticMatch(t *testing.T) {
 func TestPartition_diffErrorPropagated(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, err := Partition(ctx, "", "HEAD~2", "HEAD", "", nil)
+	_, err := Partition(ctx, "", "HEAD~2", "HEAD", "", "", nil)
 	if err == nil {
 		t.Fatal("Partition with empty repoRoot: expected error")
 	}
@@ -218,7 +219,7 @@ func TestPartition_headEqualsLastReviewedAt(t *testing.T) {
 	ctx := context.Background()
 	repo := initRepoScope(t)
 	// head=HEAD, lastReviewedAt=HEAD → current == reviewed → all approved, none to review.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -239,7 +240,7 @@ func TestPartition_strictMatch(t *testing.T) {
 	// has f1 (unchanged) and f2 (new). f1 strict match → approved, f2 → to review. We already
 	// covered this in TestPartition_incremental (f1 approved = strict match). Add explicit
 	// check that approved hunk content matches reviewed.
-	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", nil)
+	got, err := Partition(ctx, repo, "HEAD~2", "HEAD", "HEAD~1", "", nil)
 	if err != nil {
 		t.Fatalf("Partition: %v", err)
 	}
@@ -255,3 +256,26 @@ func TestPartition_strictMatch(t *testing.T) {
 		t.Error("approved hunk RawContent should match reviewed (strict match)")
 	}
 }
+
+func TestPartition_levelCarriesReviewAcrossFormatting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := initRepoGoComment(t)
+	// Reformat F (layout only) on top of the comment commit: same tokens, different text.
+	writeFileScope(t, repo, "p.go", "package p\n\nfunc F() {\n}\n")
+	runGit(t, repo, "git", "commit", "-am", "reformat")
+	got, err := Partition(ctx, repo, "HEAD~3", "HEAD", "HEAD~2", hunkid.LevelAST, nil)
+	if err != nil {
+		t.Fatalf("Partition: %v", err)
+	}
+	if len(got.ToReview) != 0 || len(got.Approved) != 1 {
+		t.Errorf("ast: ToReview=%d Approved=%d, want 0 and 1", len(got.ToReview), len(got.Approved))
+	}
+	got, err = Partition(ctx, repo, "HEAD~3", "HEAD", "HEAD~2", hunkid.LevelText, nil)
+	if err != nil {
+		t.Fatalf("Partition: %v", err)
+	}
+	if len(got.ToReview) != 1 {
+		t.Errorf("text: len(ToReview) = %d, want 1", len(got.ToReview))
+	}
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"stet/cli/internal/erruser"
+	"stet/cli/internal/history"
+)
+
+// BundleVersion is the current session export format version.
+const BundleVersion = 1
+
+// Bundle is a portable session export (stet session export): the session with
+// its findings, statuses, comments and prompt contexts, plus who exported it and
+// the review configuration, so a teammate can continue triage with stet session
+// import. Stored as gzipped JSON.
+type Bundle struct {
+	Version    int                        `json:"version"`
+	ExportedAt string                     `json:"exported_at"`
+	ExportedBy string                     `json:"exported_by,omitempty"`
+	Config     *history.RunConfigSnapshot `json:"config,omitempty"`
+	Session    Session                    `json:"session"`
+}
+
+// WriteBundle writes b to path as gzipped JSON, replacing any existing file.
+func WriteBundle(path string, b *Bundle) error {
+	if b == nil {
+		return erruser.New("Cannot export nil session bundle.", nil)
+	}
+	b.Version = BundleVersion
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return erruser.New("Could not export session.", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return erruser.New("Could not export session.", err)
+	}
+	if err := zw.Close(); err != nil {
+		return erruser.New("Could not export session.", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return erruser.New(fmt.Sprintf("Could not write session export %s.", path), err)
+	}
+	return nil
+}
+
+// ReadBundle reads a bundle written by WriteBundle. It returns a user-facing
+// error when the file is not a session export, is from a newer stet, or holds
+// no session.
+func ReadBundle(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("Could not read session export %s.", path), err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("%s is not a stet session export.", path), err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, erruser.New(fmt.Sprintf("Could not read session export %s.", path), err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, erruser.New(fmt.Sprintf("%s is not a stet session export.", path), err)
+	}
+	if b.Version > BundleVersion {
+		return nil, erruser.New(fmt.Sprintf("Session export %s was written by a newer stet; upgrade stet.", path), nil)
+	}
+	if b.Session.BaselineRef == "" {
+		return nil, erruser.New(fmt.Sprintf("Session export %s has no review session.", path), nil)
+	}
+	return &b, nil
+}
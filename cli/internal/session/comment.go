@@ -0,0 +1,38 @@
+package session
+
+import "stet/cli/internal/findings"
+
+// AddComment appends c to the comment thread of the finding with id in s. When
+// the finding was dismissed with a prompt shadow, the shadow's note is updated
+// so later reviews see the comment. Reports whether a finding with id exists.
+func AddComment(s *Session, id string, c findings.Comment) bool {
+	found := false
+	var thread []findings.Comment
+	for i := range s.Findings {
+		if s.Findings[i].ID != id {
+			continue
+		}
+		found = true
+		s.Findings[i].Comments = append(s.Findings[i].Comments, c)
+		thread = s.Findings[i].Comments
+	}
+	if !found {
+		return false
+	}
+	for i := range s.PromptShadows {
+		if s.PromptShadows[i].FindingID == id {
+			s.PromptShadows[i].Note = findings.CommentText(thread)
+		}
+	}
+	return true
+}
+
+// Comments returns the comment thread of the finding with id in s, or nil.
+func Comments(s *Session, id string) []findings.Comment {
+	for _, f := range s.Findings {
+		if f.ID == id {
+			return f.Comments
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"stet/cli/internal/erruser"
+)
+
+// sessionsDirname is the subdirectory of the state dir holding named sessions,
+// one directory (with its own session.json and lock) per name.
+const sessionsDirname = "sessions"
+
+// maxNameLen bounds session names; they become directory and worktree names.
+const maxNameLen = 64
+
+// Dir returns the directory holding session.json and the lock of the named
+// session: stateDir itself for the default session (name ""), otherwise
+// stateDir/sessions/<name>. History, prompts and other state stay in stateDir.
+func Dir(stateDir, name string) string {
+	if name == "" {
+		return stateDir
+	}
+	return filepath.Join(stateDir, sessionsDirname, name)
+}
+
+// ValidateName reports whether name can be used as a session name: 1-64
+// letters, digits, '.', '_' or '-', not starting with '.' or '-'. The empty
+// name (default session) is valid.
+func ValidateName(name string) error {
+	if name == "" {
+		return nil
+	}
+	invalid := len(name) > maxNameLen || name[0] == '.' || name[0] == '-'
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			invalid = true
+		}
+	}
+	if invalid {
+		return erruser.New(fmt.Sprintf("Invalid session name %q: use up to %d letters, digits, '.', '_' or '-', not starting with '.' or '-'.", name, maxNameLen), nil)
+	}
+	return nil
+}
+
+// Names returns the names of the sessions under stateDir that have a session
+// file: "" for the default session first (when it exists), then named
+// sessions in lexical order. A missing stateDir yields no names.
+func Names(stateDir string) ([]string, error) {
+	var names []string
+	if _, err := os.Stat(filepath.Join(stateDir, sessionFilename)); err == nil {
+		names = append(names, "")
+	}
+	entries, err := os.ReadDir(filepath.Join(stateDir, sessionsDirname))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return names, nil
+		}
+		return nil, erruser.New("Could not list sessions.", err)
+	}
+	var named []string
+	for _, e := range entries {
+		if !e.IsDir() || ValidateName(e.Name()) != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(stateDir, sessionsDirname, e.Name(), sessionFilename)); err == nil {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...), nil
+}
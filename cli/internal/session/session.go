@@ -1,6 +1,7 @@
 // Package session provides the state schema and storage for a single review
 // session: session.json with baseline_ref, last_reviewed_at, dismissed_ids,
 // and optional prompt_shadows. Load/save and advisory lock live in this package.
+// Named sessions (stet --session) each use their own directory; see Dir.
 package session
 
 import (
@@ -22,14 +23,16 @@ const (
 )
 
 // PromptShadow stores a finding_id and prompt_context for future negative
-// few-shot injection (Phase 6). Stored in session now, not yet used.
+// few-shot injection (Phase 6). Note is the text of the finding's comments
+// (stet comment), injected with the context so the model sees why it was dismissed.
 type PromptShadow struct {
 	FindingID     string `json:"finding_id"`
 	PromptContext string `json:"prompt_context"`
+	Note          string `json:"note,omitempty"`
 }
 
-// Session is the persisted state for one review session per repo.
-// Stored at stateDir/session.json.
+// Session is the persisted state for one review session.
+// Stored at Dir(stateDir, name)/session.json.
 // Strictness and RAG options (when set on stet start) are persisted so stet run
 // uses them when the corresponding flags are not set.
 type Session struct {
@@ -40,6 +43,9 @@ type Session struct {
 	PromptShadows         []PromptShadow     `json:"prompt_shadows,omitempty"`
 	FindingPromptContext  map[string]string  `json:"finding_prompt_context,omitempty"`
 	Findings              []findings.Finding `json:"findings,omitempty"`
+	// TargetBranch is the branch given to stet start --against; BaselineRef is then its
+	// merge base with HEAD at start. Empty when the session was started at a ref.
+	TargetBranch string `json:"target_branch,omitempty"`
 	// Strictness is the preset from stet start (empty = not set; run uses config).
 	Strictness string `json:"strictness,omitempty"`
 	// RAGSymbolMaxDefinitions from stet start (nil = not set; 0 is valid = disable).
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"stet/cli/internal/findings"
 )
@@ -288,3 +290,133 @@ func TestDelete_missingFileSucceeds(t *testing.T) {
 		t.Fatalf("Delete(missing file): %v", err)
 	}
 }
+
+func TestDir(t *testing.T) {
+	t.Parallel()
+	if got := Dir("/state", ""); got != "/state" {
+		t.Errorf("Dir(default) = %q, want /state", got)
+	}
+	if got, want := Dir("/state", "pr-42"), filepath.Join("/state", "sessions", "pr-42"); got != want {
+		t.Errorf("Dir(pr-42) = %q, want %q", got, want)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"", false},
+		{"feature-x", false},
+		{"alice_review.2", false},
+		{".hidden", true},
+		{"-flag", true},
+		{"a/b", true},
+		{"..", true},
+		{"with space", true},
+		{string(make([]byte, maxNameLen+1)), true},
+	}
+	for _, tt := range tests {
+		if err := ValidateName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateName(%q) err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	if got, err := Names(filepath.Join(stateDir, "missing")); err != nil || len(got) != 0 {
+		t.Fatalf("Names(missing) = %v, %v; want none", got, err)
+	}
+	for _, name := range []string{"zeta", "", "alpha"} {
+		if err := Save(Dir(stateDir, name), &Session{BaselineRef: "abc"}); err != nil {
+			t.Fatalf("Save(%q): %v", name, err)
+		}
+	}
+	// A directory without a session file (e.g. after finish) is not listed.
+	if err := os.MkdirAll(Dir(stateDir, "finished"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Names(stateDir)
+	if err != nil {
+		t.Fatalf("Names: %v", err)
+	}
+	want := []string{"", "alpha", "zeta"}
+	if len(got) != len(want) {
+		t.Fatalf("Names = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Names[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSetStatusAndFindingsWithStatus(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := &Session{
+		Findings: []findings.Finding{
+			{ID: "open1"}, {ID: "legacy"}, {ID: "acc"}, {ID: "def"}, {ID: "expired"}, {ID: "dis"}, {ID: "fix"},
+		},
+		// "legacy" was dismissed before statuses existed.
+		DismissedIDs: []string{"legacy"},
+	}
+	SetStatus(s, "acc", findings.StatusAccepted, "", "")
+	SetStatus(s, "def", findings.StatusDeferred, "2026-04-01", "")
+	SetStatus(s, "expired", findings.StatusDeferred, "2026-03-10", "")
+	SetStatus(s, "dis", findings.StatusDismissed, "", "false_positive")
+	SetStatus(s, "fix", findings.StatusFixed, "", "")
+	if SetStatus(s, "missing", findings.StatusAccepted, "", "") {
+		t.Error("SetStatus(missing) = true, want false")
+	}
+	want := map[string]findings.Status{
+		"open1": findings.StatusOpen, "legacy": findings.StatusDismissed, "acc": findings.StatusAccepted,
+		"def": findings.StatusDeferred, "expired": findings.StatusOpen, "dis": findings.StatusDismissed, "fix": findings.StatusFixed,
+	}
+	for _, f := range FindingsWithStatus(s, now) {
+		if f.Status != want[f.ID] {
+			t.Errorf("%s: status = %q, want %q", f.ID, f.Status, want[f.ID])
+		}
+		if f.ID == "dis" && f.DismissReason != "false_positive" {
+			t.Errorf("dis: DismissReason = %q", f.DismissReason)
+		}
+		if (f.ID == "def") != (f.DeferredUntil != "") {
+			t.Errorf("%s: DeferredUntil = %q", f.ID, f.DeferredUntil)
+		}
+	}
+	if got := strings.Join(s.DismissedIDs, ","); got != "legacy,dis,fix" {
+		t.Errorf("DismissedIDs = %s, want legacy,dis,fix", got)
+	}
+	// Accepting a dismissed finding reopens it.
+	SetStatus(s, "dis", findings.StatusAccepted, "", "")
+	if got := strings.Join(s.DismissedIDs, ","); got != "legacy,fix" {
+		t.Errorf("DismissedIDs after accept = %s, want legacy,fix", got)
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	t.Parallel()
+	s := &Session{
+		Findings:      []findings.Finding{{ID: "f1"}, {ID: "f2"}},
+		PromptShadows: []PromptShadow{{FindingID: "f1", PromptContext: "ctx"}},
+	}
+	if AddComment(s, "missing", findings.Comment{Text: "x"}) {
+		t.Error("AddComment(missing) = true, want false")
+	}
+	if !AddComment(s, "f1", findings.Comment{Author: "Dev", At: "2025-01-02T10:00:00Z", Text: "Caller checks nil"}) ||
+		!AddComment(s, "f1", findings.Comment{Author: "Lead", At: "2025-01-02T11:00:00Z", Text: "agreed"}) {
+		t.Fatal("AddComment(f1) = false, want true")
+	}
+	if got := Comments(s, "f1"); len(got) != 2 || got[1].Author != "Lead" {
+		t.Errorf("Comments(f1) = %+v, want 2 comments in order", got)
+	}
+	if got := Comments(s, "f2"); got != nil {
+		t.Errorf("Comments(f2) = %+v, want nil", got)
+	}
+	if got := s.PromptShadows[0].Note; got != "Caller checks nil; agreed" {
+		t.Errorf("shadow note = %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"time"
+
+	"stet/cli/internal/findings"
+)
+
+// FindingsWithStatus returns a copy of s.Findings with Status set to each
+// finding's current status at now (see findings.CurrentStatus). Findings in
+// DismissedIDs without a fixed or dismissed status (sessions saved before
+// statuses existed) are dismissed.
+func FindingsWithStatus(s *Session, now time.Time) []findings.Finding {
+	dismissed := make(map[string]struct{}, len(s.DismissedIDs))
+	for _, id := range s.DismissedIDs {
+		dismissed[id] = struct{}{}
+	}
+	out := make([]findings.Finding, len(s.Findings))
+	for i, f := range s.Findings {
+		if _, ok := dismissed[f.ID]; ok && f.Status != findings.StatusFixed {
+			f.Status = findings.StatusDismissed
+		}
+		f.Status = findings.CurrentStatus(f, now)
+		if f.Status != findings.StatusDeferred {
+			f.DeferredUntil = ""
+		}
+		out[i] = f
+	}
+	return out
+}
+
+// SetStatus sets the status of the finding with id in s, with the deferral date
+// (StatusDeferred) or dismissal reason (StatusDismissed) it carries, and keeps
+// DismissedIDs in step: fixed and dismissed findings are in it, others are not,
+// so accepting or deferring a dismissed finding reopens it. Reports whether a
+// finding with id exists.
+func SetStatus(s *Session, id string, st findings.Status, until, reason string) bool {
+	found := false
+	for i := range s.Findings {
+		if s.Findings[i].ID != id {
+			continue
+		}
+		found = true
+		s.Findings[i].Status = st
+		s.Findings[i].DeferredUntil = ""
+		s.Findings[i].DismissReason = ""
+		switch st {
+		case findings.StatusDeferred:
+			s.Findings[i].DeferredUntil = until
+		case findings.StatusDismissed:
+			s.Findings[i].DismissReason = reason
+		}
+	}
+	if !found {
+		return false
+	}
+	closed := st == findings.StatusFixed || st == findings.StatusDismissed
+	kept := s.DismissedIDs[:0]
+	present := false
+	for _, d := range s.DismissedIDs {
+		if d == id {
+			if !closed || present {
+				continue
+			}
+			present = true
+		}
+		kept = append(kept, d)
+	}
+	s.DismissedIDs = kept
+	if closed && !present {
+		s.DismissedIDs = append(s.DismissedIDs, id)
+	}
+	if len(s.DismissedIDs) == 0 {
+		s.DismissedIDs = nil
+	}
+	return true
+}
@@ -6,6 +6,7 @@ package stats
 import (
 	"os"
 
+	"stet/cli/internal/findings"
 	"stet/cli/internal/history"
 )
 
@@ -22,11 +23,19 @@ type QualityResult struct {
 	FindingDensity    float64            `json:"finding_density,omitempty"` // Omitted when no tokens.
 	DismissalsByReason map[string]int     `json:"dismissals_by_reason"`
 	CategoryBreakdown  map[string]int     `json:"category_breakdown"`
+	StatusBreakdown    map[string]int     `json:"status_breakdown,omitempty"` // Latest status per finding; omitted when history has none.
 }
 
 // Quality reads .review/history.jsonl from stateDir (including rotated archives),
 // aggregates findings and dismissals, and returns quality metrics. When stateDir
 // does not exist or history is empty, returns a result with zero counts and no error.
+//
+// Actionability is the share of decided findings (accepted, fixed, deferred or
+// dismissed, by the latest status recorded for each finding id) that were acted
+// on or kept for later. History without finding statuses falls back to the
+// share of dismissals with reason already_correct. Status records (accept,
+// defer, comment; see history.Kind) only update finding statuses: they are not
+// sessions and their findings are not counted again.
 func Quality(stateDir string) (*QualityResult, error) {
 	if _, err := os.Stat(stateDir); err != nil && os.IsNotExist(err) {
 		return &QualityResult{
@@ -44,7 +53,17 @@ func Quality(stateDir string) (*QualityResult, error) {
 	}
 	var sessionsWithZeroFindings int
 	var tokensReviewed int64
+	latestStatus := make(map[string]findings.Status)
 	for _, rec := range records {
+		if history.Kind(rec) == history.KindStatus {
+			// accept, defer and comment records: only the latest status counts.
+			for _, f := range rec.ReviewOutput {
+				if f.Status != "" && f.ID != "" {
+					latestStatus[f.ID] = f.Status
+				}
+			}
+			continue
+		}
 		res.SessionsCount++
 		nFindings := len(rec.ReviewOutput)
 		res.TotalFindings += nFindings
@@ -59,6 +78,9 @@ func Quality(stateDir string) (*QualityResult, error) {
 		}
 		for _, f := range rec.ReviewOutput {
 			res.CategoryBreakdown[string(f.Category)]++
+			if f.Status != "" && f.ID != "" {
+				latestStatus[f.ID] = f.Status
+			}
 		}
 		// Tokens: prefer Record-level; fall back to Usage.
 		var prompt, completion int64
@@ -81,7 +103,16 @@ func Quality(stateDir string) (*QualityResult, error) {
 		res.AcceptanceRate = max(float64(res.TotalFindings-res.TotalDismissed)/float64(res.TotalFindings), 0.0)
 		res.FalsePositiveRate = min(float64(res.DismissalsByReason[history.ReasonFalsePositive])/float64(res.TotalFindings), 1.0)
 	}
-	if res.TotalDismissed > 0 {
+	if len(latestStatus) > 0 {
+		res.StatusBreakdown = map[string]int{}
+		for _, st := range latestStatus {
+			res.StatusBreakdown[string(st)]++
+		}
+		acted := res.StatusBreakdown[string(findings.StatusAccepted)] + res.StatusBreakdown[string(findings.StatusFixed)] + res.StatusBreakdown[string(findings.StatusDeferred)]
+		if decided := acted + res.StatusBreakdown[string(findings.StatusDismissed)]; decided > 0 {
+			res.Actionability = float64(acted) / float64(decided)
+		}
+	} else if res.TotalDismissed > 0 {
 		res.Actionability = min(float64(res.DismissalsByReason[history.ReasonAlreadyCorrect])/float64(res.TotalDismissed), 1.0)
 	}
 	if res.SessionsCount > 0 {
@@ -38,7 +38,7 @@ func TestQuality_fixtureHistory(t *testing.T) {
 	rec2 := history.Record{
 		DiffRef:      "HEAD~1",
 		ReviewOutput: nil,
-		UserAction:   history.UserAction{},
+		UserAction:   history.UserAction{FinishedAt: "2026-03-01T12:00:00Z"},
 	}
 	if err := history.Append(stateDir, rec1, 0); err != nil {
 		t.Fatalf("Append rec1: %v", err)
@@ -109,7 +109,7 @@ func TestQuality_zeroDenominators(t *testing.T) {
 	if err := history.Append(stateDir, history.Record{
 		DiffRef:      "HEAD",
 		ReviewOutput: nil,
-		UserAction:   history.UserAction{},
+		UserAction:   history.UserAction{FinishedAt: "2026-03-01T12:00:00Z"},
 	}, 0); err != nil {
 		t.Fatalf("Append: %v", err)
 	}
@@ -132,6 +132,76 @@ func TestQuality_zeroDenominators(t *testing.T) {
 	}
 }
 
+func TestQuality_statusActionability(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	// The run record has no statuses; the later finish record decides them.
+	recs := []history.Record{
+		{DiffRef: "HEAD", ReviewOutput: []findings.Finding{{ID: "f1"}, {ID: "f2"}, {ID: "f3"}, {ID: "f4"}, {ID: "f5"}}},
+		{
+			DiffRef: "HEAD",
+			ReviewOutput: []findings.Finding{
+				{ID: "f1", Status: findings.StatusFixed},
+				{ID: "f2", Status: findings.StatusAccepted},
+				{ID: "f3", Status: findings.StatusDeferred, DeferredUntil: "2030-01-01"},
+				{ID: "f4", Status: findings.StatusDismissed, DismissReason: history.ReasonFalsePositive},
+				{ID: "f5", Status: findings.StatusOpen},
+			},
+			UserAction: history.UserAction{DismissedIDs: []string{"f4"}, Dismissals: []history.Dismissal{{FindingID: "f4", Reason: history.ReasonFalsePositive}}},
+		},
+	}
+	for _, rec := range recs {
+		if err := history.Append(stateDir, rec, 0); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	res, err := Quality(stateDir)
+	if err != nil {
+		t.Fatalf("Quality: %v", err)
+	}
+	want := map[string]int{"fixed": 1, "accepted": 1, "deferred": 1, "dismissed": 1, "open": 1}
+	for k, v := range want {
+		if res.StatusBreakdown[k] != v {
+			t.Errorf("StatusBreakdown[%q] = %d, want %d (%v)", k, res.StatusBreakdown[k], v, res.StatusBreakdown)
+		}
+	}
+	// Open findings are undecided: 3 acted on out of 4 decided.
+	if res.Actionability != 0.75 {
+		t.Errorf("Actionability = %.2f, want 0.75", res.Actionability)
+	}
+}
+
+func TestQuality_statusRecordsNotCounted(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	out := []findings.Finding{{ID: "f1", Category: findings.CategoryBug}, {ID: "f2", Category: findings.CategoryStyle}, {ID: "f3", Category: findings.CategoryStyle}}
+	if err := history.Append(stateDir, history.Record{DiffRef: "HEAD", ReviewOutput: out, UserAction: history.UserAction{FinishedAt: "2026-03-01T12:00:00Z"}}, 0); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	before, err := Quality(stateDir)
+	if err != nil {
+		t.Fatalf("Quality: %v", err)
+	}
+	// stet accept f1: a status record, also when it holds a full snapshot (older history).
+	accepted := append([]findings.Finding(nil), out...)
+	accepted[0].Status = findings.StatusAccepted
+	for _, rec := range []history.Record{{DiffRef: "HEAD", ReviewOutput: accepted[:1]}, {DiffRef: "HEAD", ReviewOutput: accepted}} {
+		if err := history.Append(stateDir, rec, 0); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	after, err := Quality(stateDir)
+	if err != nil {
+		t.Fatalf("Quality: %v", err)
+	}
+	if after.TotalFindings != before.TotalFindings || after.SessionsCount != before.SessionsCount || after.TotalFindings != 3 || after.SessionsCount != 1 {
+		t.Errorf("after accept: TotalFindings=%d SessionsCount=%d, want %d and %d", after.TotalFindings, after.SessionsCount, before.TotalFindings, before.SessionsCount)
+	}
+	if after.CategoryBreakdown["style"] != 2 || after.StatusBreakdown["accepted"] != 1 {
+		t.Errorf("CategoryBreakdown = %v StatusBreakdown = %v, want style 2 and accepted 1", after.CategoryBreakdown, after.StatusBreakdown)
+	}
+}
+
 func TestQuality_emptyOrMissingHistory(t *testing.T) {
 	t.Parallel()
 	// Empty state dir (no history.jsonl): ReadRecords returns empty slice.
@@ -0,0 +1,146 @@
+// Package summary provides the optional whole-change summary pass: before the
+// per-hunk review, a compressed view of the entire diff (commit messages, file
+// list with line counts, hunk headers) is sent to the model once, and the
+// resulting summary is injected into every hunk's system prompt so that a hunk
+// in one file can be judged knowing what changed in the others.
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/llm"
+	"stet/cli/internal/ollama"
+)
+
+const (
+	// defaultMaxOverviewBytes caps the overview sent to the model when no budget is given.
+	defaultMaxOverviewBytes = 32 * 1024
+	// MaxSummaryChars caps the summary injected into every hunk prompt.
+	MaxSummaryChars = 2000
+	// maxCommitMsgChars caps each commit message in the overview.
+	maxCommitMsgChars = 500
+	truncatedMarker   = "[truncated]"
+)
+
+// SystemPrompt instructs the model to summarize the change for per-hunk reviewers.
+const SystemPrompt = `You summarize a code change for reviewers who will each see only one diff hunk at a time.
+You receive the commit messages, the changed files with added/removed line counts, and every hunk header (including the enclosing function when git provides one).
+Write at most 8 short bullet points, plain text, no markdown headings or code blocks:
+- The overall intent of the change.
+- The main changes, grouped by area or file.
+- Relationships across files that a reviewer of a single hunk needs (e.g. a function renamed or re-signatured in one file and its callers updated in others; a field added in one place and read elsewhere; code moved between files).
+Describe only what the change does. Do not speculate about bugs and do not give review advice.`
+
+// fileStat aggregates the hunks of one file for the overview.
+type fileStat struct {
+	path           string
+	note           string // diff.Hunk.StatusNote of the file, e.g. "renamed from x.go, 90% similar"
+	added, removed int
+	headers        []string
+}
+
+// BuildOverview returns the compressed whole-change view sent to the model:
+// commit messages (newest first, as from git.RevList), then each changed file
+// with its +/- line counts, file status (rename, new file, ...) and hunk
+// headers. Output is capped at maxBytes (0 = defaultMaxOverviewBytes); the
+// file list is cut first, with a marker.
+func BuildOverview(commitMsgs []string, hunks []diff.Hunk, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxOverviewBytes
+	}
+	var files []*fileStat
+	byPath := make(map[string]*fileStat)
+	for _, h := range hunks {
+		fs, ok := byPath[h.FilePath]
+		if !ok {
+			fs = &fileStat{path: h.FilePath, note: h.StatusNote()}
+			byPath[h.FilePath] = fs
+			files = append(files, fs)
+		}
+		lines := strings.Split(h.RawContent, "\n")
+		for i, line := range lines {
+			switch {
+			case i == 0 && strings.HasPrefix(line, "@@"):
+				fs.headers = append(fs.headers, strings.TrimSpace(line))
+			case strings.HasPrefix(line, "+"):
+				fs.added++
+			case strings.HasPrefix(line, "-"):
+				fs.removed++
+			}
+		}
+	}
+	var b strings.Builder
+	if len(commitMsgs) > 0 {
+		b.WriteString("## Commits (newest first)\n\n")
+		for _, msg := range commitMsgs {
+			msg = strings.TrimSpace(msg)
+			if len(msg) > maxCommitMsgChars {
+				msg = truncateUTF8(msg, maxCommitMsgChars) + " " + truncatedMarker
+			}
+			b.WriteString("- ")
+			b.WriteString(strings.ReplaceAll(msg, "\n", "\n  "))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "## Changed files (%d)\n\n", len(files))
+	for i, fs := range files {
+		var entry strings.Builder
+		fmt.Fprintf(&entry, "%s (+%d -%d", fs.path, fs.added, fs.removed)
+		if fs.note != "" {
+			entry.WriteString("; " + fs.note)
+		}
+		entry.WriteString(")\n")
+		for _, h := range fs.headers {
+			entry.WriteString("  ")
+			entry.WriteString(h)
+			entry.WriteString("\n")
+		}
+		if b.Len()+entry.Len() > maxBytes {
+			fmt.Fprintf(&b, "%s %d more files not shown\n", truncatedMarker, len(files)-i)
+			break
+		}
+		b.WriteString(entry.String())
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// Summarize asks the model for a whole-change summary of overview (from
+// BuildOverview). The result is trimmed and capped at MaxSummaryChars. opts
+// may be nil. Returns the generate result too so callers can account for tokens.
+func Summarize(ctx context.Context, client llm.Client, model, overview string, opts *ollama.GenerateOptions) (string, *ollama.GenerateResult, error) {
+	if client == nil {
+		return "", nil, errors.New("summary: nil client")
+	}
+	if strings.TrimSpace(overview) == "" {
+		return "", nil, errors.New("summary: empty overview")
+	}
+	res, err := client.GeneratePlain(ctx, model, SystemPrompt, overview, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	if res == nil {
+		return "", nil, errors.New("summary: unexpected nil result")
+	}
+	text := strings.TrimSpace(res.Response)
+	if len(text) > MaxSummaryChars {
+		text = strings.TrimSpace(truncateUTF8(text, MaxSummaryChars)) + " " + truncatedMarker
+	}
+	return text, res, nil
+}
+
+// truncateUTF8 returns s cut to at most maxBytes without splitting a rune.
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
@@ -0,0 +1,104 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/llm"
+)
+
+func TestBuildOverview_commitsFilesAndHeaders(t *testing.T) {
+	hunks := []diff.Hunk{
+		{FilePath: "a.go", RawContent: "@@ -1,3 +1,4 @@ func A() {\n ctx\n-old\n+new\n+more\n"},
+		{FilePath: "b.go", RawContent: "@@ -10,2 +10,1 @@\n-gone\n ctx\n", Status: diff.StatusRenamed, OldPath: "old_b.go", Similarity: 90},
+		{FilePath: "a.go", RawContent: "@@ -20,1 +21,1 @@ func B() {\n-x\n+y\n"},
+	}
+	got := BuildOverview([]string{"Rename A\n\nBody line", "Initial"}, hunks, 0)
+	for _, want := range []string{
+		"## Commits (newest first)",
+		"- Rename A\n  \n  Body line\n",
+		"- Initial\n",
+		"## Changed files (2)",
+		"a.go (+3 -2)\n  @@ -1,3 +1,4 @@ func A() {\n  @@ -20,1 +21,1 @@ func B() {\n",
+		"b.go (+0 -1; renamed from old_b.go, 90% similar)\n  @@ -10,2 +10,1 @@",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("overview missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "a.go") > strings.Index(got, "b.go") {
+		t.Errorf("files should keep first-seen order:\n%s", got)
+	}
+}
+
+func TestBuildOverview_noCommitsAndTruncation(t *testing.T) {
+	var hunks []diff.Hunk
+	for _, f := range []string{"one.go", "two.go", "three.go"} {
+		hunks = append(hunks, diff.Hunk{FilePath: f, RawContent: "@@ -1 +1 @@\n-a\n+b\n"})
+	}
+	got := BuildOverview(nil, hunks, 60)
+	if strings.Contains(got, "## Commits") {
+		t.Errorf("no commits: section should be omitted:\n%s", got)
+	}
+	if !strings.Contains(got, "one.go") || strings.Contains(got, "three.go") {
+		t.Errorf("expected only the first files within budget:\n%s", got)
+	}
+	if !strings.Contains(got, truncatedMarker+" 2 more files not shown") {
+		t.Errorf("expected truncation marker:\n%s", got)
+	}
+}
+
+func TestSummarize_nilClientAndEmptyOverview(t *testing.T) {
+	if _, _, err := Summarize(context.Background(), nil, "m", "x", nil); err == nil {
+		t.Error("Summarize with nil client: want error")
+	}
+	client, _ := llm.NewClient("ollama", "http://127.0.0.1:1", nil)
+	if _, _, err := Summarize(context.Background(), client, "m", "  ", nil); err == nil {
+		t.Error("Summarize with empty overview: want error")
+	}
+}
+
+func TestSummarize_trimsAndCapsResponse(t *testing.T) {
+	var gotSystem string
+	response := "  - Adds retries\n" + strings.Repeat("é", MaxSummaryChars) + "\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			System string `json:"system"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotSystem = req.System
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"response":          response,
+			"done":              true,
+			"prompt_eval_count": 12,
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := llm.NewClient("ollama", srv.URL, srv.Client())
+	got, res, err := Summarize(context.Background(), client, "m", "## Changed files (1)\na.go (+1 -0)", nil)
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if gotSystem != SystemPrompt {
+		t.Errorf("system prompt not sent")
+	}
+	if !strings.HasPrefix(got, "- Adds retries") || !strings.HasSuffix(got, truncatedMarker) {
+		t.Errorf("summary should be trimmed and capped; got prefix %q", got[:20])
+	}
+	if len(got) > MaxSummaryChars+len(truncatedMarker)+1 {
+		t.Errorf("summary len %d exceeds cap", len(got))
+	}
+	if res == nil || res.PromptEvalCount != 12 {
+		t.Errorf("result = %+v, want PromptEvalCount 12", res)
+	}
+}
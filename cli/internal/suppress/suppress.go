@@ -0,0 +1,269 @@
+// Package suppress recognizes inline suppression directives in source comments,
+// such as "// stet:ignore security -- input is validated upstream" or
+// "# stet:ignore-next-line", and drops the findings they cover.
+package suppress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"stet/cli/internal/findings"
+	"stet/cli/internal/hunkid"
+)
+
+// Kind is the form of a directive.
+type Kind string
+
+const (
+	// KindIgnore ("stet:ignore") covers its own line; a directive alone on its
+	// line also covers the next line.
+	KindIgnore Kind = "ignore"
+	// KindIgnoreNextLine ("stet:ignore-next-line") covers the next line only.
+	KindIgnoreNextLine Kind = "ignore-next-line"
+)
+
+// Directive is one suppression comment in a file.
+type Directive struct {
+	File       string
+	Line       int // 1-based line of the comment
+	Kind       Kind
+	Categories []string // lowercased; empty means every category
+	Reason     string   // text after "--", if any
+	standalone bool     // the comment is the only content on its line
+}
+
+// directiveRe matches the directive text after its comment marker. Group 1 is the
+// kind suffix, group 2 the rest of the comment (categories and reason).
+var directiveRe = regexp.MustCompile(`^\s*stet:ignore(-next-line)?(?:\s+(.*))?$`)
+
+// commentMarkers returns the line comment markers for the language of path
+// (hunkid.LangFromPath). Unknown languages accept both "//" and "#".
+func commentMarkers(path string) []string {
+	switch hunkid.LangFromPath(path) {
+	case "go", "js", "rust":
+		return []string{"//", "/*"}
+	case "python", "sh":
+		return []string{"#"}
+	default:
+		return []string{"//", "/*", "#"}
+	}
+}
+
+// Parse returns the directives in content, the text of the file at path.
+func Parse(path, content string) []Directive {
+	if !strings.Contains(content, "stet:ignore") {
+		return nil
+	}
+	markers := commentMarkers(path)
+	var out []Directive
+	for i, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		k := strings.Index(line, "stet:ignore")
+		if k < 0 {
+			continue
+		}
+		// The directive must directly follow a comment marker ("// stet:ignore").
+		before := strings.TrimRight(line[:k], " \t")
+		for _, m := range markers {
+			if !strings.HasSuffix(before, m) {
+				continue
+			}
+			comment := line[k:]
+			if m == "/*" {
+				comment, _, _ = strings.Cut(comment, "*/")
+			}
+			sub := directiveRe.FindStringSubmatch(strings.TrimSpace(comment))
+			if sub == nil {
+				break
+			}
+			d := Directive{File: path, Line: i + 1, Kind: KindIgnore, standalone: strings.TrimSpace(strings.TrimSuffix(before, m)) == ""}
+			if sub[1] != "" {
+				d.Kind = KindIgnoreNextLine
+			}
+			rest := sub[2]
+			if j := strings.Index(rest, "--"); j >= 0 {
+				d.Reason = strings.TrimSpace(rest[j+2:])
+				rest = rest[:j]
+			}
+			for _, c := range strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+				d.Categories = append(d.Categories, strings.ToLower(c))
+			}
+			out = append(out, d)
+			break
+		}
+	}
+	return out
+}
+
+// Covers reports whether the directive applies to code on line.
+func (d Directive) Covers(line int) bool {
+	for _, l := range d.coveredLines() {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
+
+func (d Directive) coveredLines() []int {
+	switch {
+	case d.Kind == KindIgnoreNextLine:
+		return []int{d.Line + 1}
+	case d.standalone:
+		return []int{d.Line, d.Line + 1}
+	default:
+		return []int{d.Line}
+	}
+}
+
+// Suppresses reports whether the directive drops f: f is in the directive's
+// file, one of its lines (Line or Range) is covered, and its category is listed
+// (or no categories are listed).
+func (d Directive) Suppresses(f findings.Finding) bool {
+	if f.File != d.File {
+		return false
+	}
+	if len(d.Categories) > 0 {
+		match := false
+		for _, c := range d.Categories {
+			if c == strings.ToLower(string(f.Category)) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	lo, hi := f.Line, f.Line
+	if f.Range != nil {
+		lo, hi = f.Range.Start, f.Range.End
+	}
+	if lo <= 0 {
+		return false
+	}
+	for line := lo; line <= hi; line++ {
+		if d.Covers(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns "file:line" for trace and warning output.
+func (d Directive) String() string {
+	return fmt.Sprintf("%s:%d", d.File, d.Line)
+}
+
+// Tracker applies the directives of the files under a repository root across
+// one review and records which directives were used, for suppressed counts and
+// stale suppression warnings. Files are read from the working tree on first
+// use; unreadable files have no directives. Not safe for concurrent use.
+type Tracker struct {
+	repoRoot   string
+	directives map[string][]Directive
+	used       map[string]int      // Directive.String() -> findings suppressed
+	reviewed   map[string][][2]int // file -> reviewed new-file line ranges
+	suppressed int
+}
+
+// NewTracker returns a Tracker reading files under repoRoot.
+func NewTracker(repoRoot string) *Tracker {
+	return &Tracker{repoRoot: repoRoot, directives: map[string][]Directive{}, used: map[string]int{}, reviewed: map[string][][2]int{}}
+}
+
+func (t *Tracker) fileDirectives(file string) []Directive {
+	if ds, ok := t.directives[file]; ok {
+		return ds
+	}
+	var ds []Directive
+	if data, err := os.ReadFile(filepath.Join(t.repoRoot, filepath.FromSlash(file))); err == nil {
+		ds = Parse(file, string(data))
+	}
+	t.directives[file] = ds
+	return ds
+}
+
+// Filter returns the findings no directive suppresses. A nil Tracker returns
+// list unchanged.
+func (t *Tracker) Filter(list []findings.Finding) []findings.Finding {
+	if t == nil || len(list) == 0 {
+		return list
+	}
+	kept := list[:0:0]
+	for _, f := range list {
+		suppressed := false
+		for _, d := range t.fileDirectives(f.File) {
+			if d.Suppresses(f) {
+				t.used[d.String()]++
+				suppressed = true
+				break
+			}
+		}
+		if suppressed {
+			t.suppressed++
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// Reviewed records that lines start..end of file were reviewed, so directives
+// covering them can be reported as stale when they suppress nothing.
+func (t *Tracker) Reviewed(file string, start, end int) {
+	if t == nil {
+		return
+	}
+	t.reviewed[file] = append(t.reviewed[file], [2]int{start, end})
+}
+
+// Suppressed returns the number of findings dropped by directives so far.
+func (t *Tracker) Suppressed() int {
+	if t == nil {
+		return 0
+	}
+	return t.suppressed
+}
+
+// Stale returns the directives whose covered lines were reviewed but that
+// suppressed no finding, sorted by file and line. Since the model does not
+// report the same issues on every run, these are candidates for removal only.
+func (t *Tracker) Stale() []Directive {
+	if t == nil {
+		return nil
+	}
+	var out []Directive
+	for file, ranges := range t.reviewed {
+		for _, d := range t.fileDirectives(file) {
+			if t.used[d.String()] > 0 {
+				continue
+			}
+			if coversReviewed(d, ranges) {
+				out = append(out, d)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
+
+// coversReviewed reports whether a line d covers lies in one of ranges.
+func coversReviewed(d Directive, ranges [][2]int) bool {
+	for _, l := range d.coveredLines() {
+		for _, r := range ranges {
+			if l >= r[0] && l <= r[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
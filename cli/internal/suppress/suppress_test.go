@@ -0,0 +1,132 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"stet/cli/internal/findings"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []Directive
+	}{
+		{
+			name:    "go trailing with category and reason",
+			path:    "a.go",
+			content: "package a\nx := exec.Command(s) // stet:ignore security -- s is a constant\n",
+			want:    []Directive{{File: "a.go", Line: 2, Kind: KindIgnore, Categories: []string{"security"}, Reason: "s is a constant"}},
+		},
+		{
+			name:    "python next line, several categories",
+			path:    "a.py",
+			content: "# stet:ignore-next-line Security, performance\nx = eval(s)\n",
+			want:    []Directive{{File: "a.py", Line: 1, Kind: KindIgnoreNextLine, Categories: []string{"security", "performance"}, standalone: true}},
+		},
+		{
+			name:    "block comment",
+			path:    "a.ts",
+			content: "foo(); /* stet:ignore -- legacy */ bar();\n",
+			want:    []Directive{{File: "a.ts", Line: 1, Kind: KindIgnore, Reason: "legacy"}},
+		},
+		{
+			name:    "url before comment",
+			path:    "a.rs",
+			content: "let u = \"http://x\"; // stet:ignore\n",
+			want:    []Directive{{File: "a.rs", Line: 1, Kind: KindIgnore}},
+		},
+		{
+			name:    "hash is not a go comment",
+			path:    "a.go",
+			content: "# stet:ignore\n",
+		},
+		{
+			name:    "unknown language accepts hash",
+			path:    "config.yaml",
+			content: "key: 1 # stet:ignore\n",
+			want:    []Directive{{File: "config.yaml", Line: 1, Kind: KindIgnore}},
+		},
+		{
+			name:    "mention in a string",
+			path:    "a.go",
+			content: "s := \"stet:ignore\"\n// see stet:ignore docs\n// stet:ignored\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.path, tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirectiveSuppresses(t *testing.T) {
+	t.Parallel()
+	ds := Parse("a.go", "// stet:ignore security\nrun(x)\ny := 1 // stet:ignore\n// stet:ignore-next-line\nz()\n")
+	tests := []struct {
+		name string
+		f    findings.Finding
+		want bool
+	}{
+		{"line after standalone ignore", findings.Finding{File: "a.go", Line: 2, Category: findings.CategorySecurity}, true},
+		{"category not listed", findings.Finding{File: "a.go", Line: 2, Category: findings.CategoryPerformance}, false},
+		{"trailing ignore, same line", findings.Finding{File: "a.go", Line: 3, Category: findings.CategoryStyle}, true},
+		{"trailing ignore does not cover next line", findings.Finding{File: "a.go", Line: 4, Category: findings.CategoryStyle}, false},
+		{"next-line", findings.Finding{File: "a.go", Line: 5, Category: findings.CategoryBug}, true},
+		{"range overlapping", findings.Finding{File: "a.go", Line: 6, Range: &findings.LineRange{Start: 5, End: 7}, Category: findings.CategoryBug}, true},
+		{"other file", findings.Finding{File: "b.go", Line: 3}, false},
+		{"file-level finding", findings.Finding{File: "a.go"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := false
+			for _, d := range ds {
+				if d.Suppresses(tt.f) {
+					got = true
+				}
+			}
+			if got != tt.want {
+				t.Errorf("suppressed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+	repo := t.TempDir()
+	content := "package a\n\nfunc f() {\n\tx() // stet:ignore\n\t// stet:ignore-next-line correctness\n\ty()\n}\n\n// stet:ignore\nfunc g() {}\n"
+	if err := os.WriteFile(filepath.Join(repo, "a.go"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tr := NewTracker(repo)
+	tr.Reviewed("a.go", 3, 7)
+	kept := tr.Filter([]findings.Finding{
+		{ID: "1", File: "a.go", Line: 4, Category: findings.CategoryBug},
+		{ID: "2", File: "a.go", Line: 6, Category: findings.CategoryStyle},
+		{ID: "3", File: "missing.go", Line: 1},
+	})
+	if len(kept) != 2 || kept[0].ID != "2" || kept[1].ID != "3" {
+		t.Errorf("Filter kept %+v, want findings 2 and 3", kept)
+	}
+	if tr.Suppressed() != 1 {
+		t.Errorf("Suppressed = %d, want 1", tr.Suppressed())
+	}
+	// Line 5 covers reviewed line 6 but suppressed nothing; line 9 is outside the reviewed range.
+	stale := tr.Stale()
+	if len(stale) != 1 || stale[0].String() != "a.go:5" {
+		t.Errorf("Stale = %v, want [a.go:5]", stale)
+	}
+	var nilTracker *Tracker
+	if got := nilTracker.Filter(kept); len(got) != 2 || nilTracker.Suppressed() != 0 || nilTracker.Stale() != nil {
+		t.Error("nil Tracker should be a no-op")
+	}
+}
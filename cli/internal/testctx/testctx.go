@@ -0,0 +1,467 @@
+// Package testctx locates tests related to a diff hunk so the reviewer can see
+// how the changed code is exercised. The hunk's enclosing function is found
+// first (go/ast for Go, a line scan for Python and JS/TS); then:
+//   - Go: test functions in *_test.go files that call it (git grep);
+//   - Python: test_<name>.py / <name>_test.py files, test functions naming it;
+//   - JS/TS: <name>.spec.* / <name>.test.* files, it()/test() blocks naming it.
+//
+// All lookups are best-effort: errors yield an empty result, never a failed review.
+package testctx
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet/cli/internal/diff"
+	"stet/cli/internal/expand"
+	"stet/cli/internal/git"
+)
+
+const (
+	gitTimeout      = 5 * time.Second
+	maxSnippetLines = 60
+	maxTestFileSize = 1024 * 1024 // 1 MiB, same as expand
+	truncateMarker  = "... (truncated)"
+)
+
+// Snippet is one related test: the test function or block that references the
+// changed function, with its 1-based start line.
+type Snippet struct {
+	File    string
+	Line    int
+	Name    string
+	Content string
+}
+
+// Result is the outcome of a related-test search for one hunk.
+type Result struct {
+	// Subject is the enclosing function of the hunk ("" when none was found).
+	Subject string
+	// Searched lists the test files (or, for Go, the "*_test.go" pathspec) that were searched.
+	Searched []string
+	// Snippets are the related tests, at most the requested maximum.
+	Snippets []Snippet
+}
+
+// language describes how to find the enclosing function and related tests.
+type language struct {
+	// funcDecl matches a function declaration line; the first non-empty group is the name.
+	funcDecl []*regexp.Regexp
+	// testStart matches the first line of a test function or block.
+	testStart *regexp.Regexp
+	// braces is true when blocks end with a closing brace line rather than a dedent.
+	braces bool
+	// testFiles returns candidate test file names for the base name without extension.
+	testFiles func(stem string) []string
+	// isTest reports whether a file name is itself a test file.
+	isTest func(base string) bool
+}
+
+var jsExts = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"}
+
+var python = &language{
+	funcDecl: []*regexp.Regexp{
+		regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)\s*\(`),
+	},
+	testStart: regexp.MustCompile(`^\s*(?:async\s+)?def\s+test\w*\s*\(`),
+	testFiles: func(stem string) []string {
+		return []string{"test_" + stem + ".py", stem + "_test.py"}
+	},
+	isTest: func(base string) bool {
+		return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py") || base == "conftest.py"
+	},
+}
+
+var javascript = &language{
+	funcDecl: []*regexp.Regexp{
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)`),
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*(?::[^=]+)?=>|[A-Za-z_$][\w$]*\s*=>)`),
+		regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|async|override|readonly)\s+)*([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\([^)]*\)\s*(?::\s*[^{]+)?\{\s*$`),
+	},
+	testStart: regexp.MustCompile(`^\s*(?:it|test)(?:\.\w+)?\s*\(`),
+	braces:    true,
+	testFiles: func(stem string) []string {
+		var out []string
+		for _, kind := range []string{".spec", ".test"} {
+			for _, ext := range jsExts {
+				out = append(out, stem+kind+ext)
+			}
+		}
+		return out
+	},
+	isTest: func(base string) bool {
+		stem := strings.TrimSuffix(base, path.Ext(base))
+		return strings.HasSuffix(stem, ".spec") || strings.HasSuffix(stem, ".test")
+	},
+}
+
+// jsKeywords are control-flow words the method pattern would otherwise take for names.
+var jsKeywords = map[string]bool{"if": true, "for": true, "while": true, "switch": true, "catch": true, "function": true, "return": true, "with": true}
+
+func languageFor(filePath string) *language {
+	ext := strings.ToLower(path.Ext(filePath))
+	if ext == ".py" {
+		return python
+	}
+	for _, e := range jsExts {
+		if ext == e {
+			return javascript
+		}
+	}
+	return nil
+}
+
+// FindRelated returns up to max tests related to the hunk in filePath
+// (repo-relative). hunkContent is the raw hunk including its @@ header.
+// Unsupported languages and test files themselves yield an empty Result.
+func FindRelated(ctx context.Context, repoRoot, filePath, hunkContent string, max int) Result {
+	if repoRoot == "" || filePath == "" || max <= 0 {
+		return Result{}
+	}
+	start, end, ok := expand.HunkLineRange(diff.Hunk{FilePath: filePath, RawContent: hunkContent})
+	if !ok {
+		return Result{}
+	}
+	filePath = filepath.ToSlash(filePath)
+	base := path.Base(filePath)
+	if path.Ext(filePath) == ".go" {
+		if strings.HasSuffix(base, "_test.go") {
+			return Result{}
+		}
+		return findGo(ctx, repoRoot, filePath, start, end, max)
+	}
+	lang := languageFor(filePath)
+	if lang == nil || lang.isTest(base) {
+		return Result{}
+	}
+	return findByConvention(ctx, repoRoot, filePath, lang, start, end, max)
+}
+
+// findGo greps *_test.go files for calls to the enclosing function and returns
+// the test functions containing them, tests in the same directory first.
+func findGo(ctx context.Context, repoRoot, filePath string, start, end, max int) Result {
+	funcName, ok := expand.EnclosingFuncName(repoRoot, filePath, start, end)
+	if !ok {
+		return Result{}
+	}
+	name := funcName
+	if i := strings.LastIndex(name, ")."); i >= 0 {
+		name = name[i+2:]
+	}
+	res := Result{Subject: funcName, Searched: []string{"*_test.go"}}
+	pattern := `(^|[^A-Za-z0-9_])` + regexp.QuoteMeta(name) + `[[:space:]]*\(`
+	out, err := runGit(ctx, repoRoot, "grep", "-n", "-E", pattern, "--", "*_test.go")
+	if err != nil || out == "" {
+		return res
+	}
+	type hit struct {
+		file string
+		line int
+	}
+	var hits []hit
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		file, n, ok := parseGrepLine(l)
+		if ok {
+			hits = append(hits, hit{file, n})
+		}
+	}
+	dir := path.Dir(filePath)
+	sort.SliceStable(hits, func(i, j int) bool {
+		return path.Dir(hits[i].file) == dir && path.Dir(hits[j].file) != dir
+	})
+	parsed := make(map[string]*goTestFile)
+	seen := make(map[string]bool)
+	for _, h := range hits {
+		if len(res.Snippets) >= max {
+			break
+		}
+		tf, ok := parsed[h.file]
+		if !ok {
+			tf = parseGoTestFile(filepath.Join(repoRoot, filepath.FromSlash(h.file)))
+			parsed[h.file] = tf
+		}
+		if tf == nil {
+			continue
+		}
+		fn := tf.enclosing(h.line)
+		if fn == nil {
+			continue
+		}
+		startLine := tf.fset.Position(fn.Pos()).Line
+		key := h.file + ":" + strconv.Itoa(startLine)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		endLine := tf.fset.Position(fn.End()).Line
+		res.Snippets = append(res.Snippets, Snippet{
+			File:    h.file,
+			Line:    startLine,
+			Name:    fn.Name.Name,
+			Content: sliceLines(tf.lines, startLine, endLine),
+		})
+	}
+	return res
+}
+
+type goTestFile struct {
+	fset  *token.FileSet
+	file  *ast.File
+	lines []string
+}
+
+func parseGoTestFile(absPath string) *goTestFile {
+	src, err := readSmallFile(absPath)
+	if err != nil {
+		return nil
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, absPath, src, 0)
+	if err != nil {
+		return nil
+	}
+	return &goTestFile{fset: fset, file: f, lines: strings.Split(string(src), "\n")}
+}
+
+// enclosing returns the top-level function declaration containing line.
+func (tf *goTestFile) enclosing(line int) *ast.FuncDecl {
+	for _, d := range tf.file.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if tf.fset.Position(fn.Pos()).Line <= line && line <= tf.fset.Position(fn.End()).Line {
+			return fn
+		}
+	}
+	return nil
+}
+
+// findByConvention looks for test files named after filePath anywhere in the
+// repo and returns the test blocks that mention the enclosing function.
+func findByConvention(ctx context.Context, repoRoot, filePath string, lang *language, start, end, max int) Result {
+	src, err := readSmallFile(filepath.Join(repoRoot, filepath.FromSlash(filePath)))
+	if err != nil {
+		return Result{}
+	}
+	subject := enclosingName(strings.Split(string(src), "\n"), lang, start, end)
+	stem := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	wanted := make(map[string]bool)
+	for _, name := range lang.testFiles(stem) {
+		wanted[name] = true
+	}
+	out, err := runGit(ctx, repoRoot, "ls-files", "-z")
+	if err != nil {
+		return Result{Subject: subject}
+	}
+	var candidates []string
+	for _, f := range strings.Split(strings.TrimSuffix(out, "\x00"), "\x00") {
+		if f != "" && wanted[path.Base(f)] {
+			candidates = append(candidates, f)
+		}
+	}
+	// Closest test files first: same directory, then by path.
+	dir := path.Dir(filePath)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := path.Dir(candidates[i]) == dir, path.Dir(candidates[j]) == dir
+		if si != sj {
+			return si
+		}
+		return candidates[i] < candidates[j]
+	})
+	res := Result{Subject: subject, Searched: candidates}
+	if subject == "" {
+		return res
+	}
+	mention := regexp.MustCompile(`(^|[^\w$])` + regexp.QuoteMeta(subject) + `([^\w$]|$)`)
+	for _, c := range candidates {
+		if len(res.Snippets) >= max {
+			break
+		}
+		data, err := readSmallFile(filepath.Join(repoRoot, filepath.FromSlash(c)))
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(string(data), "\n")
+		seen := make(map[int]bool)
+		for i, l := range lines {
+			if len(res.Snippets) >= max {
+				break
+			}
+			if !mention.MatchString(l) {
+				continue
+			}
+			blockStart := testBlockStart(lines, lang, i)
+			if blockStart < 0 || seen[blockStart] {
+				continue
+			}
+			seen[blockStart] = true
+			blockEnd := testBlockEnd(lines, lang, blockStart)
+			res.Snippets = append(res.Snippets, Snippet{
+				File:    c,
+				Line:    blockStart + 1,
+				Name:    strings.TrimSpace(lines[blockStart]),
+				Content: sliceLines(lines, blockStart+1, blockEnd+1),
+			})
+		}
+	}
+	return res
+}
+
+// enclosingName returns the name of the nearest function declared at or above
+// the hunk's first line; when there is none, the first one declared inside the
+// hunk (a newly added function). start and end are 1-based.
+func enclosingName(lines []string, lang *language, start, end int) string {
+	if start > len(lines) {
+		return ""
+	}
+	for i := start - 1; i >= 0; i-- {
+		if name := declaredName(lines[i], lang); name != "" {
+			return name
+		}
+	}
+	for i := start; i < end && i < len(lines); i++ {
+		if name := declaredName(lines[i], lang); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func declaredName(line string, lang *language) string {
+	for _, re := range lang.funcDecl {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, g := range m[1:] {
+			if g != "" && !jsKeywords[g] {
+				return g
+			}
+		}
+	}
+	return ""
+}
+
+// testBlockStart returns the index of the test start line at or above i whose
+// block contains i, or -1.
+func testBlockStart(lines []string, lang *language, i int) int {
+	for j := i; j >= 0; j-- {
+		if lang.testStart.MatchString(lines[j]) {
+			if testBlockEnd(lines, lang, j) >= i {
+				return j
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// testBlockEnd returns the index of the last line of the block starting at
+// start: the closing brace line at the same indentation for brace languages,
+// otherwise the last line before the next non-blank line indented no deeper.
+func testBlockEnd(lines []string, lang *language, start int) int {
+	indent := indentation(lines[start])
+	last := start
+	for j := start + 1; j < len(lines); j++ {
+		l := lines[j]
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if lang.braces {
+			trimmed := strings.TrimSpace(l)
+			if indentation(l) == indent && (strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, ")")) {
+				return j
+			}
+			if indentation(l) < indent {
+				return last
+			}
+		} else if indentation(l) <= indent {
+			return last
+		}
+		last = j
+	}
+	return last
+}
+
+func indentation(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
+
+// sliceLines returns lines start..end (1-based, inclusive), capped at maxSnippetLines.
+func sliceLines(lines []string, start, end int) string {
+	if start < 1 {
+		start = 1
+	}
+	if end > len(lines) {
+		end = len(lines)
+	}
+	if end < start {
+		return ""
+	}
+	truncated := false
+	if end-start+1 > maxSnippetLines {
+		end = start + maxSnippetLines - 1
+		truncated = true
+	}
+	s := strings.Join(lines[start-1:end], "\n")
+	if truncated {
+		s += "\n" + truncateMarker
+	}
+	return s
+}
+
+func readSmallFile(absPath string) ([]byte, error) {
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxTestFileSize {
+		return nil, os.ErrInvalid
+	}
+	return os.ReadFile(absPath)
+}
+
+// parseGrepLine splits "path:line:content" from git grep -n.
+func parseGrepLine(line string) (file string, n int, ok bool) {
+	parts := strings.SplitN(line, ":", 3)
+	if len(parts) < 3 || parts[0] == "" {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil || n < 1 {
+		return "", 0, false
+	}
+	return parts[0], n, true
+}
+
+// runGit runs git in repoRoot and returns stdout. git grep's "no match" exit
+// status 1 is returned as empty output.
+func runGit(ctx context.Context, repoRoot string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoRoot
+	cmd.Env = git.MinimalEnv()
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		if e, ok := err.(*exec.ExitError); ok && e.ExitCode() == 1 && args[0] == "grep" {
+			return "", nil
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,156 @@
+package testctx
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindRelated_goCallers(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"calc/calc.go":      "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n\nfunc Sub(a, b int) int {\n\treturn a - b\n}\n",
+		"calc/calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"bad\")\n\t}\n\tif Add(2, 2) != 4 {\n\t\tt.Fatal(\"bad\")\n\t}\n}\n",
+		"other/use_test.go": "package other\n\nimport (\n\t\"testing\"\n\n\t\"example.com/calc\"\n)\n\nfunc TestUse(t *testing.T) {\n\t_ = calc.Add(1, 1)\n}\n",
+	})
+	hunk := "@@ -3,3 +3,3 @@\n func Add(a, b int) int {\n-\treturn b + a\n+\treturn a + b\n }\n"
+	res := FindRelated(context.Background(), dir, "calc/calc.go", hunk, 5)
+	if res.Subject != "Add" {
+		t.Errorf("Subject = %q, want Add", res.Subject)
+	}
+	if len(res.Snippets) != 2 {
+		t.Fatalf("got %d snippets, want 2 (one per test function): %+v", len(res.Snippets), res.Snippets)
+	}
+	first := res.Snippets[0]
+	if first.File != "calc/calc_test.go" || first.Name != "TestAdd" || first.Line != 5 {
+		t.Errorf("first snippet = %+v, want TestAdd in calc/calc_test.go line 5 (same package first)", first)
+	}
+	if !strings.HasPrefix(first.Content, "func TestAdd(") || !strings.HasSuffix(first.Content, "}") {
+		t.Errorf("snippet content should be the whole test function; got %q", first.Content)
+	}
+	if res.Snippets[1].Name != "TestUse" {
+		t.Errorf("second snippet = %+v, want TestUse", res.Snippets[1])
+	}
+
+	res = FindRelated(context.Background(), dir, "calc/calc.go", hunk, 1)
+	if len(res.Snippets) != 1 {
+		t.Errorf("max=1: got %d snippets", len(res.Snippets))
+	}
+
+	subHunk := "@@ -7,3 +7,3 @@\n func Sub(a, b int) int {\n-\treturn b - a\n+\treturn a - b\n }\n"
+	res = FindRelated(context.Background(), dir, "calc/calc.go", subHunk, 5)
+	if res.Subject != "Sub" || len(res.Snippets) != 0 {
+		t.Errorf("untested Sub: got subject %q, %d snippets; want Sub, 0", res.Subject, len(res.Snippets))
+	}
+}
+
+func TestFindRelated_pythonAndTypeScript(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"app/parser.py":        "import re\n\n\ndef parse(text):\n    return text.split()\n\n\ndef unused():\n    pass\n",
+		"tests/test_parser.py": "from app.parser import parse\n\n\ndef test_parse_words():\n    assert parse(\"a b\") == [\"a\", \"b\"]\n\n\ndef test_other():\n    assert True\n",
+		"src/util.ts":          "export function clamp(x: number, lo: number, hi: number): number {\n  return Math.min(hi, Math.max(lo, x));\n}\n",
+		"src/util.spec.ts":     "import { clamp } from './util';\n\ndescribe('clamp', () => {\n  it('clamps high', () => {\n    expect(clamp(5, 0, 3)).toBe(3);\n  });\n\n  it('other', () => {\n    expect(1).toBe(1);\n  });\n});\n",
+	})
+	tests := []struct {
+		name        string
+		file        string
+		hunk        string
+		wantSubject string
+		wantFile    string
+		wantName    string
+		wantLines   int
+	}{
+		{
+			name:        "python",
+			file:        "app/parser.py",
+			hunk:        "@@ -4,2 +4,2 @@\n def parse(text):\n-    return text\n+    return text.split()\n",
+			wantSubject: "parse",
+			wantFile:    "tests/test_parser.py",
+			wantName:    "def test_parse_words():",
+			wantLines:   2,
+		},
+		{
+			name:        "typescript",
+			file:        "src/util.ts",
+			hunk:        "@@ -1,3 +1,3 @@\n export function clamp(x: number, lo: number, hi: number): number {\n-  return x;\n+  return Math.min(hi, Math.max(lo, x));\n }\n",
+			wantSubject: "clamp",
+			wantFile:    "src/util.spec.ts",
+			wantName:    "it('clamps high', () => {",
+			wantLines:   3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := FindRelated(context.Background(), dir, tt.file, tt.hunk, 5)
+			if res.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", res.Subject, tt.wantSubject)
+			}
+			if len(res.Searched) != 1 || res.Searched[0] != tt.wantFile {
+				t.Errorf("Searched = %v, want [%s]", res.Searched, tt.wantFile)
+			}
+			if len(res.Snippets) != 1 {
+				t.Fatalf("got %d snippets, want 1: %+v", len(res.Snippets), res.Snippets)
+			}
+			s := res.Snippets[0]
+			if s.File != tt.wantFile || s.Name != tt.wantName {
+				t.Errorf("snippet = %+v, want %s %q", s, tt.wantFile, tt.wantName)
+			}
+			if n := len(strings.Split(s.Content, "\n")); n != tt.wantLines {
+				t.Errorf("snippet has %d lines, want %d:\n%s", n, tt.wantLines, s.Content)
+			}
+		})
+	}
+}
+
+func TestFindRelated_skipsTestFilesAndUnsupported(t *testing.T) {
+	dir := setupRepo(t, map[string]string{
+		"a_test.go": "package a\n\nfunc TestX() {}\n",
+		"lib.rs":    "fn x() {}\n",
+		"x.spec.ts": "it('x', () => {\n});\n",
+		"test_x.py": "def test_x():\n    pass\n",
+	})
+	hunk := "@@ -1,1 +1,1 @@\n+x\n"
+	for _, f := range []string{"a_test.go", "lib.rs", "x.spec.ts", "test_x.py"} {
+		if res := FindRelated(context.Background(), dir, f, hunk, 5); res.Subject != "" || len(res.Snippets) != 0 {
+			t.Errorf("FindRelated(%s) = %+v, want empty", f, res)
+		}
+	}
+}
+
+func TestSliceLines_truncates(t *testing.T) {
+	lines := make([]string, maxSnippetLines+10)
+	for i := range lines {
+		lines[i] = "x"
+	}
+	got := sliceLines(lines, 1, len(lines))
+	if !strings.HasSuffix(got, truncateMarker) {
+		t.Errorf("expected truncation marker; got %d lines", len(strings.Split(got, "\n")))
+	}
+	if n := len(strings.Split(got, "\n")); n != maxSnippetLines+1 {
+		t.Errorf("got %d lines, want %d", n, maxSnippetLines+1)
+	}
+}
+
+func setupRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}